@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"portofolio-go/internal/config"
+	"portofolio-go/internal/database"
+	"portofolio-go/internal/export"
+	"portofolio-go/internal/model"
+	"portofolio-go/internal/repository"
+	"portofolio-go/internal/service"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// runExport menjalankan subcommand "export": menulis pesan kontak ke file atau stdout
+// tanpa menyalakan server. Contoh:
+//
+//	portfolio-server export -format mbox -o pesan.mbox
+//	portfolio-server export -format csv -status new -from 2024-01-01 > pesan.csv
+func runExport(cfg *config.AppConfig, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	format := fs.String("format", export.FormatCSV, "format ekspor: "+strings.Join(export.Formats, ", "))
+	output := fs.String("o", "-", "file tujuan (- = stdout)")
+	status := fs.String("status", model.MessageAll, "status pesan: all, new, read, replied, archived (kosong = semua kecuali arsip)")
+	query := fs.String("q", "", "kata kunci pencarian (nama, email, isi pesan)")
+	from := fs.String("from", "", "tanggal awal YYYY-MM-DD (inklusif)")
+	to := fs.String("to", "", "tanggal akhir YYYY-MM-DD (inklusif)")
+	ids := fs.String("ids", "", "hanya pesan dengan ID ini, dipisah koma (misal: 3,7,12)")
+	fs.Parse(args)
+
+	if !export.SupportedFormat(*format) {
+		return fmt.Errorf("format tidak dikenal: %q (pilihan: %s)", *format, strings.Join(export.Formats, ", "))
+	}
+	filter := model.MessageFilter{Query: *query, Status: *status}
+	var err error
+	if filter.From, err = dateFlag("from", *from); err != nil {
+		return err
+	}
+	if filter.To, err = dateFlag("to", *to); err != nil {
+		return err
+	}
+	for _, value := range strings.Split(*ids, ",") {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("ID pesan tidak valid: %q", value)
+		}
+		filter.IDs = append(filter.IDs, id)
+	}
+
+	db, err := database.InitDB(cfg.DBPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	svc := service.NewService(repository.NewRepository(db), cfg, nil)
+
+	w := os.Stdout
+	if *output != "-" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("gagal membuat file ekspor: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	n, err := svc.ExportMessages(context.Background(), w, *format, filter)
+	if err != nil {
+		return err
+	}
+	if w != os.Stdout {
+		if err := w.Close(); err != nil {
+			return fmt.Errorf("gagal menyimpan file ekspor: %w", err)
+		}
+	}
+	slog.Info("Pesan diekspor", "count", n, "format", *format)
+	return nil
+}
+
+// dateFlag mem-parsing flag tanggal YYYY-MM-DD (kosong = tanpa batas)
+func dateFlag(name, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("flag -%s harus berformat YYYY-MM-DD: %q", name, value)
+	}
+	return &t, nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"portofolio-go/internal/config"
+	"time"
+)
+
+// runHealthcheck menjalankan subcommand "healthcheck": memanggil endpoint kesehatan
+// server yang berjalan di container/mesin yang sama lewat PORT dan keluar dengan status
+// 1 jika jawabannya bukan 200. Dipakai HEALTHCHECK Dockerfile sehingga image tidak butuh
+// curl/wget. Contoh:
+//
+//	portfolio-server healthcheck
+//	portfolio-server healthcheck -path /healthz -timeout 2s
+func runHealthcheck(cfg *config.AppConfig, args []string) error {
+	fs := flag.NewFlagSet("healthcheck", flag.ExitOnError)
+	path := fs.String("path", "/readyz", "endpoint yang diperiksa (/readyz atau /healthz)")
+	timeout := fs.Duration("timeout", 5*time.Second, "batas waktu menunggu jawaban")
+	fs.Parse(args)
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	// Port HTTP tetap menjawab endpoint kesehatan meski TLS aktif (lihat healthRoutes)
+	url := "http://127.0.0.1:" + cfg.Port + *path
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("gagal membuat request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("server tidak menjawab: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s menjawab %s", *path, resp.Status)
+	}
+	return nil
+}
@@ -1,22 +1,39 @@
 package main
 
 import (
-	"fmt"
-	"html/template"
-	"log"
-	"strings"
+	"context"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"portofolio-go/internal/assets"
+	"portofolio-go/internal/buildinfo"
+	"portofolio-go/internal/captcha"
 	"portofolio-go/internal/config"
 	"portofolio-go/internal/database"
 	"portofolio-go/internal/handler"
+	"portofolio-go/internal/i18n"
+	"portofolio-go/internal/jobs"
+	"portofolio-go/internal/logging"
+	"portofolio-go/internal/metrics"
 	"portofolio-go/internal/middleware"
+	"portofolio-go/internal/notify"
 	"portofolio-go/internal/repository"
 	"portofolio-go/internal/service"
+	"portofolio-go/internal/tracing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
+// cspReportRateLimit adalah batas laporan CSP per jam dari satu IP
+// (satu halaman bisa memicu beberapa laporan sekaligus)
+const cspReportRateLimit = 120
+
 func main() {
 	// Muat file .env jika ada (untuk development)
 	// Di production, environment variables diset langsung di sistem
@@ -25,6 +42,56 @@ func main() {
 	// Muat konfigurasi dari environment variables
 	cfg := config.LoadConfig()
 
+	// Log terstruktur: JSON di production, teks di development (LOG_FORMAT/LOG_LEVEL).
+	// slog.SetDefault ikut mengarahkan package log standar (dan library) ke logger ini
+	logger, err := logging.New(os.Stderr, cfg.LogFormat, cfg.LogLevel)
+	if err != nil {
+		fatal("Gagal menyiapkan log", "error", err)
+	}
+	slog.SetDefault(logger)
+
+	// Subcommand CLI; tanpa argumen server dijalankan seperti biasa
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "export":
+			if err := runExport(cfg, os.Args[2:]); err != nil {
+				fatal("Ekspor pesan gagal", "error", err)
+			}
+		case "healthcheck":
+			if err := runHealthcheck(cfg, os.Args[2:]); err != nil {
+				fatal("Healthcheck gagal", "error", err)
+			}
+		default:
+			fatal("Subcommand tidak dikenal (pilihan: export, healthcheck)", "subcommand", os.Args[1])
+		}
+		return
+	}
+
+	// SESSION_SECRET menandatangani link preview draft, token form kontak, dan tantangan CAPTCHA;
+	// secret yang bisa ditebak membuat semuanya bisa dipalsukan, jadi production menolak start
+	if err := cfg.PrepareSessionSecret(); err != nil {
+		fatal("SESSION_SECRET tidak aman; isi dengan string acak (misal: openssl rand -hex 32)", "error", err)
+	}
+	if cfg.EphemeralSecret {
+		slog.Warn("SESSION_SECRET belum diisi, memakai secret acak sementara: link preview dan token form tidak berlaku lagi setelah restart")
+	}
+
+	// Catat commit yang sedang berjalan (sama dengan isi /version)
+	build := buildinfo.Read()
+	slog.Info("Memulai server", "revision", build.ShortRevision(), "modified", build.Modified, "go", build.GoVersion)
+
+	// Tracing OpenTelemetry (OTEL_TRACES_EXPORTER=otlp/stdout); span stdout ditulis ke stdout, log tetap di stderr
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		Exporter:       cfg.TracesExporter,
+		Endpoint:       cfg.OTLPEndpoint,
+		ServiceName:    cfg.ServiceName,
+		ServiceVersion: build.Revision,
+		Stdout:         os.Stdout,
+	})
+	if err != nil {
+		fatal("Gagal menyiapkan tracing", "error", err)
+	}
+
 	// Set mode Gin berdasarkan konfigurasi
 	if cfg.AppMode == "production" {
 		gin.SetMode(gin.ReleaseMode)
@@ -33,63 +100,227 @@ func main() {
 	// Inisialisasi database SQLite dan jalankan migration
 	db, err := database.InitDB(cfg.DBPath)
 	if err != nil {
-		log.Fatalf("Gagal menginisialisasi database: %v", err)
+		fatal("Gagal menginisialisasi database", "error", err)
 	}
-	defer db.Close()
+
+	// Statistik pool koneksi database & session admin ikut di /metrics
+	database.RegisterMetrics(db)
+	metrics.NewGaugeFunc("admin_sessions_active", "Jumlah session admin yang masih berlaku.",
+		func() float64 { return float64(middleware.ActiveSessions()) })
 
 	// Inisialisasi layer-layer arsitektur (dependency injection)
 	repo := repository.NewRepository(db)
-	svc := service.NewService(repo)
 
-	// Inisialisasi handler
-	pageHandler := handler.NewPageHandler(svc)
-	contactHandler := handler.NewContactHandler(svc)
-	adminHandler := handler.NewAdminHandler(svc, cfg)
+	// Siapkan mailer notifikasi email (nil = nonaktif jika SMTP_HOST kosong)
+	var mailer *notify.Mailer
+	if cfg.SMTPHost != "" {
+		switch cfg.SMTPTLS {
+		case notify.TLSStartTLS, notify.TLSImplicit, notify.TLSNone:
+		default:
+			fatal("SMTP_TLS tidak dikenal (pilihan: starttls, tls, none)", "smtp_tls", cfg.SMTPTLS)
+		}
+		mailer, err = notify.NewMailer(&notify.SMTPSender{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.MailFrom,
+			TLS:      cfg.SMTPTLS,
+		}, "web/templates/email")
+		if err != nil {
+			fatal("Gagal menyiapkan notifikasi email", "error", err)
+		}
+	}
+
+	svc := service.NewService(repo, cfg, mailer)
+
+	// Jalankan background job (dihentikan saat shutdown, sebelum database ditutup)
+	runner := jobs.NewRunner()
+
+	// Hapus permanen konten yang terlalu lama di sampah (cek setiap jam)
+	if cfg.TrashRetentionDays > 0 {
+		retention := time.Duration(cfg.TrashRetentionDays) * 24 * time.Hour
+		runner.Every("purge-sampah", time.Hour, func(ctx context.Context) error {
+			n, err := svc.PurgeExpiredTrash(ctx, retention)
+			if n > 0 {
+				slog.InfoContext(ctx, "Konten dihapus permanen dari sampah", "count", n)
+			}
+			return err
+		})
+	}
+
+	// Hapus permanen pesan kontak yang melewati masa retensi beserta data terkait (cek setiap jam)
+	if cfg.MessageRetentionDays > 0 {
+		retention := time.Duration(cfg.MessageRetentionDays) * 24 * time.Hour
+		runner.Every("retensi-pesan", time.Hour, func(ctx context.Context) error {
+			req, err := svc.PurgeExpiredMessages(ctx, retention)
+			if req != nil && req.Affected() {
+				slog.InfoContext(ctx, "Retensi: data pesan lama dihapus permanen",
+					"messages", req.Messages, "replies", req.Replies, "emails", req.Emails, "deliveries", req.Deliveries)
+			}
+			return err
+		})
+	}
+
+	// Terbitkan konten terjadwal yang waktunya sudah tiba (cek setiap menit)
+	runner.Every("publish-terjadwal", time.Minute, func(ctx context.Context) error {
+		n, err := svc.PublishScheduled(ctx)
+		if n > 0 {
+			slog.InfoContext(ctx, "Konten terjadwal diterbitkan", "count", n)
+		}
+		return err
+	})
+
+	// Kirim email di antrean (notifikasi pesan kontak); gagal kirim dicoba ulang otomatis
+	runner.Every("kirim-email", 30*time.Second, func(ctx context.Context) error {
+		sent, failed, err := svc.ProcessEmailQueue(ctx)
+		if sent > 0 || failed > 0 {
+			slog.InfoContext(ctx, "Antrean email diproses", "sent", sent, "failed", failed)
+		}
+		return err
+	})
+
+	// Kirim event ke webhook; delivery yang gagal dicoba ulang dengan jeda eksponensial
+	runner.Every("kirim-webhook", 10*time.Second, func(ctx context.Context) error {
+		delivered, failed, err := svc.ProcessWebhookQueue(ctx)
+		if delivered > 0 || failed > 0 {
+			slog.InfoContext(ctx, "Antrean webhook diproses", "delivered", delivered, "failed", failed)
+		}
+		return err
+	})
+
+	// Pilih verifier CAPTCHA form kontak (nil = nonaktif)
+	var verifier captcha.Verifier
+	switch cfg.CaptchaProvider {
+	case "":
+	case captcha.ProviderProofOfWork:
+		// Tantangan ditandatangani SESSION_SECRET yang sudah diperiksa PrepareSessionSecret di atas
+		verifier = captcha.NewProofOfWork(cfg.SessionSecret, cfg.CaptchaDifficulty)
+	case captcha.ProviderHTTP:
+		verifier = captcha.NewHTTPVerifier(captcha.HTTPOptions{
+			Endpoint:      cfg.CaptchaVerifyURL,
+			Secret:        cfg.CaptchaSecret,
+			SiteKey:       cfg.CaptchaSiteKey,
+			ScriptURL:     cfg.CaptchaScriptURL,
+			WidgetClass:   cfg.CaptchaWidgetClass,
+			ResponseField: cfg.CaptchaResponseField,
+		})
+	default:
+		fatal("CAPTCHA_PROVIDER tidak dikenal (pilihan: pow, http)", "captcha_provider", cfg.CaptchaProvider)
+	}
+
+	// Siapkan file statis: nama ber-hash isi dan varian gzip/brotli (dibaca sekali saat startup)
+	staticAssets, err := assets.Load("web/static", "/static")
+	if err != nil {
+		fatal("Gagal menyiapkan file statis", "error", err)
+	}
+
+	// Siapkan HTTPS langsung (sertifikat statis atau ACME); nil = server HTTP biasa
+	tlsConfig, redirect, err := newTLSConfig(cfg)
+	if err != nil {
+		fatal("Gagal menyiapkan TLS", "error", err)
+	}
 
-	// Setup router Gin
-	r := gin.Default()
-
-	// Daftarkan custom template functions dan muat template secara manual
-	// (LoadHTMLGlob tidak mendukung nested directory dengan baik)
-	funcMap := template.FuncMap{
-		// split memecah string berdasarkan separator
-		// Digunakan di template untuk memecah tech_used yang comma-separated
-		"split": func(s, sep string) []string {
-			return strings.Split(s, sep)
-		},
-		// trim menghapus whitespace di awal dan akhir string
-		"trim": strings.TrimSpace,
-		// add menjumlahkan dua angka (untuk kalkulasi di template)
-		"add": func(a, b int) int {
-			return a + b
-		},
-		// safe menandai string sebagai HTML yang aman (tidak di-escape)
-		// Hanya gunakan untuk konten yang sudah disanitasi
-		"safe": func(s string) template.HTML {
-			return template.HTML(s)
-		},
-	}
-
-	// Muat semua template HTML dari berbagai subdirektori
-	tmpl := template.Must(template.New("").Funcs(funcMap).ParseFiles(
-		"web/templates/pages/index.html",
-		"web/templates/admin/login.html",
-		"web/templates/admin/dashboard.html",
-	))
+	// Setup router Gin: request ID, log akses & recovery lewat slog (bukan logger bawaan Gin),
+	// respons HTML dan JSON dikompres gzip. Probe kesehatan yang sukses tidak memenuhi log akses.
+	// Span request dibuat paling awal (melanjutkan traceparent dari luar jika ada) agar log akses
+	// ikut membawa trace_id; probe, scrape metrik, dan file statis tidak di-trace
+	r := gin.New()
+	// IP pengunjung (c.ClientIP untuk rate limit & log) hanya diambil dari X-Forwarded-For
+	// jika koneksi datang dari proxy yang dipercaya; default Gin mempercayai semua alamat
+	if err := r.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		fatal("TRUSTED_PROXIES tidak valid", "error", err)
+	}
+	r.Use(otelgin.Middleware(cfg.ServiceName, otelgin.WithGinFilter(func(c *gin.Context) bool {
+		switch c.FullPath() {
+		case "/healthz", "/readyz", "/metrics", "/static/*filepath":
+			return false
+		}
+		return true
+	})))
+	r.Use(middleware.RequestID(), middleware.AccessLog("/healthz", "/readyz"), middleware.Recovery(), middleware.Metrics())
+	r.Use(middleware.Gzip())
+
+	// Header keamanan & Content Security Policy (widget CAPTCHA pihak ketiga ikut diizinkan)
+	var cspSources []string
+	if verifier != nil {
+		cspSources = verifier.Widget().CSPSources()
+	}
+	r.Use(middleware.SecurityHeaders(middleware.SecurityOptions{
+		ReportOnly:   cfg.CSPReportOnly,
+		ReportURI:    "/csp-report",
+		ExtraSources: cspSources,
+	}))
+	if tlsConfig != nil && cfg.HSTSMaxAge > 0 {
+		r.Use(middleware.HSTS(cfg.HSTSMaxAge))
+	}
+
+	// Muat semua template HTML dari berbagai subdirektori beserta custom template functions
+	tmpl, err := handler.LoadTemplates(staticAssets.URL)
+	if err != nil {
+		fatal("Gagal memuat template", "error", err)
+	}
 	r.SetHTMLTemplate(tmpl)
 
-	// Serve file statis (CSS, JS, gambar)
-	r.Static("/static", "./web/static")
+	// Inisialisasi handler (halaman utama merender template sendiri untuk cache halaman)
+	pageHandler := handler.NewPageHandler(svc, cfg, verifier, tmpl)
+	contactHandler := handler.NewContactHandler(svc, cfg, verifier)
+	adminHandler := handler.NewAdminHandler(svc, cfg)
+	securityHandler := handler.NewSecurityHandler(svc)
+	healthHandler := handler.NewHealthHandler(db, cfg.DBPath)
+
+	// Serve file statis (CSS, JS, gambar); URL ber-hash di-cache browser selamanya
+	r.GET("/static/*filepath", staticAssets.Handler)
+	r.HEAD("/static/*filepath", staticAssets.Handler)
 
 	// ============================================
 	// ROUTES — Definisi rute aplikasi
 	// ============================================
 
-	// Halaman utama portofolio
+	// Halaman utama portofolio: "/" diarahkan ke bahasa pengunjung (/id/ atau /en/)
 	r.GET("/", pageHandler.Index)
+	for _, locale := range i18n.Locales {
+		r.GET("/"+locale+"/", pageHandler.Localized(locale))
+	}
+
+	// Preview halaman utama termasuk draft (butuh token bertanda tangan)
+	r.GET("/preview", pageHandler.Preview)
+
+	// API kontak form (dibatasi per IP jika CONTACT_RATE_LIMIT > 0)
+	contactRoute := []gin.HandlerFunc{contactHandler.SubmitContact}
+	if cfg.ContactRateLimit > 0 {
+		limiter := middleware.NewRateLimiter(cfg.ContactRateLimit, time.Hour)
+		contactRoute = append([]gin.HandlerFunc{limiter.Middleware()}, contactRoute...)
+	}
+	r.POST("/api/contact", contactRoute...)
+
+	// Token form kontak bertanda waktu (tidak ikut dirender agar halaman bisa di-cache)
+	r.GET("/api/contact/token", contactHandler.FormToken)
+
+	// Laporan pelanggaran CSP dari browser (dibatasi per IP agar tidak bisa membanjiri database)
+	cspLimiter := middleware.NewRateLimiter(cspReportRateLimit, time.Hour)
+	r.POST("/csp-report", cspLimiter.Middleware(), securityHandler.CSPReport)
+
+	// Tantangan CAPTCHA proof-of-work (hanya jika CAPTCHA_PROVIDER=pow)
+	if _, ok := verifier.(captcha.Challenger); ok {
+		r.GET("/api/captcha/challenge", contactHandler.CaptchaChallenge)
+	}
+
+	// Pemeriksaan kesehatan (proses hidup / siap menerima trafik) & versi build
+	r.GET("/healthz", healthHandler.Healthz)
+	r.GET("/readyz", healthHandler.Readyz)
+	r.GET("/version", healthHandler.Version)
 
-	// API kontak form
-	r.POST("/api/contact", contactHandler.SubmitContact)
+	// Metrik Prometheus (opsional dibatasi basic auth dan/atau IP allowlist)
+	metricsGuard, err := middleware.MetricsGuard(middleware.MetricsAccess{
+		Username:   cfg.MetricsUsername,
+		Password:   cfg.MetricsPassword,
+		AllowedIPs: cfg.MetricsAllowedIPs,
+	})
+	if err != nil {
+		fatal("Gagal menyiapkan endpoint metrics", "error", err)
+	}
+	r.GET("/metrics", metricsGuard, gin.WrapH(metrics.Handler()))
 
 	// ============================================
 	// Admin routes — dilindungi middleware auth
@@ -124,20 +355,81 @@ func main() {
 		admin.POST("/techstack/:id", adminHandler.UpdateTechStack)
 		admin.POST("/techstack/:id/delete", adminHandler.DeleteTechStack)
 
+		// Urutan tampil (drag & drop)
+		admin.POST("/experience/reorder", adminHandler.Reorder("experience"))
+		admin.POST("/project/reorder", adminHandler.Reorder("project"))
+		admin.POST("/techstack/reorder", adminHandler.Reorder("techstack"))
+
+		// Riwayat revisi & rollback (entity: experience/project/techstack/config)
+		admin.GET("/history/:entity/:key", adminHandler.ShowHistory)
+		admin.POST("/revision/:id/restore", adminHandler.RestoreRevision)
+
+		// Terjemahan konten (entity: experience/project/techstack/config)
+		admin.POST("/translation/:entity/:key", adminHandler.SaveTranslation)
+
 		// Update konfigurasi situs
 		admin.POST("/config", adminHandler.UpdateSiteConfig)
 
 		// Pesan kontak
 		admin.POST("/message/:id/read", adminHandler.MarkMessageRead)
 		admin.POST("/message/:id/delete", adminHandler.DeleteMessage)
+		admin.POST("/message/:id/spam", adminHandler.MarkMessageSpam(true))
+		admin.POST("/message/:id/not-spam", adminHandler.MarkMessageSpam(false))
+		admin.POST("/message/:id/archive", adminHandler.ArchiveMessage(true))
+		admin.POST("/message/:id/unarchive", adminHandler.ArchiveMessage(false))
+		admin.POST("/message/:id/reply", adminHandler.ReplyMessage)
+		admin.POST("/messages/bulk", adminHandler.BulkMessages)
+		admin.GET("/messages/export", adminHandler.ExportMessages)
+
+		// Permintaan privasi pengunjung (hapus/anonimkan data per email)
+		admin.POST("/privacy/erase", adminHandler.ErasePersonalData)
+
+		// Laporan pelanggaran CSP
+		admin.POST("/csp-reports/clear", adminHandler.ClearCSPReports)
+
+		// Webhook keluar & log delivery
+		admin.POST("/webhook", adminHandler.CreateWebhook)
+		admin.POST("/webhook/:id", adminHandler.UpdateWebhook)
+		admin.POST("/webhook/:id/delete", adminHandler.DeleteWebhook)
+		admin.POST("/delivery/:id/redeliver", adminHandler.RedeliverWebhook)
+
+		// Sampah: pulihkan atau hapus permanen (entity: experience/project/techstack/message)
+		admin.POST("/trash/:entity/:id/restore", adminHandler.RestoreTrash)
+		admin.POST("/trash/:entity/:id/purge", adminHandler.PurgeTrash)
 	}
 
-	// Jalankan server
-	addr := fmt.Sprintf(":%s", cfg.Port)
-	log.Printf("🚀 Server berjalan di http://localhost%s", addr)
-	log.Printf("📖 Buku portofolio siap dibaca!")
+	// Jalankan server sampai menerima SIGINT (Ctrl+C) atau SIGTERM (docker stop)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	servers := []*http.Server{newHTTPServer(cfg, cfg.Port, r)}
+	if tlsConfig != nil {
+		// Port HTTPS melayani aplikasi; port HTTP hanya redirect ke HTTPS, tantangan ACME,
+		// dan endpoint kesehatan (agar healthcheck lokal tidak perlu sertifikat yang cocok)
+		https := newHTTPServer(cfg, cfg.HTTPSPort, r)
+		https.TLSConfig = tlsConfig
+		servers = []*http.Server{https, newHTTPServer(cfg, cfg.Port, healthRoutes(r, redirect))}
+	}
+	serveErr := serve(ctx, cfg.ShutdownTimeout, servers...)
 
-	if err := r.Run(addr); err != nil {
-		log.Fatalf("Gagal menjalankan server: %v", err)
+	// Urutan berhenti: request HTTP sudah selesai, lalu job latar belakang, terakhir database
+	runner.Stop()
+	if err := db.Close(); err != nil {
+		slog.Error("Gagal menutup database", "error", err)
 	}
+	// Kirim span yang masih di antrean exporter
+	tracingCtx, cancelTracing := context.WithTimeout(context.Background(), 5*time.Second)
+	if err := shutdownTracing(tracingCtx); err != nil {
+		slog.Error("Gagal mengirim span tersisa", "error", err)
+	}
+	cancelTracing()
+	if serveErr != nil {
+		fatal("Gagal menjalankan server", "error", serveErr)
+	}
+	slog.Info("Server berhenti")
+}
+
+// fatal mencatat kesalahan yang membuat server tidak bisa jalan lalu keluar dengan status 1
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
 }
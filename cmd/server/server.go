@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"portofolio-go/internal/config"
+	"time"
+)
+
+// newHTTPServer membuat http.Server dengan batas waktu dan ukuran header dari konfigurasi
+// (r.Run milik gin tidak memasang batas apa pun, sehingga koneksi lambat bisa menahan server)
+func newHTTPServer(cfg *config.AppConfig, port string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              ":" + port,
+		Handler:           handler,
+		ReadHeaderTimeout: cfg.HTTPReadHeaderTimeout,
+		ReadTimeout:       cfg.HTTPReadTimeout,
+		WriteTimeout:      cfg.HTTPWriteTimeout,
+		IdleTimeout:       cfg.HTTPIdleTimeout,
+		MaxHeaderBytes:    cfg.HTTPMaxHeaderBytes,
+	}
+}
+
+// healthRoutes meneruskan /healthz dan /readyz ke app, sedangkan request lain ke fallback
+// (dipakai port HTTP saat TLS aktif, yang selain itu hanya mengarahkan ke HTTPS)
+func healthRoutes(app, fallback http.Handler) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("GET /healthz", app)
+	mux.Handle("GET /readyz", app)
+	mux.Handle("/", fallback)
+	return mux
+}
+
+// serve menjalankan semua server sampai ctx selesai (misal: SIGINT/SIGTERM), lalu berhenti
+// menerima koneksi baru dan menunggu request yang sedang berjalan selesai paling lama timeout.
+// Server dengan TLSConfig dijalankan sebagai HTTPS. Mengembalikan nil jika semua berhenti dengan bersih
+func serve(ctx context.Context, timeout time.Duration, servers ...*http.Server) error {
+	// Semua port dibuka lebih dulu agar error (misal: port terpakai) langsung terlihat
+	listeners := make([]net.Listener, 0, len(servers))
+	for _, srv := range servers {
+		ln, err := net.Listen("tcp", srv.Addr)
+		if err != nil {
+			for _, l := range listeners {
+				l.Close()
+			}
+			return fmt.Errorf("gagal membuka port %s: %w", srv.Addr, err)
+		}
+		listeners = append(listeners, ln)
+	}
+
+	errc := make(chan error, len(servers))
+	for i, srv := range servers {
+		ln, scheme := listeners[i], "http"
+		if srv.TLSConfig != nil {
+			scheme = "https"
+		}
+		slog.Info("Server berjalan", "url", fmt.Sprintf("%s://localhost:%d", scheme, ln.Addr().(*net.TCPAddr).Port))
+		go func() {
+			if srv.TLSConfig != nil {
+				errc <- srv.ServeTLS(ln, "", "")
+			} else {
+				errc <- srv.Serve(ln)
+			}
+		}()
+	}
+
+	var serveErr error
+	select {
+	case err := <-errc:
+		serveErr = fmt.Errorf("server berhenti tiba-tiba: %w", err)
+	case <-ctx.Done():
+	}
+
+	slog.Info("Menghentikan server, menunggu request yang sedang berjalan", "timeout", timeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	for _, srv := range servers {
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			// Request yang belum selesai sampai batas waktu diputus paksa
+			srv.Close()
+			serveErr = errors.Join(serveErr, fmt.Errorf("gagal menunggu request selesai: %w", err))
+		}
+	}
+	return serveErr
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"portofolio-go/internal/config"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// urlRecorder adalah slog.Handler yang meneruskan atribut "url" dari log "Server berjalan",
+// satu-satunya cara mengetahui port yang dipilih saat serve dijalankan di ":0"
+type urlRecorder struct {
+	urls chan string
+}
+
+func (h urlRecorder) Enabled(context.Context, slog.Level) bool { return true }
+func (h urlRecorder) WithAttrs([]slog.Attr) slog.Handler       { return h }
+func (h urlRecorder) WithGroup(string) slog.Handler            { return h }
+func (h urlRecorder) Handle(_ context.Context, r slog.Record) error {
+	r.Attrs(func(a slog.Attr) bool {
+		if a.Key == "url" {
+			h.urls <- a.Value.String()
+		}
+		return true
+	})
+	return nil
+}
+
+// startServe menjalankan serve di port acak dengan handler h. Mengembalikan URL server
+// dan channel hasil serve
+func startServe(t *testing.T, ctx context.Context, timeout time.Duration, h http.Handler) (string, <-chan error) {
+	t.Helper()
+	rec := urlRecorder{urls: make(chan string, 1)}
+	prev := slog.Default()
+	slog.SetDefault(slog.New(rec))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+
+	srv := newHTTPServer(&config.AppConfig{HTTPReadHeaderTimeout: 5 * time.Second}, "0", h)
+	done := make(chan error, 1)
+	go func() { done <- serve(ctx, timeout, srv) }()
+
+	select {
+	case url := <-rec.urls:
+		return url, done
+	case err := <-done:
+		t.Fatalf("serve berhenti sebelum berjalan: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("serve tidak mulai mendengarkan")
+	}
+	return "", nil
+}
+
+// slowHandler menjawab setelah delay; started ditutup saat request mulai diproses
+func slowHandler(delay time.Duration, started chan<- struct{}, finished *atomic.Bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(delay)
+		io.WriteString(w, "selesai")
+		finished.Store(true)
+	})
+}
+
+func TestServeGracefulShutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	started := make(chan struct{})
+	var finished atomic.Bool
+	url, done := startServe(t, ctx, 5*time.Second, slowHandler(300*time.Millisecond, started, &finished))
+
+	type result struct {
+		status int
+		body   string
+		err    error
+	}
+	resc := make(chan result, 1)
+	go func() {
+		resp, err := http.Get(url + "/lambat")
+		if err != nil {
+			resc <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		resc <- result{status: resp.StatusCode, body: string(body), err: err}
+	}()
+
+	// Hentikan server saat request masih diproses
+	<-started
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("serve = %v, want nil", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("serve tidak berhenti")
+	}
+	if !finished.Load() {
+		t.Fatal("serve kembali sebelum request yang sedang berjalan selesai")
+	}
+
+	res := <-resc
+	if res.err != nil || res.status != http.StatusOK || res.body != "selesai" {
+		t.Fatalf("request lambat: status=%d body=%q err=%v, want 200 \"selesai\"", res.status, res.body, res.err)
+	}
+
+	// Koneksi baru ditolak setelah server berhenti
+	if resp, err := http.Get(url + "/lambat"); err == nil {
+		resp.Body.Close()
+		t.Fatal("server masih menerima request setelah berhenti")
+	}
+}
+
+func TestServeShutdownTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	started := make(chan struct{})
+	var finished atomic.Bool
+	url, done := startServe(t, ctx, 50*time.Millisecond, slowHandler(2*time.Second, started, &finished))
+
+	go func() {
+		if resp, err := http.Get(url + "/lambat"); err == nil {
+			resp.Body.Close()
+		}
+	}()
+	<-started
+	cancel()
+
+	// Request yang melewati batas waktu shutdown diputus dan serve melaporkan error
+	select {
+	case err := <-done:
+		if err == nil || !strings.Contains(err.Error(), "gagal menunggu request selesai") {
+			t.Fatalf("serve = %v, want error batas waktu shutdown", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("serve tidak menghormati batas waktu shutdown")
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"portofolio-go/internal/config"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// newTLSConfig menyiapkan TLS dari konfigurasi: sertifikat statis (TLS_CERT_FILE/TLS_KEY_FILE)
+// atau sertifikat otomatis ACME (ACME_DOMAINS). Mengembalikan nil jika TLS tidak aktif.
+// Handler yang dikembalikan dipasang di port HTTP: menjawab tantangan ACME http-01
+// dan mengarahkan request lain ke HTTPS
+func newTLSConfig(cfg *config.AppConfig) (*tls.Config, http.Handler, error) {
+	static := cfg.TLSCertFile != "" || cfg.TLSKeyFile != ""
+	switch {
+	case !static && len(cfg.ACMEDomains) == 0:
+		return nil, nil, nil
+	case static && len(cfg.ACMEDomains) > 0:
+		return nil, nil, errors.New("pilih salah satu: TLS_CERT_FILE/TLS_KEY_FILE atau ACME_DOMAINS")
+	}
+
+	redirect := redirectToHTTPS(cfg.HTTPSPort)
+	if static {
+		if cfg.TLSCertFile == "" || cfg.TLSKeyFile == "" {
+			return nil, nil, errors.New("TLS_CERT_FILE dan TLS_KEY_FILE harus diisi bersamaan")
+		}
+		cert, err := tls.LoadX509KeyPair(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("gagal memuat sertifikat TLS: %w", err)
+		}
+		return &tls.Config{MinVersion: tls.VersionTLS12, Certificates: []tls.Certificate{cert}}, redirect, nil
+	}
+
+	manager := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(cfg.ACMEDomains...),
+		Cache:      autocert.DirCache(cfg.ACMECacheDir),
+		Email:      cfg.ACMEEmail,
+	}
+	if cfg.ACMEDirectoryURL != "" || cfg.ACMECACertFile != "" {
+		client, err := acmeClient(cfg.ACMEDirectoryURL, cfg.ACMECACertFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		manager.Client = client
+	}
+	slog.Info("Sertifikat ACME aktif", "domains", cfg.ACMEDomains, "cache_dir", cfg.ACMECacheDir)
+
+	// TLSConfig manager juga menjawab tantangan tls-alpn-01 di port HTTPS
+	tlsConfig := manager.TLSConfig()
+	tlsConfig.MinVersion = tls.VersionTLS12
+	return tlsConfig, manager.HTTPHandler(redirect), nil
+}
+
+// acmeClient membuat client ACME untuk server selain Let's Encrypt production
+// (misal: Let's Encrypt staging, atau Pebble dengan CA uji coba-nya sendiri)
+func acmeClient(directoryURL, caCertFile string) (*acme.Client, error) {
+	client := &acme.Client{DirectoryURL: directoryURL}
+	if directoryURL == "" {
+		client.DirectoryURL = autocert.DefaultACMEDirectory
+	}
+	if caCertFile == "" {
+		return client, nil
+	}
+
+	pem, err := os.ReadFile(caCertFile)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca ACME_CA_CERT_FILE: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("ACME_CA_CERT_FILE tidak berisi sertifikat PEM: %s", caCertFile)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	client.HTTPClient = &http.Client{Transport: transport}
+	return client, nil
+}
+
+// redirectToHTTPS mengarahkan request HTTP ke alamat yang sama di HTTPS.
+// GET/HEAD memakai 301; method lain 308 agar method dan body tidak berubah
+func redirectToHTTPS(httpsPort string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if httpsPort != "443" {
+			host = net.JoinHostPort(host, httpsPort)
+		}
+
+		status := http.StatusMovedPermanently
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			status = http.StatusPermanentRedirect
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), status)
+	})
+}
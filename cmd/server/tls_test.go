@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/pem"
+	"net"
+	"os"
+	"path/filepath"
+	"portofolio-go/internal/config"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestACMEPebble menerbitkan sertifikat sungguhan dari server ACME uji Pebble
+// (https://github.com/letsencrypt/pebble). Dilewati kecuali ACME_PEBBLE_TEST=1. Contoh:
+//
+//	docker run --rm -e PEBBLE_VA_ALWAYS_VALID=1 -p 14000:14000 ghcr.io/letsencrypt/pebble
+//	curl -sko pebble.minica.pem https://raw.githubusercontent.com/letsencrypt/pebble/main/test/certs/pebble.minica.pem
+//	ACME_PEBBLE_TEST=1 ACME_DIRECTORY_URL=https://localhost:14000/dir \
+//	  ACME_CA_CERT_FILE=$PWD/pebble.minica.pem go test -run TestACMEPebble ./cmd/server
+//
+// Tanpa PEBBLE_VA_ALWAYS_VALID, Pebble memvalidasi tantangan ke ACME_TEST_DOMAIN di port
+// 5001 (tls-alpn-01) dan 5002 (http-01), jadi domain harus di-resolve DNS Pebble
+// (misal: pebble-challtestsrv) ke mesin yang menjalankan tes
+func TestACMEPebble(t *testing.T) {
+	if os.Getenv("ACME_PEBBLE_TEST") != "1" {
+		t.Skip("set ACME_PEBBLE_TEST=1 beserta ACME_DIRECTORY_URL dan ACME_CA_CERT_FILE untuk menguji penerbitan sertifikat dengan Pebble")
+	}
+	env := func(key, fallback string) string {
+		if v := os.Getenv(key); v != "" {
+			return v
+		}
+		return fallback
+	}
+
+	domain := env("ACME_TEST_DOMAIN", "portofolio.test")
+	cfg := &config.AppConfig{
+		HTTPReadHeaderTimeout: 5 * time.Second,
+		HTTPSPort:             env("ACME_TEST_HTTPS_PORT", "5001"),
+		ACMEDomains:           []string{domain},
+		ACMEEmail:             "admin@" + domain,
+		ACMEDirectoryURL:      os.Getenv("ACME_DIRECTORY_URL"),
+		ACMECACertFile:        os.Getenv("ACME_CA_CERT_FILE"),
+		ACMECacheDir:          t.TempDir(),
+	}
+	if cfg.ACMEDirectoryURL == "" || cfg.ACMECACertFile == "" {
+		t.Fatal("ACME_DIRECTORY_URL dan ACME_CA_CERT_FILE harus diarahkan ke Pebble")
+	}
+
+	tlsConfig, challenge, err := newTLSConfig(cfg)
+	if err != nil {
+		t.Fatalf("newTLSConfig: %v", err)
+	}
+
+	// Jalankan port HTTP (tantangan http-01) dan HTTPS (tls-alpn-01) seperti di main
+	ctx, cancel := context.WithCancel(context.Background())
+	httpsSrv := newHTTPServer(cfg, cfg.HTTPSPort, challenge)
+	httpsSrv.TLSConfig = tlsConfig
+	httpSrv := newHTTPServer(cfg, env("ACME_TEST_HTTP_PORT", "5002"), challenge)
+	done := make(chan error, 1)
+	go func() { done <- serve(ctx, 5*time.Second, httpSrv, httpsSrv) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("serve: %v", err)
+		}
+	})
+
+	// Handshake TLS pertama untuk domain memicu penerbitan sertifikat
+	var conn *tls.Conn
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: 5 * time.Second},
+		// Root CA penerbit Pebble dibuat acak setiap start; yang diperiksa di sini adalah isi sertifikat
+		Config: &tls.Config{ServerName: domain, InsecureSkipVerify: true},
+	}
+	deadline := time.Now().Add(time.Minute)
+	for {
+		dialCtx, dialCancel := context.WithTimeout(context.Background(), time.Minute)
+		c, err := dialer.DialContext(dialCtx, "tcp", net.JoinHostPort("127.0.0.1", cfg.HTTPSPort))
+		dialCancel()
+		if err == nil {
+			conn = c.(*tls.Conn)
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("handshake TLS gagal: %v", err)
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	defer conn.Close()
+
+	leaf := conn.ConnectionState().PeerCertificates[0]
+	if !slices.Contains(leaf.DNSNames, domain) {
+		t.Errorf("DNSNames sertifikat = %v, want %s", leaf.DNSNames, domain)
+	}
+	if !strings.Contains(leaf.Issuer.CommonName, "Pebble") {
+		t.Errorf("penerbit sertifikat = %q, want CA Pebble", leaf.Issuer.CommonName)
+	}
+
+	// Sertifikat dan akun tersimpan di ACME_CACHE_DIR agar tidak diterbitkan ulang setelah restart
+	cached, err := os.ReadFile(filepath.Join(cfg.ACMECacheDir, domain))
+	if err != nil {
+		t.Fatalf("sertifikat tidak tersimpan di cache: %v", err)
+	}
+	var types []string
+	for rest := cached; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		types = append(types, block.Type)
+	}
+	if !slices.Contains(types, "EC PRIVATE KEY") || !slices.Contains(types, "CERTIFICATE") {
+		t.Errorf("isi cache %s = %v, want private key dan sertifikat", domain, types)
+	}
+	if _, err := os.Stat(filepath.Join(cfg.ACMECacheDir, "acme_account+key")); err != nil {
+		t.Errorf("kunci akun ACME tidak tersimpan: %v", err)
+	}
+}
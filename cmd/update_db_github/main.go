@@ -3,7 +3,8 @@ package main
 import (
 	"database/sql"
 	"fmt"
-	"log"
+	"log/slog"
+	"os"
 
 	_ "github.com/mattn/go-sqlite3"
 )
@@ -12,7 +13,8 @@ func main() {
 	dbPath := "portfolio.db"
 	db, err := sql.Open("sqlite3", dbPath)
 	if err != nil {
-		log.Fatal(err)
+		slog.Error("Gagal membuka database", "error", err)
+		os.Exit(1)
 	}
 	defer db.Close()
 
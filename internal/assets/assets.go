@@ -0,0 +1,204 @@
+// Package assets menyajikan file statis (CSS, JS, gambar) dengan nama ber-hash isi.
+// Saat startup setiap file dibaca, di-hash, dan dikompres gzip; template memanggil
+// fungsi asset untuk mendapatkan URL ber-hash (misal: /static/css/notebook.3f2a9c1b07.css)
+// yang aman di-cache browser selamanya karena URL berubah setiap isi file berubah
+package assets
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"portofolio-go/internal/middleware"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	hashLength     = 10                                    // Panjang hash hex di nama file
+	immutableCache = "public, max-age=31536000, immutable" // URL ber-hash tidak pernah berubah isi
+	revalidate     = "public, no-cache"                    // URL lama tanpa hash harus divalidasi ulang
+)
+
+// compressible adalah ekstensi file teks yang layak dikompres (gambar sudah terkompres)
+var compressible = []string{".css", ".js", ".svg", ".json", ".txt", ".map", ".xml", ".html"}
+
+// asset adalah satu file statis beserta varian terkompresinya
+type asset struct {
+	name    string    // Path relatif asli (misal: css/notebook.css)
+	hashed  string    // Path relatif ber-hash (misal: css/notebook.3f2a9c1b07.css)
+	hash    string    // Hash isi file (juga dipakai sebagai ETag)
+	modTime time.Time // Waktu ubah file untuk Last-Modified
+	raw     []byte    // Isi asli
+	gzip    []byte    // Varian gzip (nil jika tidak lebih kecil dari aslinya)
+	brotli  []byte    // Varian brotli dari file .br yang dibuat saat build (nil jika tidak ada)
+}
+
+// Pipeline menyimpan semua file statis dalam satu direktori
+type Pipeline struct {
+	prefix string            // Prefix URL (misal: /static)
+	files  map[string]*asset // Key: path relatif asli dan path ber-hash
+}
+
+// Load membaca semua file di dir dan menyiapkan nama ber-hash serta varian gzip-nya.
+// File .br di sebelah file asli (misal: notebook.css.br hasil `brotli -k` saat build)
+// dipakai sebagai varian brotli; file .gz dipakai menggantikan hasil kompresi startup
+func Load(dir, prefix string) (*Pipeline, error) {
+	p := &Pipeline{prefix: strings.TrimSuffix(prefix, "/"), files: make(map[string]*asset)}
+	err := filepath.WalkDir(dir, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		ext := filepath.Ext(file)
+		if d.IsDir() || ext == ".br" || ext == ".gz" {
+			return nil
+		}
+
+		a, err := loadAsset(dir, file)
+		if err != nil {
+			return err
+		}
+		p.files[a.name] = a
+		p.files[a.hashed] = a
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gagal memuat file statis dari %s: %w", dir, err)
+	}
+	return p, nil
+}
+
+// loadAsset membaca satu file statis beserta varian terkompresinya
+func loadAsset(dir, file string) (*asset, error) {
+	info, err := os.Stat(file)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := filepath.Rel(dir, file)
+	if err != nil {
+		return nil, err
+	}
+
+	name := filepath.ToSlash(rel)
+	sum := sha256.Sum256(raw)
+	hash := hex.EncodeToString(sum[:])[:hashLength]
+	ext := path.Ext(name)
+	a := &asset{
+		name:    name,
+		hashed:  strings.TrimSuffix(name, ext) + "." + hash + ext,
+		hash:    hash,
+		modTime: info.ModTime(),
+		raw:     raw,
+	}
+	if !slices.Contains(compressible, ext) {
+		return a, nil
+	}
+
+	// Varian terkompresi hanya dipakai jika lebih kecil dari aslinya
+	if a.gzip, err = precompressed(file+".gz", info.ModTime()); err != nil {
+		return nil, err
+	}
+	if a.gzip == nil {
+		if a.gzip, err = gzipBytes(raw); err != nil {
+			return nil, fmt.Errorf("gagal mengompres %s: %w", name, err)
+		}
+	}
+	if len(a.gzip) >= len(raw) {
+		a.gzip = nil
+	}
+	if a.brotli, err = precompressed(file+".br", info.ModTime()); err != nil {
+		return nil, err
+	}
+	if len(a.brotli) >= len(raw) {
+		a.brotli = nil
+	}
+	return a, nil
+}
+
+// precompressed membaca varian terkompresi yang dibuat saat build.
+// File yang lebih tua dari aslinya dianggap basi dan diabaikan
+func precompressed(file string, sourceModTime time.Time) ([]byte, error) {
+	info, err := os.Stat(file)
+	if os.IsNotExist(err) || (err == nil && info.ModTime().Before(sourceModTime)) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(file)
+}
+
+// gzipBytes mengompres data dengan tingkat kompresi terbaik (hanya sekali saat startup)
+func gzipBytes(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	gz, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := gz.Write(data); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// URL mengembalikan URL ber-hash untuk file statis (dipakai template lewat fungsi asset).
+// File yang tidak dikenal dikembalikan tanpa hash agar halaman tetap bisa dimuat
+func (p *Pipeline) URL(name string) string {
+	name = strings.TrimPrefix(name, "/")
+	if a, ok := p.files[name]; ok {
+		return p.prefix + "/" + a.hashed
+	}
+	return p.prefix + "/" + name
+}
+
+// Handler menyajikan file statis untuk rute GET/HEAD prefix + "/*filepath".
+// URL ber-hash di-cache selamanya (immutable); URL asli tetap dilayani untuk link lama
+// tetapi wajib divalidasi ulang. Varian brotli/gzip dipilih dari header Accept-Encoding
+func (p *Pipeline) Handler(c *gin.Context) {
+	name := strings.TrimPrefix(c.Param("filepath"), "/")
+	a, ok := p.files[name]
+	if !ok {
+		c.Status(http.StatusNotFound)
+		return
+	}
+
+	h := c.Writer.Header()
+	if name == a.hashed {
+		h.Set("Cache-Control", immutableCache)
+	} else {
+		h.Set("Cache-Control", revalidate)
+	}
+
+	body, etag := a.raw, a.hash
+	if a.gzip != nil || a.brotli != nil {
+		h.Add("Vary", "Accept-Encoding")
+		switch {
+		case a.brotli != nil && middleware.AcceptsEncoding(c.Request, "br"):
+			body, etag = a.brotli, a.hash+"-br"
+			h.Set("Content-Encoding", "br")
+		case a.gzip != nil && middleware.AcceptsEncoding(c.Request, "gzip"):
+			body, etag = a.gzip, a.hash+"-gz"
+			h.Set("Content-Encoding", "gzip")
+		}
+	}
+	h.Set("ETag", `"`+etag+`"`)
+
+	// ServeContent menangani If-None-Match, Range, dan Content-Type dari ekstensi file asli
+	http.ServeContent(c.Writer, c.Request, a.name, a.modTime, bytes.NewReader(body))
+}
@@ -0,0 +1,45 @@
+// Package buildinfo membaca informasi build yang ditanam compiler Go ke binary
+// (commit VCS, waktu commit, versi Go) lewat debug.ReadBuildInfo, sehingga versi
+// yang sedang berjalan bisa dilihat tanpa flag -ldflags tambahan
+package buildinfo
+
+import (
+	"runtime/debug"
+	"sync"
+)
+
+// Info adalah identitas build binary yang sedang berjalan
+type Info struct {
+	Revision  string `json:"revision"`   // Hash commit VCS (kosong jika dibangun tanpa .git)
+	Time      string `json:"time"`       // Waktu commit (RFC 3339)
+	Modified  bool   `json:"modified"`   // true jika ada perubahan yang belum di-commit saat build
+	GoVersion string `json:"go_version"` // Versi compiler Go
+}
+
+// Read mengembalikan informasi build; hasilnya dibaca sekali lalu disimpan
+var Read = sync.OnceValue(func() Info {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return Info{}
+	}
+	info := Info{GoVersion: bi.GoVersion}
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			info.Revision = s.Value
+		case "vcs.time":
+			info.Time = s.Value
+		case "vcs.modified":
+			info.Modified = s.Value == "true"
+		}
+	}
+	return info
+})
+
+// ShortRevision mengembalikan 12 karakter pertama hash commit (untuk log)
+func (i Info) ShortRevision() string {
+	if len(i.Revision) > 12 {
+		return i.Revision[:12]
+	}
+	return i.Revision
+}
@@ -0,0 +1,68 @@
+// Package captcha menyediakan verifikasi CAPTCHA untuk form kontak.
+// Implementasi bisa diganti lewat konfigurasi tanpa mengubah handler:
+// proof-of-work yang di-host sendiri, atau verifier HTTP bergaya hCaptcha/Turnstile
+package captcha
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Jenis provider CAPTCHA (nilai CAPTCHA_PROVIDER)
+const (
+	ProviderProofOfWork = "pow"  // Tantangan hash yang diselesaikan browser, tanpa pihak ketiga
+	ProviderHTTP        = "http" // Verifikasi token widget ke endpoint siteverify (hCaptcha/Turnstile)
+)
+
+// Error verifikasi CAPTCHA
+var (
+	ErrMissing = errors.New("jawaban captcha kosong")
+	ErrFailed  = errors.New("verifikasi captcha gagal")
+)
+
+// Verifier memverifikasi jawaban CAPTCHA yang dikirim bersama form kontak
+type Verifier interface {
+	// Widget mengembalikan data yang dibutuhkan frontend untuk menampilkan/menyelesaikan tantangan
+	Widget() Widget
+	// Verify memeriksa jawaban CAPTCHA dari pengunjung dengan IP remoteIP.
+	// Mengembalikan error (ErrMissing/ErrFailed atau error jaringan) jika tidak lolos
+	Verify(ctx context.Context, response, remoteIP string) error
+}
+
+// Challenger diimplementasikan verifier yang menerbitkan tantangannya sendiri
+// (misal: proof-of-work), sehingga butuh endpoint tantangan untuk browser
+type Challenger interface {
+	NewChallenge(now time.Time) Challenge
+}
+
+// Widget berisi konfigurasi CAPTCHA untuk frontend (dibaca contact.js dari atribut data-*)
+type Widget struct {
+	Provider      string // Jenis provider (pow/http)
+	ChallengeURL  string // Endpoint tantangan proof-of-work
+	SiteKey       string // Site key publik widget HTTP
+	ScriptURL     string // Script widget pihak ketiga (misal: https://js.hcaptcha.com/1/api.js)
+	WidgetClass   string // Class elemen yang dirender otomatis oleh script widget (misal: h-captcha)
+	ResponseField string // Nama input tersembunyi berisi token jawaban dari widget
+}
+
+// CSPSources mengembalikan origin yang perlu diizinkan Content Security Policy untuk
+// script widget pihak ketiga: domain induk beserta semua subdomainnya, karena widget
+// memuat iframe dan API dari subdomain lain (misal: js.hcaptcha.com → https://hcaptcha.com
+// dan https://*.hcaptcha.com). Kosong jika widget tidak memakai script pihak ketiga
+func (w Widget) CSPSources() []string {
+	u, err := url.Parse(w.ScriptURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil
+	}
+	if u.Port() != "" {
+		return []string{u.Scheme + "://" + u.Host}
+	}
+	host := u.Hostname()
+	if labels := strings.Split(host, "."); len(labels) > 2 {
+		host = strings.Join(labels[1:], ".")
+	}
+	return []string{u.Scheme + "://" + host, u.Scheme + "://*." + host}
+}
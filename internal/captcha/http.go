@@ -0,0 +1,96 @@
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// HTTPVerifier memverifikasi token widget CAPTCHA pihak ketiga lewat endpoint
+// siteverify. hCaptcha dan Cloudflare Turnstile memakai protokol yang sama:
+// POST form (secret, response, remoteip) → JSON {"success": bool, "error-codes": [...]}.
+// Endpoint bisa diarahkan ke server palsu lokal untuk pengujian
+type HTTPVerifier struct {
+	endpoint string
+	secret   string
+	widget   Widget
+	client   *http.Client
+}
+
+// HTTPOptions adalah konfigurasi HTTPVerifier
+type HTTPOptions struct {
+	Endpoint      string // URL siteverify (misal: https://api.hcaptcha.com/siteverify)
+	Secret        string // Secret key dari dashboard provider
+	SiteKey       string // Site key publik untuk widget
+	ScriptURL     string // Script widget yang dimuat di halaman
+	WidgetClass   string // Class elemen widget (h-captcha / cf-turnstile)
+	ResponseField string // Nama input token dari widget (h-captcha-response / cf-turnstile-response)
+}
+
+// NewHTTPVerifier membuat verifier HTTP dengan timeout request 10 detik
+func NewHTTPVerifier(opts HTTPOptions) *HTTPVerifier {
+	return &HTTPVerifier{
+		endpoint: opts.Endpoint,
+		secret:   opts.Secret,
+		widget: Widget{
+			Provider:      ProviderHTTP,
+			SiteKey:       opts.SiteKey,
+			ScriptURL:     opts.ScriptURL,
+			WidgetClass:   opts.WidgetClass,
+			ResponseField: opts.ResponseField,
+		},
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Widget mengembalikan konfigurasi frontend widget pihak ketiga
+func (v *HTTPVerifier) Widget() Widget {
+	return v.widget
+}
+
+// siteverifyResponse adalah respons JSON endpoint siteverify
+type siteverifyResponse struct {
+	Success    bool     `json:"success"`
+	ErrorCodes []string `json:"error-codes"`
+}
+
+// Verify mengirim token widget ke endpoint siteverify
+func (v *HTTPVerifier) Verify(ctx context.Context, response, remoteIP string) error {
+	if response == "" {
+		return ErrMissing
+	}
+
+	form := url.Values{
+		"secret":   {v.secret},
+		"response": {response},
+		"remoteip": {remoteIP},
+		"sitekey":  {v.widget.SiteKey},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("gagal membuat request siteverify: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("gagal menghubungi siteverify: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("siteverify membalas status %d", resp.StatusCode)
+	}
+
+	var result siteverifyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("gagal membaca respons siteverify: %w", err)
+	}
+	if !result.Success {
+		return fmt.Errorf("%w: %s", ErrFailed, strings.Join(result.ErrorCodes, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package captcha
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestHTTPVerifier memeriksa Verify terhadap server siteverify palsu
+func TestHTTPVerifier(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr error  // nil = sukses; selain itu dicek dengan errors.Is
+		wantMsg string // Potongan pesan error yang diharapkan
+	}{
+		{name: "sukses", status: http.StatusOK, body: `{"success":true}`},
+		{
+			name:    "gagal dengan error-codes",
+			status:  http.StatusOK,
+			body:    `{"success":false,"error-codes":["invalid-input-response","timeout-or-duplicate"]}`,
+			wantErr: ErrFailed,
+			wantMsg: "invalid-input-response, timeout-or-duplicate",
+		},
+		{name: "status bukan 200", status: http.StatusBadGateway, body: `{"success":true}`, wantMsg: "status 502"},
+		{name: "JSON rusak", status: http.StatusOK, body: `<html>`, wantMsg: "gagal membaca respons siteverify"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					t.Errorf("method = %s, want POST", r.Method)
+				}
+				if err := r.ParseForm(); err != nil {
+					t.Errorf("ParseForm: %v", err)
+				}
+				for field, want := range map[string]string{
+					"secret":   "rahasia",
+					"response": "token-widget",
+					"remoteip": "203.0.113.7",
+					"sitekey":  "site-key",
+				} {
+					if got := r.PostForm.Get(field); got != want {
+						t.Errorf("form %s = %q, want %q", field, got, want)
+					}
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			v := NewHTTPVerifier(HTTPOptions{Endpoint: srv.URL, Secret: "rahasia", SiteKey: "site-key"})
+			err := v.Verify(context.Background(), "token-widget", "203.0.113.7")
+
+			if tt.wantErr == nil && tt.wantMsg == "" {
+				if err != nil {
+					t.Fatalf("Verify: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Verify = nil, want error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && errors.Is(err, ErrFailed) {
+				t.Errorf("Verify = %v; gangguan provider tidak boleh dianggap jawaban salah", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("Verify = %q, want containing %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+// TestHTTPVerifierMissing memastikan token kosong ditolak tanpa menghubungi provider
+func TestHTTPVerifierMissing(t *testing.T) {
+	v := NewHTTPVerifier(HTTPOptions{Endpoint: "http://127.0.0.1:1/siteverify"})
+	if err := v.Verify(context.Background(), "", ""); !errors.Is(err, ErrMissing) {
+		t.Fatalf("Verify = %v, want ErrMissing", err)
+	}
+}
@@ -0,0 +1,138 @@
+package captcha
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Umur tantangan proof-of-work sebelum harus diminta ulang
+const ChallengeTTL = 10 * time.Minute
+
+// ProofOfWork adalah CAPTCHA tanpa pihak ketiga: browser harus mencari nonce
+// sehingga SHA-256("<challenge>:<nonce>") diawali minimal Difficulty bit nol.
+// Tantangan bersifat stateless (ditandatangani HMAC); hanya tantangan yang
+// sudah terpakai yang disimpan di memory untuk mencegah replay
+type ProofOfWork struct {
+	secret     string
+	difficulty int
+	used       map[string]time.Time // Tantangan terpakai → waktu kedaluwarsanya
+	mu         sync.Mutex
+}
+
+// Challenge adalah tantangan proof-of-work yang dikirim ke browser
+type Challenge struct {
+	Challenge  string `json:"challenge"`  // "<unix-expiry>.<salt>.<difficulty>.<hmac>"
+	Difficulty int    `json:"difficulty"` // Jumlah bit nol di awal hash yang dibutuhkan
+}
+
+// NewProofOfWork membuat verifier proof-of-work dengan tingkat kesulitan difficulty bit
+// Setiap kenaikan 1 bit menggandakan rata-rata waktu penyelesaian di browser.
+// secret harus sudah lolos config.PrepareSessionSecret: dengan secret yang diketahui,
+// tantangan bisa dibuat dan diselesaikan offline sehingga CAPTCHA tidak berarti
+func NewProofOfWork(secret string, difficulty int) *ProofOfWork {
+	if secret == "" {
+		panic("captcha: secret proof-of-work kosong")
+	}
+	return &ProofOfWork{secret: secret, difficulty: difficulty, used: make(map[string]time.Time)}
+}
+
+// Widget mengembalikan konfigurasi frontend proof-of-work
+func (p *ProofOfWork) Widget() Widget {
+	return Widget{Provider: ProviderProofOfWork, ChallengeURL: "/api/captcha/challenge"}
+}
+
+// NewChallenge membuat tantangan baru yang berlaku selama ChallengeTTL
+func (p *ProofOfWork) NewChallenge(now time.Time) Challenge {
+	salt := make([]byte, 16)
+	rand.Read(salt)
+	payload := fmt.Sprintf("%d.%s.%d", now.Add(ChallengeTTL).Unix(), hex.EncodeToString(salt), p.difficulty)
+	return Challenge{Challenge: payload + "." + p.sign(payload), Difficulty: p.difficulty}
+}
+
+// Verify memeriksa jawaban "<challenge>:<nonce>" dari browser
+func (p *ProofOfWork) Verify(ctx context.Context, response, remoteIP string) error {
+	if response == "" {
+		return ErrMissing
+	}
+	challenge, nonce, ok := strings.Cut(response, ":")
+	if !ok || nonce == "" {
+		return fmt.Errorf("%w: format jawaban tidak valid", ErrFailed)
+	}
+
+	// Tantangan harus dibuat server ini dan belum kedaluwarsa
+	payload, sig, ok := cutLast(challenge, ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(p.sign(payload))) {
+		return fmt.Errorf("%w: tanda tangan tantangan tidak valid", ErrFailed)
+	}
+	parts := strings.Split(payload, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("%w: format tantangan tidak valid", ErrFailed)
+	}
+	unix, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: format tantangan tidak valid", ErrFailed)
+	}
+	expires := time.Unix(unix, 0)
+	now := time.Now()
+	if now.After(expires) {
+		return fmt.Errorf("%w: tantangan kedaluwarsa", ErrFailed)
+	}
+	difficulty, _ := strconv.Atoi(parts[2])
+
+	// Cek hasil kerja browser
+	sum := sha256.Sum256([]byte(response))
+	if leadingZeroBits(sum[:]) < difficulty {
+		return fmt.Errorf("%w: nonce tidak memenuhi tingkat kesulitan", ErrFailed)
+	}
+
+	// Satu tantangan hanya boleh dipakai sekali
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key, exp := range p.used {
+		if now.After(exp) {
+			delete(p.used, key)
+		}
+	}
+	if _, dup := p.used[challenge]; dup {
+		return fmt.Errorf("%w: tantangan sudah dipakai", ErrFailed)
+	}
+	p.used[challenge] = expires
+	return nil
+}
+
+// sign menghitung HMAC-SHA256 (hex) untuk payload tantangan
+func (p *ProofOfWork) sign(payload string) string {
+	mac := hmac.New(sha256.New, []byte(p.secret))
+	mac.Write([]byte("captcha:" + payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// leadingZeroBits menghitung jumlah bit nol di awal hash
+func leadingZeroBits(hash []byte) int {
+	n := 0
+	for _, b := range hash {
+		if b != 0 {
+			return n + bits.LeadingZeros8(b)
+		}
+		n += 8
+	}
+	return n
+}
+
+// cutLast memotong s di kemunculan terakhir sep
+func cutLast(s, sep string) (before, after string, found bool) {
+	i := strings.LastIndex(s, sep)
+	if i < 0 {
+		return s, "", false
+	}
+	return s[:i], s[i+len(sep):], true
+}
@@ -0,0 +1,96 @@
+package captcha
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// solve mencari nonce seperti yang dilakukan browser (contact.js)
+func solve(t *testing.T, ch Challenge) string {
+	t.Helper()
+	for nonce := 0; nonce < 1<<24; nonce++ {
+		response := ch.Challenge + ":" + strconv.Itoa(nonce)
+		sum := sha256.Sum256([]byte(response))
+		if leadingZeroBits(sum[:]) >= ch.Difficulty {
+			return response
+		}
+	}
+	t.Fatalf("nonce untuk difficulty %d tidak ditemukan", ch.Difficulty)
+	return ""
+}
+
+func TestProofOfWork(t *testing.T) {
+	ctx := context.Background()
+	pow := NewProofOfWork("rahasia", 8)
+
+	ch := pow.NewChallenge(time.Now())
+	if ch.Difficulty != 8 {
+		t.Fatalf("Difficulty = %d, want 8", ch.Difficulty)
+	}
+	response := solve(t, ch)
+
+	if err := pow.Verify(ctx, response, ""); err != nil {
+		t.Fatalf("Verify jawaban benar: %v", err)
+	}
+
+	// Jawaban yang sama tidak boleh dipakai dua kali
+	err := pow.Verify(ctx, response, "")
+	if !errors.Is(err, ErrFailed) || !strings.Contains(err.Error(), "sudah dipakai") {
+		t.Fatalf("Verify replay = %v, want tantangan sudah dipakai", err)
+	}
+}
+
+func TestNewProofOfWorkEmptySecret(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("NewProofOfWork menerima secret kosong")
+		}
+	}()
+	NewProofOfWork("", 8)
+}
+
+func TestProofOfWorkRejects(t *testing.T) {
+	ctx := context.Background()
+	pow := NewProofOfWork("rahasia", 8)
+
+	// Nonce yang tidak memenuhi tingkat kesulitan
+	ch := pow.NewChallenge(time.Now())
+	weak := ""
+	for nonce := 0; ; nonce++ {
+		weak = ch.Challenge + ":" + strconv.Itoa(nonce)
+		sum := sha256.Sum256([]byte(weak))
+		if leadingZeroBits(sum[:]) < ch.Difficulty {
+			break
+		}
+	}
+
+	tests := []struct {
+		name     string
+		response string
+		want     error
+		wantMsg  string
+	}{
+		{"kosong", "", ErrMissing, ""},
+		{"tanpa nonce", ch.Challenge, ErrFailed, "format jawaban"},
+		// Tantangan yang dibuat sendiri dengan default lama dari repo
+		{"tanda tangan palsu", solve(t, NewProofOfWork("default-secret-ganti-ini", 8).NewChallenge(time.Now())), ErrFailed, "tanda tangan"},
+		{"kedaluwarsa", solve(t, pow.NewChallenge(time.Now().Add(-2*ChallengeTTL))), ErrFailed, "kedaluwarsa"},
+		{"nonce lemah", weak, ErrFailed, "tingkat kesulitan"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := pow.Verify(ctx, tt.response, "")
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Verify = %v, want %v", err, tt.want)
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("Verify = %q, want containing %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
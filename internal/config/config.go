@@ -1,31 +1,203 @@
 package config
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log/slog"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// minSessionSecretLen adalah panjang minimal SESSION_SECRET yang dianggap layak
+const minSessionSecretLen = 16
+
+// publicSessionSecrets adalah nilai SESSION_SECRET yang tertulis di repo (default lama
+// dan contoh .env.example); siapa pun bisa menandatangani token dengannya
+var publicSessionSecrets = []string{"default-secret-ganti-ini", "ganti-dengan-random-secret-yang-kuat"}
+
+// ErrWeakSessionSecret dikembalikan PrepareSessionSecret di production jika SESSION_SECRET tidak layak
+var ErrWeakSessionSecret = errors.New("SESSION_SECRET kosong, kurang dari 16 karakter, atau masih nilai contoh dari repo")
+
 // AppConfig menyimpan seluruh konfigurasi aplikasi
 // yang diambil dari environment variables
 type AppConfig struct {
-	Port          string // Port server HTTP
-	DBPath        string // Path ke file database SQLite
-	AdminUsername  string // Username untuk login admin panel
-	AdminPassword  string // Password untuk login admin panel
-	SessionSecret string // Secret key untuk session cookie
-	AppMode       string // Mode aplikasi (development/production)
+	Port               string // Port server HTTP
+	DBPath             string // Path ke file database SQLite
+	AdminUsername      string // Username untuk login admin panel
+	AdminPassword      string // Password untuk login admin panel
+	SessionSecret      string // Secret untuk menandatangani link preview, token form kontak, tantangan CAPTCHA, dan hash log privasi
+	EphemeralSecret    bool   // true jika SESSION_SECRET tidak layak dan diganti secret acak per proses (development)
+	AppMode            string // Mode aplikasi (development/production)
+	LogFormat          string // Format log: "json" (default di production) atau "text"
+	LogLevel           string // Level log minimum: debug, info, warn, error
+	TrashRetentionDays int    // Umur konten di sampah sebelum dihapus permanen (0 = tidak pernah)
+
+	// Pertahanan spam form kontak
+	ContactRateLimit  int      // Maksimal pesan per jam dari satu IP (0 = tanpa batas)
+	ContactMinSeconds int      // Jeda minimal antara form tampil dan dikirim (detik)
+	SpamThreshold     int      // Skor minimal agar pesan masuk folder spam
+	SpamBlockedWords  []string // Kata/frasa yang menaikkan skor spam (case-insensitive)
+
+	// CAPTCHA form kontak
+	CaptchaProvider      string // Provider CAPTCHA: "" (nonaktif), "pow", atau "http"
+	CaptchaDifficulty    int    // Tingkat kesulitan proof-of-work (bit nol di awal hash)
+	CaptchaVerifyURL     string // Endpoint siteverify untuk provider http
+	CaptchaSiteKey       string // Site key publik widget provider http
+	CaptchaSecret        string // Secret key provider http
+	CaptchaScriptURL     string // Script widget provider http
+	CaptchaWidgetClass   string // Class elemen widget provider http
+	CaptchaResponseField string // Nama input token dari widget provider http
+
+	// Notifikasi email (nonaktif jika SMTPHost kosong)
+	SMTPHost      string // Host server SMTP
+	SMTPPort      int    // Port server SMTP
+	SMTPUsername  string // Username SMTP (kosong = tanpa autentikasi)
+	SMTPPassword  string // Password SMTP
+	SMTPTLS       string // Mode TLS: "starttls", "tls" (implicit), atau "none"
+	MailFrom      string // Alamat pengirim email notifikasi
+	MailOwner     string // Penerima notifikasi pesan baru (kosong = email di site_config)
+	MailAutoReply bool   // Kirim balasan otomatis ke pengunjung
+	SiteURL       string // URL publik situs untuk tautan di email dan link kanonik/hreflang halaman
+
+	// Privasi data pengunjung
+	MessageRetentionDays int    // Umur pesan kontak sebelum dihapus permanen beserta email & log terkait (0 = simpan selamanya)
+	ConsentVersion       string // Versi kebijakan privasi yang disetujui lewat checkbox form kontak
+
+	// Server HTTP
+	HTTPReadHeaderTimeout time.Duration // Batas waktu membaca header request (menangkal koneksi lambat/slowloris)
+	HTTPReadTimeout       time.Duration // Batas waktu membaca seluruh request termasuk body
+	HTTPWriteTimeout      time.Duration // Batas waktu menulis respons (unduhan ekspor pesan dikecualikan)
+	HTTPIdleTimeout       time.Duration // Umur koneksi keep-alive yang menganggur
+	HTTPMaxHeaderBytes    int           // Ukuran maksimal header request (byte)
+	ShutdownTimeout       time.Duration // Batas waktu menunggu request yang sedang berjalan saat server dihentikan
+	TrustedProxies        []string      // IP/CIDR reverse proxy yang header X-Forwarded-For-nya dipercaya (kosong = tidak ada)
+
+	// HTTPS langsung tanpa reverse proxy (nonaktif jika TLSCertFile dan ACMEDomains kosong)
+	HTTPSPort        string   // Port HTTPS; saat TLS aktif, Port hanya melayani redirect ke HTTPS dan tantangan ACME
+	TLSCertFile      string   // File sertifikat PEM (beserta chain) untuk sertifikat statis
+	TLSKeyFile       string   // File private key PEM untuk sertifikat statis
+	ACMEDomains      []string // Domain yang sertifikatnya diterbitkan otomatis lewat ACME (misal: Let's Encrypt)
+	ACMEEmail        string   // Email kontak akun ACME (pemberitahuan sertifikat kedaluwarsa)
+	ACMEDirectoryURL string   // Directory URL server ACME (kosong = Let's Encrypt production)
+	ACMECACertFile   string   // CA tambahan untuk mempercayai server ACME uji (misal: Pebble)
+	ACMECacheDir     string   // Direktori penyimpanan akun & sertifikat ACME (taruh di volume data)
+	HSTSMaxAge       int      // max-age header Strict-Transport-Security dalam detik (0 = tanpa HSTS)
+
+	// Header keamanan
+	CSPReportOnly bool // Content Security Policy hanya melaporkan pelanggaran ke /csp-report, tidak memblokir
+
+	// Endpoint /metrics (format Prometheus); terbuka jika username dan allowlist kosong
+	MetricsUsername   string   // Username basic auth untuk scraper (kosong = tanpa basic auth)
+	MetricsPassword   string   // Password basic auth untuk scraper
+	MetricsAllowedIPs []string // IP/CIDR yang boleh scrape, dicek dari alamat koneksi langsung
+
+	// Tracing OpenTelemetry
+	TracesExporter string // none (nonaktif), otlp (OTLP/HTTP ke collector), atau stdout
+	OTLPEndpoint   string // URL lengkap endpoint OTLP/HTTP traces
+	ServiceName    string // Nama service di setiap span
 }
 
 // LoadConfig membaca konfigurasi dari environment variables
 // dan mengembalikan struct AppConfig dengan nilai default jika tidak diset
 func LoadConfig() *AppConfig {
+	// Format log mengikuti mode aplikasi kecuali diset eksplisit
+	appMode := getEnv("APP_MODE", "development")
+	logFormat := getEnv("LOG_FORMAT", "")
+	if logFormat == "" {
+		logFormat = "text"
+		if appMode == "production" {
+			logFormat = "json"
+		}
+	}
+
 	return &AppConfig{
-		Port:          getEnv("PORT", "8080"),
-		DBPath:        getEnv("DB_PATH", "./data/portfolio.db"),
-		AdminUsername:  getEnv("ADMIN_USERNAME", "admin"),
-		AdminPassword:  getEnv("ADMIN_PASSWORD", "changeme"),
-		SessionSecret: getEnv("SESSION_SECRET", "default-secret-ganti-ini"),
-		AppMode:       getEnv("APP_MODE", "development"),
+		Port:               getEnv("PORT", "8080"),
+		DBPath:             getEnv("DB_PATH", "./data/portfolio.db"),
+		AdminUsername:      getEnv("ADMIN_USERNAME", "admin"),
+		AdminPassword:      getEnv("ADMIN_PASSWORD", "changeme"),
+		SessionSecret:      getEnv("SESSION_SECRET", ""),
+		AppMode:            appMode,
+		LogFormat:          logFormat,
+		LogLevel:           getEnv("LOG_LEVEL", "info"),
+		TrashRetentionDays: getEnvInt("TRASH_RETENTION_DAYS", 30),
+		ContactRateLimit:   getEnvInt("CONTACT_RATE_LIMIT", 5),
+		ContactMinSeconds:  getEnvInt("CONTACT_MIN_SECONDS", 3),
+		SpamThreshold:      getEnvInt("SPAM_THRESHOLD", 3),
+		SpamBlockedWords:   getEnvList("SPAM_BLOCKED_WORDS", "casino,viagra,bitcoin,crypto investment,seo service,backlink,loan offer"),
+
+		// Default provider http mengikuti hCaptcha; untuk Turnstile ganti URL, class, dan nama field
+		CaptchaProvider:      getEnv("CAPTCHA_PROVIDER", ""),
+		CaptchaDifficulty:    getEnvInt("CAPTCHA_POW_DIFFICULTY", 16),
+		CaptchaVerifyURL:     getEnv("CAPTCHA_VERIFY_URL", "https://api.hcaptcha.com/siteverify"),
+		CaptchaSiteKey:       getEnv("CAPTCHA_SITE_KEY", ""),
+		CaptchaSecret:        getEnv("CAPTCHA_SECRET", ""),
+		CaptchaScriptURL:     getEnv("CAPTCHA_SCRIPT_URL", "https://js.hcaptcha.com/1/api.js"),
+		CaptchaWidgetClass:   getEnv("CAPTCHA_WIDGET_CLASS", "h-captcha"),
+		CaptchaResponseField: getEnv("CAPTCHA_RESPONSE_FIELD", "h-captcha-response"),
+
+		SMTPHost:      getEnv("SMTP_HOST", ""),
+		SMTPPort:      getEnvInt("SMTP_PORT", 587),
+		SMTPUsername:  getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:  getEnv("SMTP_PASSWORD", ""),
+		SMTPTLS:       getEnv("SMTP_TLS", "starttls"),
+		MailFrom:      getEnv("MAIL_FROM", "portfolio@localhost"),
+		MailOwner:     getEnv("MAIL_OWNER", ""),
+		MailAutoReply: getEnvBool("MAIL_AUTO_REPLY", false),
+		SiteURL:       strings.TrimRight(getEnv("SITE_URL", "http://localhost:8080"), "/"),
+
+		MessageRetentionDays: getEnvInt("MESSAGE_RETENTION_DAYS", 0),
+		ConsentVersion:       getEnv("CONSENT_VERSION", "1"),
+
+		HTTPReadHeaderTimeout: getEnvDuration("HTTP_READ_HEADER_TIMEOUT", 5*time.Second),
+		HTTPReadTimeout:       getEnvDuration("HTTP_READ_TIMEOUT", 15*time.Second),
+		HTTPWriteTimeout:      getEnvDuration("HTTP_WRITE_TIMEOUT", 30*time.Second),
+		HTTPIdleTimeout:       getEnvDuration("HTTP_IDLE_TIMEOUT", 2*time.Minute),
+		HTTPMaxHeaderBytes:    getEnvInt("HTTP_MAX_HEADER_BYTES", 64<<10),
+		ShutdownTimeout:       getEnvDuration("SHUTDOWN_TIMEOUT", 15*time.Second),
+		TrustedProxies:        getEnvList("TRUSTED_PROXIES", ""),
+
+		HTTPSPort:        getEnv("HTTPS_PORT", "443"),
+		TLSCertFile:      getEnv("TLS_CERT_FILE", ""),
+		TLSKeyFile:       getEnv("TLS_KEY_FILE", ""),
+		ACMEDomains:      getEnvList("ACME_DOMAINS", ""),
+		ACMEEmail:        getEnv("ACME_EMAIL", ""),
+		ACMEDirectoryURL: getEnv("ACME_DIRECTORY_URL", ""),
+		ACMECACertFile:   getEnv("ACME_CA_CERT_FILE", ""),
+		ACMECacheDir:     getEnv("ACME_CACHE_DIR", "./data/acme"),
+		HSTSMaxAge:       getEnvInt("HSTS_MAX_AGE", 31536000),
+
+		CSPReportOnly: getEnvBool("CSP_REPORT_ONLY", false),
+
+		MetricsUsername:   getEnv("METRICS_USERNAME", ""),
+		MetricsPassword:   getEnv("METRICS_PASSWORD", ""),
+		MetricsAllowedIPs: getEnvList("METRICS_ALLOWED_IPS", ""),
+
+		TracesExporter: getEnv("OTEL_TRACES_EXPORTER", "none"),
+		OTLPEndpoint:   getEnv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "http://localhost:4318/v1/traces"),
+		ServiceName:    getEnv("OTEL_SERVICE_NAME", "portofolio-go"),
+	}
+}
+
+// PrepareSessionSecret memastikan SESSION_SECRET layak dipakai sebelum server berjalan.
+// Di production secret yang kosong, terlalu pendek, atau masih contoh dari repo ditolak.
+// Di development secret seperti itu diganti secret acak per proses (EphemeralSecret),
+// sehingga tidak ada token yang bisa ditandatangani dengan nilai yang diketahui publik
+func (c *AppConfig) PrepareSessionSecret() error {
+	if len(c.SessionSecret) >= minSessionSecretLen && !slices.Contains(publicSessionSecrets, c.SessionSecret) {
+		return nil
+	}
+	if c.AppMode == "production" {
+		return ErrWeakSessionSecret
 	}
+	key := make([]byte, 32)
+	rand.Read(key)
+	c.SessionSecret = hex.EncodeToString(key)
+	c.EphemeralSecret = true
+	return nil
 }
 
 // getEnv mengambil nilai environment variable
@@ -36,3 +208,60 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+// getEnvInt mengambil environment variable bertipe angka
+// Jika tidak diset atau bukan angka valid, kembalikan nilai default
+func getEnvInt(key string, fallback int) int {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		slog.Warn("Environment variable bukan angka valid, memakai default", "key", key, "value", value, "default", fallback)
+		return fallback
+	}
+	return n
+}
+
+// getEnvBool mengambil environment variable bertipe boolean (1/0, true/false, dst)
+// Jika tidak diset atau tidak valid, kembalikan nilai default
+func getEnvBool(key string, fallback bool) bool {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		slog.Warn("Environment variable bukan boolean valid, memakai default", "key", key, "value", value, "default", fallback)
+		return fallback
+	}
+	return b
+}
+
+// getEnvDuration mengambil environment variable berformat durasi Go (misal: 15s, 2m)
+// Jika tidak diset atau tidak valid, kembalikan nilai default
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		slog.Warn("Environment variable bukan durasi valid, memakai default", "key", key, "value", value, "default", fallback)
+		return fallback
+	}
+	return d
+}
+
+// getEnvList mengambil environment variable berisi daftar comma-separated
+// Spasi di sekitar item dibuang dan item kosong diabaikan
+func getEnvList(key, fallback string) []string {
+	var items []string
+	for _, item := range strings.Split(getEnv(key, fallback), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPrepareSessionSecret(t *testing.T) {
+	const strong = "c2f1a9e07b4d4e3f8a6b5c4d3e2f1a0b"
+	tests := []struct {
+		name          string
+		mode          string
+		secret        string
+		wantErr       error
+		wantEphemeral bool
+	}{
+		{"production dengan secret kuat", "production", strong, nil, false},
+		{"production tanpa secret", "production", "", ErrWeakSessionSecret, false},
+		{"production default lama", "production", "default-secret-ganti-ini", ErrWeakSessionSecret, false},
+		{"production contoh .env.example", "production", "ganti-dengan-random-secret-yang-kuat", ErrWeakSessionSecret, false},
+		{"production terlalu pendek", "production", "rahasia", ErrWeakSessionSecret, false},
+		{"development dengan secret kuat", "development", strong, nil, false},
+		{"development tanpa secret", "development", "", nil, true},
+		{"development contoh .env.example", "development", "ganti-dengan-random-secret-yang-kuat", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &AppConfig{AppMode: tt.mode, SessionSecret: tt.secret}
+			err := cfg.PrepareSessionSecret()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PrepareSessionSecret = %v, want %v", err, tt.wantErr)
+			}
+			if cfg.EphemeralSecret != tt.wantEphemeral {
+				t.Errorf("EphemeralSecret = %v, want %v", cfg.EphemeralSecret, tt.wantEphemeral)
+			}
+			switch {
+			case tt.wantEphemeral:
+				if cfg.SessionSecret == tt.secret || len(cfg.SessionSecret) < minSessionSecretLen {
+					t.Errorf("secret development tidak diganti secret acak: %q", cfg.SessionSecret)
+				}
+			case cfg.SessionSecret != tt.secret:
+				t.Errorf("SessionSecret diubah menjadi %q", cfg.SessionSecret)
+			}
+		})
+	}
+
+	// Secret acak berbeda di setiap proses
+	a := &AppConfig{AppMode: "development"}
+	b := &AppConfig{AppMode: "development"}
+	a.PrepareSessionSecret()
+	b.PrepareSessionSecret()
+	if a.SessionSecret == b.SessionSecret {
+		t.Error("secret acak development sama di dua pemanggilan")
+	}
+}
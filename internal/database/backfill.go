@@ -0,0 +1,180 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"portofolio-go/internal/i18n"
+)
+
+// backfills berisi langkah migrasi data yang ditulis dalam Go,
+// dijalankan tepat setelah file SQL dengan versi yang sama dieksekusi
+var backfills = map[string]func(tx *sql.Tx) error{
+	"002_project_metadata": backfillProjectTags,
+	"005_experience_dates": backfillExperienceDates,
+}
+
+// backfillProjectTags memecah nilai projects.tech_used (comma-separated)
+// menjadi baris di tabel tags dan project_tags
+func backfillProjectTags(tx *sql.Tx) error {
+	rows, err := tx.Query("SELECT id, tech_used FROM projects")
+	if err != nil {
+		return fmt.Errorf("gagal membaca tech_used: %w", err)
+	}
+
+	// Kumpulkan dulu semua baris agar cursor tertutup sebelum INSERT
+	techUsed := make(map[int]string)
+	for rows.Next() {
+		var id int
+		var value string
+		if err := rows.Scan(&id, &value); err != nil {
+			rows.Close()
+			return fmt.Errorf("gagal scan tech_used: %w", err)
+		}
+		techUsed[id] = value
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for projectID, value := range techUsed {
+		position := 0
+		seen := make(map[string]bool)
+		for _, name := range strings.Split(value, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" || seen[strings.ToLower(name)] {
+				continue
+			}
+			seen[strings.ToLower(name)] = true
+
+			if _, err := tx.Exec("INSERT OR IGNORE INTO tags (name) VALUES (?)", name); err != nil {
+				return fmt.Errorf("gagal membuat tag %q: %w", name, err)
+			}
+			if _, err := tx.Exec(
+				"INSERT OR IGNORE INTO project_tags (project_id, tag_id, position) SELECT ?, id, ? FROM tags WHERE name = ?",
+				projectID, position, name,
+			); err != nil {
+				return fmt.Errorf("gagal menghubungkan tag %q ke project ID %d: %w", name, projectID, err)
+			}
+			position++
+		}
+	}
+
+	return nil
+}
+
+// backfillExperienceDates mengisi start_date, end_date, dan is_current
+// dari teks experiences.period (misal: "Jan 2023 - Sekarang").
+// Parsing bersifat best-effort; periode yang tidak dikenali dibiarkan kosong dan
+// teks aslinya disalin ke legacy_period oleh migration 006 sebelum period dihapus,
+// agar bisa dilengkapi manual lewat dashboard.
+func backfillExperienceDates(tx *sql.Tx) error {
+	rows, err := tx.Query("SELECT id, period FROM experiences")
+	if err != nil {
+		return fmt.Errorf("gagal membaca period: %w", err)
+	}
+
+	periods := make(map[int]string)
+	for rows.Next() {
+		var id int
+		var period string
+		if err := rows.Scan(&id, &period); err != nil {
+			rows.Close()
+			return fmt.Errorf("gagal scan period: %w", err)
+		}
+		periods[id] = period
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for id, period := range periods {
+		start, end, isCurrent, ok := parsePeriod(period)
+		if !ok {
+			slog.Warn("Periode experience tidak dikenali, teks asli disimpan; isi tanggalnya lewat dashboard", "id", id, "period", period)
+			continue
+		}
+		if _, err := tx.Exec(
+			"UPDATE experiences SET start_date = ?, end_date = ?, is_current = ? WHERE id = ?",
+			start, end, isCurrent, id,
+		); err != nil {
+			return fmt.Errorf("gagal update tanggal experience ID %d: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// periodSeparator memisahkan awal dan akhir periode: "-", "–", "—", "to", "sampai", "s/d"
+var periodSeparator = regexp.MustCompile(`(?i)\s*(?:[-–—]|\bto\b|\bsampai\b|\bhingga\b|\bs/d\b|\bs\.d\.?)\s*`)
+
+// parsePeriod mem-parsing teks periode bebas menjadi tanggal mulai/selesai
+// Mendukung nama bulan Indonesia & Inggris, "MM/YYYY", dan tahun saja.
+// ok bernilai false jika teks tidak dikenali atau rentangnya terbalik
+func parsePeriod(period string) (start time.Time, end *time.Time, isCurrent bool, ok bool) {
+	parts := periodSeparator.Split(strings.TrimSpace(period), 2)
+
+	start, ok = parseMonthYear(parts[0], false)
+	if !ok {
+		return start, nil, false, false
+	}
+
+	// Periode satu titik (misal: "Mar 2020") dianggap selesai di bulan yang sama
+	if len(parts) == 1 || strings.TrimSpace(parts[1]) == "" {
+		return start, &start, false, true
+	}
+
+	if i18n.IsPresentWord(parts[1]) {
+		return start, nil, true, true
+	}
+
+	// Rentang terbalik (selesai sebelum mulai) juga ditolak validasi dashboard,
+	// jadi teks aslinya disimpan untuk diperbaiki manual
+	e, ok := parseMonthYear(parts[1], true)
+	if !ok || e.Before(start) {
+		return start, nil, false, false
+	}
+	return start, &e, false, true
+}
+
+// parseMonthYear mem-parsing "Jan 2023", "Januari 2023", "01/2023", atau "2023"
+// Jika hanya tahun yang diketahui, isEnd menentukan apakah dipakai Januari atau Desember
+func parseMonthYear(s string, isEnd bool) (time.Time, bool) {
+	fields := strings.FieldsFunc(strings.TrimSpace(s), func(r rune) bool {
+		return r == ' ' || r == '/' || r == ',' || r == '.'
+	})
+
+	switch len(fields) {
+	case 1:
+		year, err := strconv.Atoi(fields[0])
+		if err != nil || year < 1900 {
+			return time.Time{}, false
+		}
+		month := time.January
+		if isEnd {
+			month = time.December
+		}
+		return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC), true
+	case 2:
+		year, err := strconv.Atoi(fields[1])
+		if err != nil || year < 1900 {
+			return time.Time{}, false
+		}
+		month, ok := i18n.ParseMonth(fields[0])
+		if !ok {
+			n, err := strconv.Atoi(fields[0])
+			if err != nil || n < 1 || n > 12 {
+				return time.Time{}, false
+			}
+			month = time.Month(n)
+		}
+		return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC), true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,169 @@
+package database
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func month(year int, m time.Month) time.Time {
+	return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParsePeriod(t *testing.T) {
+	tests := []struct {
+		period      string
+		wantStart   time.Time
+		wantEnd     time.Time // Nol jika end nil
+		wantCurrent bool
+		wantOK      bool
+	}{
+		// Nama bulan Indonesia
+		{"Januari 2023 - Desember 2024", month(2023, time.January), month(2024, time.December), false, true},
+		{"Agu 2021 – Okt 2022", month(2021, time.August), month(2022, time.October), false, true},
+		{"Mei 2020 sampai Agt 2021", month(2020, time.May), month(2021, time.August), false, true},
+		{"Mar 2019 s/d Des 2019", month(2019, time.March), month(2019, time.December), false, true},
+		// Nama bulan Inggris
+		{"March 2021 to May 2022", month(2021, time.March), month(2022, time.May), false, true},
+		{"Sept. 2018 — Oct 2019", month(2018, time.September), month(2019, time.October), false, true},
+		// Masih bekerja
+		{"Jan 2023 - Sekarang", month(2023, time.January), time.Time{}, true, true},
+		{"Feb 2022 - Present", month(2022, time.February), time.Time{}, true, true},
+		{"Juni 2024 - saat ini", month(2024, time.June), time.Time{}, true, true},
+		// MM/YYYY dan tahun saja
+		{"01/2020 - 12/2021", month(2020, time.January), month(2021, time.December), false, true},
+		{"2019 - 2021", month(2019, time.January), month(2021, time.December), false, true},
+		{"2017", month(2017, time.January), month(2017, time.January), false, true},
+		{"Maret 2021", month(2021, time.March), month(2021, time.March), false, true},
+		{"  Apr 2020 -  ", month(2020, time.April), month(2020, time.April), false, true},
+		// Rentang terbalik ditolak seperti di validasi dashboard
+		{"Des 2023 - Jan 2022", time.Time{}, time.Time{}, false, false},
+		{"2021 - 2019", time.Time{}, time.Time{}, false, false},
+		// Teks yang tidak dikenali
+		{"", time.Time{}, time.Time{}, false, false},
+		{"Musim panas 2020", time.Time{}, time.Time{}, false, false},
+		{"Q3 2021 - Q1 2022", time.Time{}, time.Time{}, false, false},
+		{"Jan 2023 - entahlah", time.Time{}, time.Time{}, false, false},
+		{"13/2020 - 01/2021", time.Time{}, time.Time{}, false, false},
+		{"Jan 99", time.Time{}, time.Time{}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.period, func(t *testing.T) {
+			start, end, isCurrent, ok := parsePeriod(tt.period)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if !start.Equal(tt.wantStart) {
+				t.Errorf("start = %v, want %v", start, tt.wantStart)
+			}
+			switch {
+			case tt.wantEnd.IsZero() && end != nil:
+				t.Errorf("end = %v, want nil", *end)
+			case !tt.wantEnd.IsZero() && (end == nil || !end.Equal(tt.wantEnd)):
+				t.Errorf("end = %v, want %v", end, tt.wantEnd)
+			}
+			if isCurrent != tt.wantCurrent {
+				t.Errorf("isCurrent = %v, want %v", isCurrent, tt.wantCurrent)
+			}
+		})
+	}
+}
+
+func TestParseMonthYear(t *testing.T) {
+	tests := []struct {
+		input  string
+		isEnd  bool
+		want   time.Time
+		wantOK bool
+	}{
+		{"Januari 2023", false, month(2023, time.January), true},
+		{"Agustus, 2022", false, month(2022, time.August), true},
+		{"okt 2020", false, month(2020, time.October), true},
+		{"December 2019", false, month(2019, time.December), true},
+		{"May 2021", false, month(2021, time.May), true},
+		{"Jan. 2024", false, month(2024, time.January), true},
+		{"07/2018", false, month(2018, time.July), true},
+		{"2020", false, month(2020, time.January), true},
+		{"2020", true, month(2020, time.December), true},
+		{"00/2020", false, time.Time{}, false},
+		{"Foo 2020", false, time.Time{}, false},
+		{"Mar 1850", false, time.Time{}, false},
+		{"Maret", false, time.Time{}, false},
+		{"1 Maret 2020", false, time.Time{}, false},
+		{"", false, time.Time{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseMonthYear(tt.input, tt.isEnd)
+		if ok != tt.wantOK || !got.Equal(tt.want) {
+			t.Errorf("parseMonthYear(%q, %v) = %v, %v; want %v, %v", tt.input, tt.isEnd, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+// TestMigrateExperiencePeriods menjalankan migration 005–006 atas data period lama dan
+// memastikan teks yang gagal di-parse tersimpan di legacy_period, bukan hilang
+func TestMigrateExperiencePeriods(t *testing.T) {
+	t.Chdir("../..") // migrations/ dibaca relatif dari root repo
+
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "test.db")+"?_foreign_keys=on")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// Schema sebelum tanggal terstruktur (001–004)
+	if _, err := db.Exec(`CREATE TABLE schema_migrations (
+		version TEXT PRIMARY KEY,
+		applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	)`); err != nil {
+		t.Fatal(err)
+	}
+	for _, version := range []string{"001_init", "002_project_metadata", "003_drop_projects_tech_used", "004_tag_tech_stack_link"} {
+		if err := applyMigration(db, version, filepath.Join(migrationsDir, version+".sql")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	periods := map[string]struct {
+		start, end string // Format date(); kosong jika NULL
+		legacy     string // Kosong jika NULL
+	}{
+		"Jan 2023 - Sekarang":   {start: "2023-01-01"},
+		"Maret 2020 - Mei 2021": {start: "2020-03-01", end: "2021-05-01"},
+		"2018":                  {start: "2018-01-01", end: "2018-01-01"},
+		"Des 2023 - Jan 2022":   {legacy: "Des 2023 - Jan 2022"},
+		"Musim panas 2020":      {legacy: "Musim panas 2020"},
+	}
+	ids := make(map[string]int64)
+	for period := range periods {
+		res, err := db.Exec(
+			"INSERT INTO experiences (company, role, period, description) VALUES ('PT Contoh', 'Backend Engineer', ?, '-')", period,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[period], _ = res.LastInsertId()
+	}
+
+	if err := runMigrations(db); err != nil {
+		t.Fatalf("runMigrations: %v", err)
+	}
+
+	for period, want := range periods {
+		var start, end, legacy string
+		if err := db.QueryRow(
+			"SELECT COALESCE(date(start_date), ''), COALESCE(date(end_date), ''), COALESCE(legacy_period, '') FROM experiences WHERE id = ?",
+			ids[period],
+		).Scan(&start, &end, &legacy); err != nil {
+			t.Fatal(err)
+		}
+		if start != want.start || end != want.end || legacy != want.legacy {
+			t.Errorf("%q: start=%q end=%q legacy_period=%q; want start=%q end=%q legacy_period=%q",
+				period, start, end, legacy, want.start, want.end, want.legacy)
+		}
+	}
+}
@@ -1,15 +1,21 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	// Driver SQLite — menggunakan CGO
 	_ "github.com/mattn/go-sqlite3"
 )
 
+// migrationsDir adalah folder tempat file migration SQL disimpan
+const migrationsDir = "migrations"
+
 // InitDB menginisialisasi koneksi database SQLite
 // Fungsi ini membuat file database jika belum ada,
 // lalu menjalankan migration script untuk membuat tabel-tabel
@@ -21,7 +27,9 @@ func InitDB(dbPath string) (*sql.DB, error) {
 	}
 
 	// Buka koneksi ke SQLite
-	db, err := sql.Open("sqlite3", dbPath+"?_journal_mode=WAL&_busy_timeout=5000")
+	// _foreign_keys diset di DSN agar berlaku untuk setiap koneksi di pool,
+	// bukan hanya koneksi pertama
+	db, err := sql.Open("sqlite3", dbPath+"?_journal_mode=WAL&_busy_timeout=5000&_foreign_keys=on")
 	if err != nil {
 		return nil, fmt.Errorf("gagal membuka database: %w", err)
 	}
@@ -41,23 +49,111 @@ func InitDB(dbPath string) (*sql.DB, error) {
 		return nil, fmt.Errorf("gagal menjalankan migration: %w", err)
 	}
 
+	// Siapkan indeks full-text search pesan kontak (jika FTS5 tersedia)
+	if err := ensureMessageSearch(db); err != nil {
+		return nil, err
+	}
+
 	return db, nil
 }
 
-// runMigrations membaca dan mengeksekusi file migration SQL
-// File migration disimpan di folder migrations/
+// runMigrations membaca dan mengeksekusi file migration SQL secara berurutan
+// File migration disimpan di folder migrations/ dengan format NNN_nama.sql.
+// Setiap file hanya dijalankan sekali; versi yang sudah diterapkan dicatat
+// di tabel schema_migrations.
 func runMigrations(db *sql.DB) error {
-	// Baca file migration
-	migrationFile := "migrations/001_init.sql"
-	sqlBytes, err := os.ReadFile(migrationFile)
+	// Buat tabel pencatat migration jika belum ada
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version TEXT PRIMARY KEY,
+		applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	)`); err != nil {
+		return fmt.Errorf("gagal membuat tabel schema_migrations: %w", err)
+	}
+
+	files, err := migrationFiles()
 	if err != nil {
-		return fmt.Errorf("gagal membaca file migration %s: %w", migrationFile, err)
+		return err
 	}
 
-	// Eksekusi SQL migration
-	if _, err := db.Exec(string(sqlBytes)); err != nil {
-		return fmt.Errorf("gagal mengeksekusi migration: %w", err)
+	applied, err := appliedMigrations(context.Background(), db)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		version := strings.TrimSuffix(filepath.Base(file), ".sql")
+		if applied[version] {
+			continue
+		}
+		if err := applyMigration(db, version, file); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
+
+// migrationFiles mengembalikan daftar file migration yang diurutkan berdasarkan nama
+func migrationFiles() ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(migrationsDir, "*.sql"))
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca folder migration: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("tidak ada file migration di %s", migrationsDir)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// appliedMigrations mengambil set versi migration yang sudah diterapkan
+func appliedMigrations(ctx context.Context, db *sql.DB) (map[string]bool, error) {
+	rows, err := db.QueryContext(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil daftar migration: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[string]bool)
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("gagal scan versi migration: %w", err)
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
+
+// applyMigration menjalankan satu file migration beserta backfill Go-nya (jika ada)
+// di dalam satu transaksi, lalu mencatat versinya di schema_migrations
+func applyMigration(db *sql.DB, version, file string) error {
+	sqlBytes, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("gagal membaca file migration %s: %w", file, err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi migration %s: %w", version, err)
+	}
+	defer tx.Rollback()
+
+	// Eksekusi SQL migration
+	if _, err := tx.Exec(string(sqlBytes)); err != nil {
+		return fmt.Errorf("gagal mengeksekusi migration %s: %w", version, err)
+	}
+
+	// Jalankan backfill data yang tidak bisa ditulis dengan SQL biasa
+	if backfill, ok := backfills[version]; ok {
+		if err := backfill(tx); err != nil {
+			return fmt.Errorf("gagal backfill migration %s: %w", version, err)
+		}
+	}
+
+	if _, err := tx.Exec("INSERT INTO schema_migrations (version) VALUES (?)", version); err != nil {
+		return fmt.Errorf("gagal mencatat migration %s: %w", version, err)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,53 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PendingMigrations mengembalikan versi migration di folder migrations/ yang belum
+// diterapkan ke database (kosong = skema sudah versi terbaru)
+func PendingMigrations(ctx context.Context, db *sql.DB) ([]string, error) {
+	files, err := migrationFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := appliedMigrations(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []string
+	for _, file := range files {
+		if version := strings.TrimSuffix(filepath.Base(file), ".sql"); !applied[version] {
+			pending = append(pending, version)
+		}
+	}
+	return pending, nil
+}
+
+// CheckWritable memastikan direktori database masih bisa ditulisi (misal: volume tidak
+// read-only dan disk tidak penuh) dengan membuat lalu menghapus file sementara
+func CheckWritable(dbPath string) error {
+	f, err := os.CreateTemp(filepath.Dir(dbPath), ".readyz-*")
+	if err != nil {
+		return fmt.Errorf("gagal menulis ke direktori database: %w", err)
+	}
+	name := f.Name()
+	_, err = f.Write([]byte("ok"))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if removeErr := os.Remove(name); err == nil {
+		err = removeErr
+	}
+	if err != nil {
+		return fmt.Errorf("gagal menulis ke direktori database: %w", err)
+	}
+	return nil
+}
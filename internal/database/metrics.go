@@ -0,0 +1,33 @@
+package database
+
+import (
+	"database/sql"
+	"portofolio-go/internal/metrics"
+)
+
+// RegisterMetrics mendaftarkan statistik pool koneksi (sql.DBStats) sebagai metrik;
+// nilainya dibaca langsung dari db setiap kali /metrics di-scrape
+func RegisterMetrics(db *sql.DB) {
+	stat := func(pick func(sql.DBStats) float64) func() float64 {
+		return func() float64 { return pick(db.Stats()) }
+	}
+
+	metrics.NewGaugeFunc("db_max_open_connections", "Batas maksimal koneksi database yang terbuka (0 = tanpa batas).",
+		stat(func(s sql.DBStats) float64 { return float64(s.MaxOpenConnections) }))
+	metrics.NewGaugeFunc("db_open_connections", "Jumlah koneksi database yang terbuka (dipakai + menganggur).",
+		stat(func(s sql.DBStats) float64 { return float64(s.OpenConnections) }))
+	metrics.NewGaugeFunc("db_in_use_connections", "Jumlah koneksi database yang sedang dipakai.",
+		stat(func(s sql.DBStats) float64 { return float64(s.InUse) }))
+	metrics.NewGaugeFunc("db_idle_connections", "Jumlah koneksi database yang menganggur.",
+		stat(func(s sql.DBStats) float64 { return float64(s.Idle) }))
+	metrics.NewCounterFunc("db_wait_count_total", "Jumlah total menunggu koneksi database karena pool penuh.",
+		stat(func(s sql.DBStats) float64 { return float64(s.WaitCount) }))
+	metrics.NewCounterFunc("db_wait_duration_seconds_total", "Total waktu menunggu koneksi database karena pool penuh.",
+		stat(func(s sql.DBStats) float64 { return s.WaitDuration.Seconds() }))
+	metrics.NewCounterFunc("db_max_idle_closed_total", "Jumlah koneksi ditutup karena melebihi batas koneksi menganggur.",
+		stat(func(s sql.DBStats) float64 { return float64(s.MaxIdleClosed) }))
+	metrics.NewCounterFunc("db_max_idle_time_closed_total", "Jumlah koneksi ditutup karena menganggur terlalu lama.",
+		stat(func(s sql.DBStats) float64 { return float64(s.MaxIdleTimeClosed) }))
+	metrics.NewCounterFunc("db_max_lifetime_closed_total", "Jumlah koneksi ditutup karena melewati umur maksimal.",
+		stat(func(s sql.DBStats) float64 { return float64(s.MaxLifetimeClosed) }))
+}
@@ -0,0 +1,82 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+)
+
+// messageSearchTriggers menjaga indeks FTS5 contact_messages_fts tetap sinkron
+// dengan tabel contact_messages (external content table)
+var messageSearchTriggers = map[string]string{
+	"contact_messages_fts_insert": `CREATE TRIGGER contact_messages_fts_insert AFTER INSERT ON contact_messages BEGIN
+		INSERT INTO contact_messages_fts (rowid, name, email, message) VALUES (new.id, new.name, new.email, new.message);
+	END`,
+	"contact_messages_fts_delete": `CREATE TRIGGER contact_messages_fts_delete AFTER DELETE ON contact_messages BEGIN
+		INSERT INTO contact_messages_fts (contact_messages_fts, rowid, name, email, message) VALUES ('delete', old.id, old.name, old.email, old.message);
+	END`,
+	"contact_messages_fts_update": `CREATE TRIGGER contact_messages_fts_update AFTER UPDATE OF name, email, message ON contact_messages BEGIN
+		INSERT INTO contact_messages_fts (contact_messages_fts, rowid, name, email, message) VALUES ('delete', old.id, old.name, old.email, old.message);
+		INSERT INTO contact_messages_fts (rowid, name, email, message) VALUES (new.id, new.name, new.email, new.message);
+	END`,
+}
+
+// ensureMessageSearch menyiapkan indeks full-text search pesan kontak (SQLite FTS5).
+// FTS5 hanya tersedia jika binary di-build dengan tag sqlite_fts5; tanpa itu trigger
+// indeks dihapus (agar INSERT tetap jalan) dan pencarian memakai LIKE biasa.
+// Indeks dibangun ulang setiap kali trigger baru dibuat, sehingga pesan yang masuk
+// saat binary tanpa FTS5 berjalan ikut terindeks
+func ensureMessageSearch(db *sql.DB) error {
+	// CREATE VIRTUAL TABLE IF NOT EXISTS tidak memuat modul jika tabelnya sudah ada,
+	// jadi ketersediaan FTS5 dicek langsung dari opsi compile SQLite
+	var available bool
+	if err := db.QueryRow("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&available); err != nil {
+		return fmt.Errorf("gagal mengecek dukungan FTS5: %w", err)
+	}
+	if !available {
+		slog.Warn("SQLite tanpa FTS5 (build dengan -tags sqlite_fts5), pencarian pesan memakai LIKE")
+		for name := range messageSearchTriggers {
+			if _, err := db.Exec("DROP TRIGGER IF EXISTS " + name); err != nil {
+				return fmt.Errorf("gagal menghapus trigger %s: %w", name, err)
+			}
+		}
+		return nil
+	}
+
+	_, err := db.Exec(`CREATE VIRTUAL TABLE IF NOT EXISTS contact_messages_fts USING fts5(
+		name, email, message,
+		content = 'contact_messages', content_rowid = 'id',
+		tokenize = 'unicode61 remove_diacritics 2'
+	)`)
+	if err != nil {
+		return fmt.Errorf("gagal membuat indeks pencarian pesan: %w", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi indeks pencarian: %w", err)
+	}
+	defer tx.Rollback()
+
+	rebuild := false
+	for name, ddl := range messageSearchTriggers {
+		var exists bool
+		if err := tx.QueryRow("SELECT EXISTS (SELECT 1 FROM sqlite_master WHERE type = 'trigger' AND name = ?)", name).Scan(&exists); err != nil {
+			return fmt.Errorf("gagal mengecek trigger %s: %w", name, err)
+		}
+		if exists {
+			continue
+		}
+		if _, err := tx.Exec(ddl); err != nil {
+			return fmt.Errorf("gagal membuat trigger %s: %w", name, err)
+		}
+		rebuild = true
+	}
+
+	if rebuild {
+		if _, err := tx.Exec("INSERT INTO contact_messages_fts (contact_messages_fts) VALUES ('rebuild')"); err != nil {
+			return fmt.Errorf("gagal membangun ulang indeks pencarian pesan: %w", err)
+		}
+	}
+	return tx.Commit()
+}
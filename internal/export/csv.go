@@ -0,0 +1,63 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"portofolio-go/internal/model"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// csvHeader adalah nama kolom baris pertama file CSV
+var csvHeader = []string{"id", "created_at", "status", "name", "email", "locale", "message", "consent_version"}
+
+// csvWriter menulis pesan sebagai baris CSV (RFC 4180, quoting oleh encoding/csv)
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) (*csvWriter, error) {
+	cw := &csvWriter{w: csv.NewWriter(w)}
+	if err := cw.w.Write(csvHeader); err != nil {
+		return nil, fmt.Errorf("gagal menulis header CSV: %w", err)
+	}
+	return cw, nil
+}
+
+// Write menulis satu pesan sebagai satu baris CSV
+func (cw *csvWriter) Write(msg model.ContactMessage) error {
+	err := cw.w.Write([]string{
+		strconv.Itoa(msg.ID),
+		msg.CreatedAt.UTC().Format(time.RFC3339),
+		msg.Status,
+		csvCell(unescape(msg.Name)),
+		csvCell(unescape(msg.Email)),
+		msg.Locale,
+		csvCell(unescape(msg.Message)),
+		csvCell(unescape(msg.Consent)),
+	})
+	if err != nil {
+		return fmt.Errorf("gagal menulis baris CSV pesan %d: %w", msg.ID, err)
+	}
+	return nil
+}
+
+// Close mengirim sisa buffer CSV ke output
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	if err := cw.w.Error(); err != nil {
+		return fmt.Errorf("gagal menulis CSV: %w", err)
+	}
+	return nil
+}
+
+// csvCell mencegah formula injection saat CSV dibuka di spreadsheet:
+// sel yang diawali = + - @ tab atau CR diberi awalan tanda kutip tunggal
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,59 @@
+// Package export menulis pesan kontak ke format yang bisa dibawa keluar aplikasi:
+// CSV (untuk spreadsheet/CRM) dan mbox RFC 4155 (untuk mail client).
+// Pesan ditulis satu per satu ke io.Writer sehingga ekspor besar tetap di-stream
+package export
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"portofolio-go/internal/model"
+	"slices"
+)
+
+// Format ekspor yang didukung
+const (
+	FormatCSV  = "csv"  // Satu baris per pesan, dengan header kolom
+	FormatMbox = "mbox" // Satu email per pesan (mboxrd, RFC 4155)
+)
+
+// Formats adalah daftar format ekspor untuk pilihan di dashboard dan CLI
+var Formats = []string{FormatCSV, FormatMbox}
+
+// Writer menulis pesan kontak satu per satu ke output
+// Close wajib dipanggil setelah pesan terakhir agar buffer terkirim
+type Writer interface {
+	Write(msg model.ContactMessage) error
+	Close() error
+}
+
+// SupportedFormat mengecek apakah format ekspor dikenal
+func SupportedFormat(format string) bool {
+	return slices.Contains(Formats, format)
+}
+
+// NewWriter membuat Writer untuk format tertentu
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w)
+	case FormatMbox:
+		return newMboxWriter(w), nil
+	default:
+		return nil, fmt.Errorf("format ekspor tidak dikenal: %q", format)
+	}
+}
+
+// ContentType mengembalikan MIME type file hasil ekspor
+func ContentType(format string) string {
+	if format == FormatMbox {
+		return "application/mbox"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// unescape mengembalikan teks pesan ke bentuk asli
+// (nama, email, dan isi pesan disimpan dalam bentuk ter-escape HTML)
+func unescape(s string) string {
+	return html.UnescapeString(s)
+}
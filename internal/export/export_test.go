@@ -0,0 +1,154 @@
+package export
+
+import (
+	"portofolio-go/internal/model"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCSVCell(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{`=HYPERLINK("http://evil.test","klik")`, `'=HYPERLINK("http://evil.test","klik")`},
+		{"+62 812 3456", "'+62 812 3456"},
+		{"-2+3", "'-2+3"},
+		{"@SUM(A1:A2)", "'@SUM(A1:A2)"},
+		{"\t=1+1", "'\t=1+1"},
+		{"\r=1+1", "'\r=1+1"},
+		{"Budi = pelanggan", "Budi = pelanggan"}, // Hanya karakter pertama yang diperiksa
+		{"'sudah dikutip", "'sudah dikutip"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := csvCell(tt.value); got != tt.want {
+			t.Errorf("csvCell(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestCSVWriter(t *testing.T) {
+	created := time.Date(2024, time.March, 5, 7, 8, 9, 0, time.UTC)
+	messages := []model.ContactMessage{
+		{
+			ID:        1,
+			Name:      "=HYPERLINK(&#34;http://evil.test&#34;,&#34;klik&#34;)", // Tersimpan ter-escape HTML
+			Email:     "budi@example.com",
+			Message:   "a,b\n&#34;kutip&#34;\r\nbaris 3",
+			Status:    model.MessageRead,
+			Locale:    "id",
+			Consent:   "2024-01",
+			CreatedAt: created,
+		},
+		{
+			ID:        2,
+			Name:      "-Siti",
+			Email:     "@siti@example.com",
+			Message:   "+SUM(A1)",
+			Status:    model.MessageNew,
+			CreatedAt: created.Add(time.Hour),
+		},
+	}
+
+	var out strings.Builder
+	w, err := NewWriter(FormatCSV, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, msg := range messages {
+		if err := w.Write(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "id,created_at,status,name,email,locale,message,consent_version\n" +
+		`1,2024-03-05T07:08:09Z,read,"'=HYPERLINK(""http://evil.test"",""klik"")",budi@example.com,id,"a,b` + "\n" +
+		`""kutip""` + "\r\n" +
+		`baris 3",2024-01` + "\n" +
+		"2,2024-03-05T08:08:09Z,new,'-Siti,'@siti@example.com,,'+SUM(A1),\n"
+	if got := out.String(); got != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMboxWriter(t *testing.T) {
+	created := time.Date(2024, time.March, 5, 7, 8, 9, 0, time.UTC)
+	messages := []model.ContactMessage{
+		{
+			ID:    1,
+			Name:  "Budi",
+			Email: "budi@example.com",
+			// Baris "From " (dengan atau tanpa ">") diberi ">" tambahan; yang lain tidak diubah
+			Message:   "Halo\r\nFrom x\n>From x\n>>From y\nFromage\n From spasi\nfrom kecil\n\n",
+			Status:    model.MessageReplied,
+			Locale:    "id",
+			Consent:   "2024-01",
+			CreatedAt: created,
+		},
+		{
+			ID:        2,
+			Name:      "Eve\r\nBcc: korban@example.com", // Baris baru tidak boleh menyisipkan header
+			Email:     "eve@example.com",
+			Message:   "From &lt;bos&gt;",
+			Status:    model.MessageNew,
+			CreatedAt: created.Add(time.Hour),
+		},
+	}
+
+	var out strings.Builder
+	w, err := NewWriter(FormatMbox, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, msg := range messages {
+		if err := w.Write(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "From budi@example.com Tue Mar  5 07:08:09 2024\n" +
+		"From: \"Budi\" <budi@example.com>\n" +
+		"Date: Tue, 05 Mar 2024 07:08:09 +0000\n" +
+		"Subject: Pesan baru dari Budi\n" +
+		"Message-ID: <contact-1@portofolio-go.local>\n" +
+		"Content-Language: id\n" +
+		"Status: RO\n" +
+		"X-Status: A\n" +
+		"X-Portfolio-Status: replied\n" +
+		"X-Consent-Version: 2024-01\n" +
+		"MIME-Version: 1.0\n" +
+		"Content-Type: text/plain; charset=utf-8\n" +
+		"Content-Transfer-Encoding: 8bit\n" +
+		"\n" +
+		"Halo\n" +
+		">From x\n" +
+		">>From x\n" +
+		">>>From y\n" +
+		"Fromage\n" +
+		" From spasi\n" +
+		"from kecil\n" +
+		"\n" +
+		"From eve@example.com Tue Mar  5 08:08:09 2024\n" +
+		"From: \"Eve Bcc: korban@example.com\" <eve@example.com>\n" +
+		"Date: Tue, 05 Mar 2024 08:08:09 +0000\n" +
+		"Subject: Pesan baru dari Eve Bcc: korban@example.com\n" +
+		"Message-ID: <contact-2@portofolio-go.local>\n" +
+		"X-Portfolio-Status: new\n" +
+		"MIME-Version: 1.0\n" +
+		"Content-Type: text/plain; charset=utf-8\n" +
+		"Content-Transfer-Encoding: 8bit\n" +
+		"\n" +
+		">From <bos>\n" +
+		"\n"
+	if got := out.String(); got != want {
+		t.Errorf("mbox =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,91 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"mime"
+	"net/mail"
+	"portofolio-go/internal/i18n"
+	"portofolio-go/internal/model"
+	"strings"
+	"time"
+)
+
+// mboxDate adalah format tanggal di baris pemisah "From " (asctime, RFC 4155)
+const mboxDate = "Mon Jan _2 15:04:05 2006"
+
+// mboxWriter menulis pesan sebagai email teks biasa dalam file mbox varian mboxrd:
+// baris isi yang diawali "From " (dengan nol atau lebih ">") diberi awalan ">"
+// agar tidak terbaca sebagai awal email baru
+type mboxWriter struct {
+	w *bufio.Writer
+}
+
+func newMboxWriter(w io.Writer) *mboxWriter {
+	return &mboxWriter{w: bufio.NewWriter(w)}
+}
+
+// Write menulis satu pesan sebagai satu email di mbox
+func (mw *mboxWriter) Write(msg model.ContactMessage) error {
+	name := headerValue(unescape(msg.Name))
+	email := headerValue(unescape(msg.Email))
+
+	sender := email
+	if sender == "" || strings.ContainsAny(sender, " \t") {
+		sender = "MAILER-DAEMON"
+	}
+	fmt.Fprintf(mw.w, "From %s %s\n", sender, msg.CreatedAt.UTC().Format(mboxDate))
+
+	from := (&mail.Address{Name: name, Address: email}).String()
+	subject := i18n.T(i18n.Default, "email.owner_subject", name)
+	fmt.Fprintf(mw.w, "From: %s\n", from)
+	fmt.Fprintf(mw.w, "Date: %s\n", msg.CreatedAt.Format(time.RFC1123Z))
+	fmt.Fprintf(mw.w, "Subject: %s\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(mw.w, "Message-ID: <contact-%d@portofolio-go.local>\n", msg.ID)
+	if msg.Locale != "" {
+		fmt.Fprintf(mw.w, "Content-Language: %s\n", msg.Locale)
+	}
+	// Status/X-Status dipahami mutt, Thunderbird, dll: R = dibaca, A = dibalas
+	if msg.Status != model.MessageNew {
+		fmt.Fprint(mw.w, "Status: RO\n")
+	}
+	if msg.Status == model.MessageReplied {
+		fmt.Fprint(mw.w, "X-Status: A\n")
+	}
+	fmt.Fprintf(mw.w, "X-Portfolio-Status: %s\n", msg.Status)
+	if msg.Consent != "" {
+		fmt.Fprintf(mw.w, "X-Consent-Version: %s\n", headerValue(unescape(msg.Consent)))
+	}
+	fmt.Fprint(mw.w, "MIME-Version: 1.0\n")
+	fmt.Fprint(mw.w, "Content-Type: text/plain; charset=utf-8\n")
+	fmt.Fprint(mw.w, "Content-Transfer-Encoding: 8bit\n\n")
+
+	body := strings.ReplaceAll(unescape(msg.Message), "\r\n", "\n")
+	for _, line := range strings.Split(strings.TrimRight(body, "\n"), "\n") {
+		if strings.HasPrefix(strings.TrimLeft(line, ">"), "From ") {
+			mw.w.WriteByte('>')
+		}
+		mw.w.WriteString(line)
+		mw.w.WriteByte('\n')
+	}
+
+	// Baris kosong memisahkan email dari baris "From " berikutnya
+	if err := mw.w.WriteByte('\n'); err != nil {
+		return fmt.Errorf("gagal menulis mbox pesan %d: %w", msg.ID, err)
+	}
+	return nil
+}
+
+// Close mengirim sisa buffer mbox ke output
+func (mw *mboxWriter) Close() error {
+	if err := mw.w.Flush(); err != nil {
+		return fmt.Errorf("gagal menulis mbox: %w", err)
+	}
+	return nil
+}
+
+// headerValue membuang baris baru agar nilai tidak bisa menyisipkan header lain
+func headerValue(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
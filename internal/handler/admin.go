@@ -1,12 +1,20 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 	"portofolio-go/internal/config"
+	"portofolio-go/internal/export"
+	"portofolio-go/internal/i18n"
 	"portofolio-go/internal/middleware"
 	"portofolio-go/internal/model"
 	"portofolio-go/internal/service"
+	"portofolio-go/internal/webhook"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -39,21 +47,24 @@ func (h *AdminHandler) Login(c *gin.Context) {
 
 	// Validasi input form login
 	if err := c.ShouldBind(&form); err != nil {
+		adminLogins.Inc("invalid")
 		c.HTML(http.StatusBadRequest, "login.html", gin.H{
-			"error": "Username dan password harus diisi.",
+			"error": i18n.T(requestLocale(c), "flash.login_invalid"),
 		})
 		return
 	}
 
 	// Cek credential — bandingkan dengan config dari environment
 	if form.Username != h.cfg.AdminUsername || form.Password != h.cfg.AdminPassword {
+		adminLogins.Inc("failed")
 		c.HTML(http.StatusUnauthorized, "login.html", gin.H{
-			"error": "Username atau password salah.",
+			"error": i18n.T(requestLocale(c), "flash.login_failed"),
 		})
 		return
 	}
 
 	// Login berhasil — buat session dan redirect ke dashboard
+	adminLogins.Inc("success")
 	middleware.CreateSession(c, form.Username)
 	c.Redirect(http.StatusFound, "/admin")
 }
@@ -71,20 +82,91 @@ func (h *AdminHandler) Logout(c *gin.Context) {
 // Dashboard menampilkan halaman utama admin panel
 // Memuat semua data untuk ditampilkan di tabel CRUD
 func (h *AdminHandler) Dashboard(c *gin.Context) {
-	// Ambil semua data untuk ditampilkan
-	experiences, _ := h.svc.GetAllExperiences()
-	projects, _ := h.svc.GetAllProjects()
-	techStacks, _ := h.svc.GetAllTechStacks()
-	messages, _ := h.svc.GetAllContactMessages()
-	siteConfig, _ := h.svc.GetAllConfig()
+	// Ambil semua data untuk ditampilkan. Data yang gagal diambil tampil kosong agar
+	// dashboard tetap bisa dipakai; error-nya dicatat di log akses request ini
+	ctx := c.Request.Context()
+	experiences, err := h.svc.GetAllExperiences(ctx)
+	logError(c, err)
+	projects, err := h.svc.GetAllProjects(ctx)
+	logError(c, err)
+	techStacks, err := h.svc.GetTechStacksWithProjects(ctx)
+	logError(c, err)
+	tags, err := h.svc.GetAllTags(ctx)
+	logError(c, err)
+	messageFilter := messageFilterFromQuery(c.Request.URL.Query())
+	messagePage, err := h.svc.SearchMessages(ctx, messageFilter)
+	if errors.Is(err, service.ErrInvalidStatus) {
+		messageFilter.Status = ""
+		messagePage, err = h.svc.SearchMessages(ctx, messageFilter)
+	}
+	if err != nil {
+		logError(c, err)
+		messagePage = &model.MessagePage{Page: 1, TotalPages: 1}
+	}
+	messageFilter.Page = messagePage.Page
+	messageCounts, err := h.svc.CountMessagesByStatus(ctx)
+	logError(c, err)
+	spam, err := h.svc.GetSpamMessages(ctx)
+	logError(c, err)
+	siteConfig, err := h.svc.GetAllConfig(ctx)
+	logError(c, err)
+	trash, err := h.svc.GetTrash(ctx)
+	logError(c, err)
+	webhooks, err := h.svc.GetWebhooks(ctx)
+	logError(c, err)
+	deliveries, err := h.svc.GetWebhookDeliveries(ctx)
+	logError(c, err)
+	privacyRequests, err := h.svc.GetPrivacyRequests(ctx)
+	logError(c, err)
+	cspReports, err := h.svc.GetCSPReports(ctx)
+	logError(c, err)
+
+	// Editor terjemahan menampilkan locale selain bahasa Indonesia
+	translationLocale := translationLocaleFromQuery(c.Query("translation_locale"))
+	translations, err := h.svc.GetTranslationEditor(ctx, translationLocale)
+	logError(c, err)
+	translationMissing := 0
+	for _, entry := range translations {
+		translationMissing += entry.Missing
+	}
 
 	c.HTML(http.StatusOK, "dashboard.html", gin.H{
-		"experiences": experiences,
-		"projects":    projects,
-		"techStacks":  techStacks,
-		"messages":    messages,
-		"siteConfig":  siteConfig,
-		"username":    c.GetString("admin_username"),
+		"experiences":        experiences,
+		"projects":           projects,
+		"techStacks":         techStacks,
+		"tags":               tags,
+		"messages":           messagePage.Messages,
+		"messagePage":        messagePage,
+		"messageFilter":      messageFilter,
+		"messageFilterQuery": messageFilterValues(messageFilter),
+		"messagePrevURL":     messagePageURL(messageFilter, messagePage.Page-1),
+		"messageNextURL":     messagePageURL(messageFilter, messagePage.Page+1),
+		"messageExportURLs":  messageExportURLs(messageFilter),
+		"messageStatus":      messageFilter.Status,
+		"messageStatuses":    model.MessageStatuses,
+		"messageCounts":      messageCounts,
+		"spam":               spam,
+		"siteConfig":         siteConfig,
+		"trash":              trash,
+		"trashDays":          h.cfg.TrashRetentionDays,
+		"translations":       translations,
+		"translationLocale":  translationLocale,
+		"translationLocales": i18n.Locales[1:],
+		"translationMissing": translationMissing,
+		"webhooks":           webhooks,
+		"webhookDeliveries":  deliveries,
+		"webhookEvents":      model.WebhookEvents,
+		"webhookFormats":     webhook.Formats,
+		"privacyRequests":    privacyRequests,
+		"messageRetention":   h.cfg.MessageRetentionDays,
+		"consentVersion":     h.cfg.ConsentVersion,
+		"cspReports":         cspReports,
+		"cspReportOnly":      h.cfg.CSPReportOnly,
+		"cspNonce":           middleware.CSPNonce(c),
+		"previewURL":         "/preview?token=" + middleware.SignPreviewToken(h.cfg.SessionSecret, time.Now().Add(middleware.PreviewTokenDuration)),
+		"username":           c.GetString("admin_username"),
+		"error":              flashMessage(c, "error"),
+		"success":            flashMessage(c, "success"),
 	})
 }
 
@@ -94,50 +176,55 @@ func (h *AdminHandler) Dashboard(c *gin.Context) {
 
 // CreateExperience menambahkan pengalaman kerja baru via POST
 func (h *AdminHandler) CreateExperience(c *gin.Context) {
-	sortOrder, _ := strconv.Atoi(c.PostForm("sort_order"))
 	exp := &model.Experience{
 		Company:     c.PostForm("company"),
 		Role:        c.PostForm("role"),
-		Period:      c.PostForm("period"),
+		StartDate:   monthFromForm(c.PostForm("start_month")),
+		EndDate:     monthFromForm(c.PostForm("end_month")),
+		IsCurrent:   c.PostForm("is_current") != "",
 		Description: c.PostForm("description"),
-		SortOrder:   sortOrder,
 	}
+	exp.Published, exp.PublishAt = publicationFromForm(c.PostForm("publish_state"), c.PostForm("publish_at"))
 
-	if err := h.svc.CreateExperience(exp); err != nil {
-		c.Redirect(http.StatusFound, "/admin?error=Gagal+menambah+experience")
+	if err := h.svc.CreateExperience(c.Request.Context(), exp); err != nil {
+		c.Error(err)
+		c.Redirect(http.StatusFound, "/admin?error=experience_create_failed")
 		return
 	}
-	c.Redirect(http.StatusFound, "/admin?success=Experience+berhasil+ditambahkan")
+	c.Redirect(http.StatusFound, "/admin?success=experience_created")
 }
 
 // UpdateExperience memperbarui pengalaman kerja via POST
 func (h *AdminHandler) UpdateExperience(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
-	sortOrder, _ := strconv.Atoi(c.PostForm("sort_order"))
 	exp := &model.Experience{
 		ID:          id,
 		Company:     c.PostForm("company"),
 		Role:        c.PostForm("role"),
-		Period:      c.PostForm("period"),
+		StartDate:   monthFromForm(c.PostForm("start_month")),
+		EndDate:     monthFromForm(c.PostForm("end_month")),
+		IsCurrent:   c.PostForm("is_current") != "",
 		Description: c.PostForm("description"),
-		SortOrder:   sortOrder,
 	}
+	exp.Published, exp.PublishAt = publicationFromForm(c.PostForm("publish_state"), c.PostForm("publish_at"))
 
-	if err := h.svc.UpdateExperience(exp); err != nil {
-		c.Redirect(http.StatusFound, "/admin?error=Gagal+update+experience")
+	if err := h.svc.UpdateExperience(c.Request.Context(), exp); err != nil {
+		c.Error(err)
+		c.Redirect(http.StatusFound, "/admin?error=experience_update_failed")
 		return
 	}
-	c.Redirect(http.StatusFound, "/admin?success=Experience+berhasil+diupdate")
+	c.Redirect(http.StatusFound, "/admin?success=experience_updated")
 }
 
-// DeleteExperience menghapus pengalaman kerja via POST
+// DeleteExperience memindahkan pengalaman kerja ke sampah via POST
 func (h *AdminHandler) DeleteExperience(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
-	if err := h.svc.DeleteExperience(id); err != nil {
-		c.Redirect(http.StatusFound, "/admin?error=Gagal+hapus+experience")
+	if err := h.svc.DeleteExperience(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		c.Redirect(http.StatusFound, "/admin?error=experience_delete_failed")
 		return
 	}
-	c.Redirect(http.StatusFound, "/admin?success=Experience+berhasil+dihapus")
+	c.Redirect(http.StatusFound, "/admin?success=experience_trashed")
 }
 
 // ============================================
@@ -146,54 +233,63 @@ func (h *AdminHandler) DeleteExperience(c *gin.Context) {
 
 // CreateProject menambahkan proyek baru via POST
 func (h *AdminHandler) CreateProject(c *gin.Context) {
-	sortOrder, _ := strconv.Atoi(c.PostForm("sort_order"))
 	proj := &model.Project{
 		Title:       c.PostForm("title"),
 		Description: c.PostForm("description"),
-		TechUsed:    c.PostForm("tech_used"),
+		Tags:        tagsFromForm(c.PostForm("tags")),
+		Role:        c.PostForm("role"),
+		Status:      c.PostForm("status"),
+		StartDate:   dateFromForm(c.PostForm("start_date")),
+		EndDate:     dateFromForm(c.PostForm("end_date")),
 		Link:        c.PostForm("link"),
 		GithubURL:   c.PostForm("github_url"),
 		ImageURL:    c.PostForm("image_url"),
-		SortOrder:   sortOrder,
 	}
+	proj.Published, proj.PublishAt = publicationFromForm(c.PostForm("publish_state"), c.PostForm("publish_at"))
 
-	if err := h.svc.CreateProject(proj); err != nil {
-		c.Redirect(http.StatusFound, "/admin?error=Gagal+menambah+project")
+	if err := h.svc.CreateProject(c.Request.Context(), proj); err != nil {
+		c.Error(err)
+		c.Redirect(http.StatusFound, "/admin?error=project_create_failed")
 		return
 	}
-	c.Redirect(http.StatusFound, "/admin?success=Project+berhasil+ditambahkan")
+	c.Redirect(http.StatusFound, "/admin?success=project_created")
 }
 
 // UpdateProject memperbarui proyek via POST
 func (h *AdminHandler) UpdateProject(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
-	sortOrder, _ := strconv.Atoi(c.PostForm("sort_order"))
 	proj := &model.Project{
 		ID:          id,
 		Title:       c.PostForm("title"),
 		Description: c.PostForm("description"),
-		TechUsed:    c.PostForm("tech_used"),
+		Tags:        tagsFromForm(c.PostForm("tags")),
+		Role:        c.PostForm("role"),
+		Status:      c.PostForm("status"),
+		StartDate:   dateFromForm(c.PostForm("start_date")),
+		EndDate:     dateFromForm(c.PostForm("end_date")),
 		Link:        c.PostForm("link"),
 		GithubURL:   c.PostForm("github_url"),
 		ImageURL:    c.PostForm("image_url"),
-		SortOrder:   sortOrder,
 	}
+	proj.Published, proj.PublishAt = publicationFromForm(c.PostForm("publish_state"), c.PostForm("publish_at"))
 
-	if err := h.svc.UpdateProject(proj); err != nil {
-		c.Redirect(http.StatusFound, "/admin?error=Gagal+update+project")
+	if err := h.svc.UpdateProject(c.Request.Context(), proj); err != nil {
+		c.Error(err)
+		c.Redirect(http.StatusFound, "/admin?error=project_update_failed")
 		return
 	}
-	c.Redirect(http.StatusFound, "/admin?success=Project+berhasil+diupdate")
+	c.Redirect(http.StatusFound, "/admin?success=project_updated")
 }
 
-// DeleteProject menghapus proyek via POST
+// DeleteProject memindahkan proyek ke sampah via POST
 func (h *AdminHandler) DeleteProject(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
-	if err := h.svc.DeleteProject(id); err != nil {
-		c.Redirect(http.StatusFound, "/admin?error=Gagal+hapus+project")
+	if err := h.svc.DeleteProject(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		c.Redirect(http.StatusFound, "/admin?error=project_delete_failed")
 		return
 	}
-	c.Redirect(http.StatusFound, "/admin?success=Project+berhasil+dihapus")
+	c.Redirect(http.StatusFound, "/admin?success=project_trashed")
 }
 
 // ============================================
@@ -202,48 +298,174 @@ func (h *AdminHandler) DeleteProject(c *gin.Context) {
 
 // CreateTechStack menambahkan tech stack baru via POST
 func (h *AdminHandler) CreateTechStack(c *gin.Context) {
-	sortOrder, _ := strconv.Atoi(c.PostForm("sort_order"))
 	ts := &model.TechStack{
 		Category:    c.PostForm("category"),
 		Name:        c.PostForm("name"),
 		Description: c.PostForm("description"),
-		SortOrder:   sortOrder,
+		Tags:        tagsFromForm(c.PostForm("tags")),
 	}
+	ts.Published, ts.PublishAt = publicationFromForm(c.PostForm("publish_state"), c.PostForm("publish_at"))
 
-	if err := h.svc.CreateTechStack(ts); err != nil {
-		c.Redirect(http.StatusFound, "/admin?error=Gagal+menambah+tech+stack")
+	if err := h.svc.CreateTechStack(c.Request.Context(), ts); err != nil {
+		c.Error(err)
+		c.Redirect(http.StatusFound, "/admin?error=techstack_create_failed")
 		return
 	}
-	c.Redirect(http.StatusFound, "/admin?success=Tech+stack+berhasil+ditambahkan")
+	c.Redirect(http.StatusFound, "/admin?success=techstack_created")
 }
 
 // UpdateTechStack memperbarui tech stack via POST
 func (h *AdminHandler) UpdateTechStack(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
-	sortOrder, _ := strconv.Atoi(c.PostForm("sort_order"))
 	ts := &model.TechStack{
 		ID:          id,
 		Category:    c.PostForm("category"),
 		Name:        c.PostForm("name"),
 		Description: c.PostForm("description"),
-		SortOrder:   sortOrder,
+		Tags:        tagsFromForm(c.PostForm("tags")),
 	}
+	ts.Published, ts.PublishAt = publicationFromForm(c.PostForm("publish_state"), c.PostForm("publish_at"))
 
-	if err := h.svc.UpdateTechStack(ts); err != nil {
-		c.Redirect(http.StatusFound, "/admin?error=Gagal+update+tech+stack")
+	if err := h.svc.UpdateTechStack(c.Request.Context(), ts); err != nil {
+		c.Error(err)
+		c.Redirect(http.StatusFound, "/admin?error=techstack_update_failed")
 		return
 	}
-	c.Redirect(http.StatusFound, "/admin?success=Tech+stack+berhasil+diupdate")
+	c.Redirect(http.StatusFound, "/admin?success=techstack_updated")
 }
 
-// DeleteTechStack menghapus tech stack via POST
+// DeleteTechStack memindahkan tech stack ke sampah via POST
 func (h *AdminHandler) DeleteTechStack(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
-	if err := h.svc.DeleteTechStack(id); err != nil {
-		c.Redirect(http.StatusFound, "/admin?error=Gagal+hapus+tech+stack")
+	if err := h.svc.DeleteTechStack(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		c.Redirect(http.StatusFound, "/admin?error=techstack_delete_failed")
 		return
 	}
-	c.Redirect(http.StatusFound, "/admin?success=Tech+stack+berhasil+dihapus")
+	c.Redirect(http.StatusFound, "/admin?success=techstack_trashed")
+}
+
+// ============================================
+// REORDER — Urutan Tampil (Drag & Drop)
+// ============================================
+
+// Reorder membuat handler yang menyimpan urutan baru untuk entity
+// (experience/project/techstack) via POST JSON.
+// Body: {"ids": [3, 1, 2]} — ID dalam urutan tampil yang diinginkan
+func (h *AdminHandler) Reorder(entity string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var form model.ReorderForm
+		if err := c.ShouldBind(&form); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"message": i18n.T(requestLocale(c), "flash.reorder_invalid"),
+			})
+			return
+		}
+
+		if err := h.svc.Reorder(c.Request.Context(), entity, form.IDs); err != nil {
+			c.Error(err)
+			status := http.StatusInternalServerError
+			if errors.Is(err, service.ErrUnknownEntity) {
+				status = http.StatusNotFound
+			}
+			c.JSON(status, gin.H{
+				"success": false,
+				"message": i18n.T(requestLocale(c), "flash.reorder_failed"),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"success": true,
+			"message": i18n.T(requestLocale(c), "flash.reorder_saved"),
+		})
+	}
+}
+
+// ============================================
+// TRASH — Sampah (Restore & Purge)
+// ============================================
+
+// RestoreTrash memulihkan konten dari sampah via POST
+func (h *AdminHandler) RestoreTrash(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	if err := h.svc.RestoreFromTrash(c.Request.Context(), c.Param("entity"), id); err != nil {
+		c.Error(err)
+		c.Redirect(http.StatusFound, "/admin?error=trash_restore_failed#trash")
+		return
+	}
+	c.Redirect(http.StatusFound, "/admin?success=trash_restored#trash")
+}
+
+// PurgeTrash menghapus permanen konten dari sampah via POST
+func (h *AdminHandler) PurgeTrash(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	if err := h.svc.PurgeFromTrash(c.Request.Context(), c.Param("entity"), id); err != nil {
+		c.Error(err)
+		c.Redirect(http.StatusFound, "/admin?error=trash_purge_failed#trash")
+		return
+	}
+	c.Redirect(http.StatusFound, "/admin?success=trash_purged#trash")
+}
+
+// ============================================
+// REVISIONS — Riwayat Perubahan & Rollback
+// ============================================
+
+// ShowHistory menampilkan riwayat revisi satu konten beserta diff per field
+// entity: experience/project/techstack/config, key: ID konten atau key konfigurasi
+func (h *AdminHandler) ShowHistory(c *gin.Context) {
+	history, err := h.svc.GetRevisionHistory(c.Request.Context(), c.Param("entity"), c.Param("key"))
+	if err != nil {
+		c.Error(err)
+		c.Redirect(http.StatusFound, "/admin?error=history_not_found")
+		return
+	}
+
+	c.HTML(http.StatusOK, "history.html", gin.H{
+		"history":  history,
+		"username": c.GetString("admin_username"),
+		"error":    flashMessage(c, "error"),
+		"success":  flashMessage(c, "success"),
+	})
+}
+
+// RestoreRevision mengembalikan konten ke isi revisi tertentu via POST
+func (h *AdminHandler) RestoreRevision(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	rev, err := h.svc.RestoreRevision(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		c.Redirect(http.StatusFound, "/admin?error=revision_restore_failed")
+		return
+	}
+	c.Redirect(http.StatusFound, "/admin/history/"+rev.Entity+"/"+url.PathEscape(rev.EntityKey)+"?success=revision_restored")
+}
+
+// ============================================
+// TRANSLATIONS — Terjemahan Konten
+// ============================================
+
+// SaveTranslation menyimpan terjemahan satu konten dari editor terjemahan via POST
+// Field yang dikosongkan kembali memakai teks bahasa Indonesia
+func (h *AdminHandler) SaveTranslation(c *gin.Context) {
+	entity, key := c.Param("entity"), c.Param("key")
+	locale := c.PostForm("locale")
+	back := "/admin?translation_locale=" + translationLocaleFromQuery(locale) + "&"
+
+	values := make(map[string]string)
+	for _, field := range model.TranslatableFields[entity] {
+		if value, ok := c.GetPostForm(field); ok {
+			values[field] = value
+		}
+	}
+	if err := h.svc.SaveTranslations(c.Request.Context(), entity, key, locale, values); err != nil {
+		c.Error(err)
+		c.Redirect(http.StatusFound, back+"error=translation_save_failed#translations")
+		return
+	}
+	c.Redirect(http.StatusFound, back+"success=translation_saved#translations")
 }
 
 // ============================================
@@ -257,13 +479,14 @@ func (h *AdminHandler) UpdateSiteConfig(c *gin.Context) {
 	for _, key := range keys {
 		value := c.PostForm(key)
 		if value != "" {
-			if err := h.svc.UpdateConfig(key, value); err != nil {
-				c.Redirect(http.StatusFound, "/admin?error=Gagal+update+konfigurasi")
+			if err := h.svc.UpdateConfig(c.Request.Context(), key, value); err != nil {
+				c.Error(err)
+				c.Redirect(http.StatusFound, "/admin?error=config_update_failed")
 				return
 			}
 		}
 	}
-	c.Redirect(http.StatusFound, "/admin?success=Konfigurasi+berhasil+diupdate")
+	c.Redirect(http.StatusFound, "/admin?success=config_updated")
 }
 
 // ============================================
@@ -273,13 +496,396 @@ func (h *AdminHandler) UpdateSiteConfig(c *gin.Context) {
 // MarkMessageRead menandai pesan sebagai sudah dibaca
 func (h *AdminHandler) MarkMessageRead(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
-	h.svc.MarkMessageAsRead(id)
-	c.Redirect(http.StatusFound, "/admin?success=Pesan+ditandai+dibaca")
+	if err := h.svc.MarkMessageAsRead(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		c.Redirect(http.StatusFound, "/admin?error=message_update_failed#messages")
+		return
+	}
+	c.Redirect(http.StatusFound, "/admin?success=message_read")
 }
 
-// DeleteMessage menghapus pesan kontak
+// DeleteMessage memindahkan pesan kontak ke sampah
 func (h *AdminHandler) DeleteMessage(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
-	h.svc.DeleteContactMessage(id)
-	c.Redirect(http.StatusFound, "/admin?success=Pesan+berhasil+dihapus")
+	if err := h.svc.DeleteContactMessage(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		c.Redirect(http.StatusFound, "/admin?error=message_update_failed#messages")
+		return
+	}
+	c.Redirect(http.StatusFound, "/admin?success=message_trashed")
+}
+
+// MarkMessageSpam mengembalikan handler yang memindahkan pesan ke folder spam
+// (spam = true) atau kembali ke kotak masuk (spam = false)
+func (h *AdminHandler) MarkMessageSpam(spam bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, _ := strconv.Atoi(c.Param("id"))
+		if err := h.svc.SetMessageSpam(c.Request.Context(), id, spam); err != nil {
+			c.Error(err)
+			c.Redirect(http.StatusFound, "/admin?error=message_update_failed#messages")
+			return
+		}
+		if spam {
+			c.Redirect(http.StatusFound, "/admin?success=message_spam#messages")
+			return
+		}
+		c.Redirect(http.StatusFound, "/admin?success=message_not_spam#messages")
+	}
+}
+
+// ArchiveMessage mengembalikan handler yang mengarsipkan pesan (archived = true)
+// atau mengembalikannya ke kotak masuk (archived = false)
+func (h *AdminHandler) ArchiveMessage(archived bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, _ := strconv.Atoi(c.Param("id"))
+		if err := h.svc.ArchiveMessage(c.Request.Context(), id, archived); err != nil {
+			c.Error(err)
+			c.Redirect(http.StatusFound, "/admin?error=message_update_failed#messages")
+			return
+		}
+		if archived {
+			c.Redirect(http.StatusFound, "/admin?success=message_archived#messages")
+			return
+		}
+		c.Redirect(http.StatusFound, "/admin?success=message_unarchived#messages")
+	}
+}
+
+// ReplyMessage mengirim balasan email ke pengirim pesan via POST
+func (h *AdminHandler) ReplyMessage(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	err := h.svc.ReplyToMessage(c.Request.Context(), id, c.PostForm("body"))
+	switch {
+	case errors.Is(err, service.ErrMailerDisabled):
+		c.Redirect(http.StatusFound, "/admin?error=message_reply_disabled#messages")
+	case err != nil:
+		c.Error(err)
+		c.Redirect(http.StatusFound, "/admin?error=message_reply_failed#messages")
+	default:
+		c.Redirect(http.StatusFound, "/admin?success=message_replied#messages")
+	}
+}
+
+// BulkMessages menjalankan aksi massal untuk pesan yang dicentang via POST
+// (action: read/archive/delete/export_csv/export_mbox). Ekspor langsung mengunduh
+// file; aksi lain kembali ke dashboard dengan filter dan halaman yang sama
+func (h *AdminHandler) BulkMessages(c *gin.Context) {
+	ids := idsFromForm(c.PostFormArray("ids"))
+
+	// Filter tampilan saat ini ikut dikirim sebagai field tersembunyi
+	back := messageFilterValues(messageFilterFromQuery(c.Request.PostForm))
+	if page := c.PostForm("page"); page != "" {
+		back.Set("page", page)
+	}
+
+	action := c.PostForm("action")
+	if format, ok := strings.CutPrefix(action, "export_"); ok {
+		if len(ids) == 0 {
+			back.Set("error", "messages_bulk_empty")
+			c.Redirect(http.StatusFound, "/admin?"+back.Encode()+"#messages")
+			return
+		}
+		// Pesan yang dicentang diekspor apa pun statusnya
+		h.streamExport(c, format, model.MessageFilter{IDs: ids, Status: model.MessageAll})
+		return
+	}
+
+	if err := h.svc.BulkMessages(c.Request.Context(), action, ids); err != nil {
+		c.Error(err)
+		if len(ids) == 0 {
+			back.Set("error", "messages_bulk_empty")
+		} else {
+			back.Set("error", "messages_bulk_failed")
+		}
+		c.Redirect(http.StatusFound, "/admin?"+back.Encode()+"#messages")
+		return
+	}
+	back.Set("success", "messages_bulk_"+action)
+	c.Redirect(http.StatusFound, "/admin?"+back.Encode()+"#messages")
+}
+
+// ExportMessages mengunduh pesan kotak masuk sebagai CSV atau mbox via GET
+// Filter sama dengan dashboard (q, message_status, from, to); ids membatasi ke
+// pesan tertentu. Contoh: /admin/messages/export?format=mbox&message_status=all
+func (h *AdminHandler) ExportMessages(c *gin.Context) {
+	values := c.Request.URL.Query()
+	filter := messageFilterFromQuery(values)
+	filter.IDs = idsFromForm(values["ids"])
+	h.streamExport(c, values.Get("format"), filter)
+}
+
+// streamExport menulis hasil ekspor langsung ke response sebagai file unduhan
+// Error sebelum ada data terkirim diarahkan kembali ke dashboard; error di tengah
+// stream hanya bisa dicatat karena status 200 sudah terkirim
+func (h *AdminHandler) streamExport(c *gin.Context, format string, filter model.MessageFilter) {
+	if !export.SupportedFormat(format) {
+		c.Redirect(http.StatusFound, "/admin?error=messages_export_failed#messages")
+		return
+	}
+
+	// Ekspor besar bisa melebihi HTTP_WRITE_TIMEOUT; batas waktu tulis dilepas khusus untuk unduhan ini
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	c.Header("Content-Type", export.ContentType(format))
+	c.Header("Content-Disposition", `attachment; filename="pesan-`+time.Now().Format("20060102-150405")+"."+format+`"`)
+	n, err := h.svc.ExportMessages(c.Request.Context(), c.Writer, format, filter)
+	if err == nil {
+		return
+	}
+	if !c.Writer.Written() {
+		c.Error(err)
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		c.Redirect(http.StatusFound, "/admin?error=messages_export_failed#messages")
+		return
+	}
+	c.Error(fmt.Errorf("ekspor pesan terputus setelah %d pesan: %w", n, err))
+}
+
+// ============================================
+// WEBHOOKS — Webhook Keluar & Log Delivery
+// ============================================
+
+// CreateWebhook menambahkan webhook baru via POST
+func (h *AdminHandler) CreateWebhook(c *gin.Context) {
+	if err := h.svc.CreateWebhook(c.Request.Context(), webhookFromForm(c)); err != nil {
+		c.Error(err)
+		c.Redirect(http.StatusFound, "/admin?error=webhook_save_failed#webhooks")
+		return
+	}
+	c.Redirect(http.StatusFound, "/admin?success=webhook_created#webhooks")
+}
+
+// UpdateWebhook memperbarui webhook via POST (secret kosong = tidak diubah)
+func (h *AdminHandler) UpdateWebhook(c *gin.Context) {
+	w := webhookFromForm(c)
+	w.ID, _ = strconv.Atoi(c.Param("id"))
+	if err := h.svc.UpdateWebhook(c.Request.Context(), w); err != nil {
+		c.Error(err)
+		c.Redirect(http.StatusFound, "/admin?error=webhook_save_failed#webhooks")
+		return
+	}
+	c.Redirect(http.StatusFound, "/admin?success=webhook_updated#webhooks")
+}
+
+// DeleteWebhook menghapus webhook beserta log delivery-nya via POST
+func (h *AdminHandler) DeleteWebhook(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	if err := h.svc.DeleteWebhook(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		c.Redirect(http.StatusFound, "/admin?error=webhook_delete_failed#webhooks")
+		return
+	}
+	c.Redirect(http.StatusFound, "/admin?success=webhook_deleted#webhooks")
+}
+
+// RedeliverWebhook mengirim ulang payload dari log delivery via POST
+func (h *AdminHandler) RedeliverWebhook(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	if err := h.svc.RedeliverWebhook(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		c.Redirect(http.StatusFound, "/admin?error=webhook_redeliver_failed#webhooks")
+		return
+	}
+	c.Redirect(http.StatusFound, "/admin?success=webhook_redelivered#webhooks")
+}
+
+// ============================================
+// PRIVACY — Permintaan Privasi Pengunjung
+// ============================================
+
+// ErasePersonalData menghapus atau menganonimkan semua data milik satu alamat email via POST
+// (kind: erase/anonymize). Hasilnya tercatat di log privasi di dashboard
+func (h *AdminHandler) ErasePersonalData(c *gin.Context) {
+	kind := c.PostForm("kind")
+	req, err := h.svc.ErasePersonalData(c.Request.Context(), kind, c.PostForm("email"), c.PostForm("note"))
+	switch {
+	case errors.Is(err, service.ErrPrivacySecretRequired):
+		c.Error(err)
+		c.Redirect(http.StatusFound, "/admin?error=privacy_secret_required#privacy")
+	case err != nil:
+		c.Error(err)
+		c.Redirect(http.StatusFound, "/admin?error=privacy_failed#privacy")
+	case !req.Affected():
+		c.Redirect(http.StatusFound, "/admin?success=privacy_nothing#privacy")
+	case kind == model.PrivacyAnonymize:
+		c.Redirect(http.StatusFound, "/admin?success=privacy_anonymized#privacy")
+	default:
+		c.Redirect(http.StatusFound, "/admin?success=privacy_erased#privacy")
+	}
+}
+
+// ============================================
+// SECURITY — Laporan Pelanggaran CSP
+// ============================================
+
+// ClearCSPReports menghapus semua laporan pelanggaran CSP yang sudah ditinjau
+func (h *AdminHandler) ClearCSPReports(c *gin.Context) {
+	if err := h.svc.ClearCSPReports(c.Request.Context()); err != nil {
+		c.Error(err)
+		c.Redirect(http.StatusFound, "/admin?error=csp_reports_clear_failed#security")
+		return
+	}
+	c.Redirect(http.StatusFound, "/admin?success=csp_reports_cleared#security")
+}
+
+// ============================================
+// HELPER FUNCTIONS
+// ============================================
+
+// logError mencatat error yang tidak menghentikan request ke c.Errors;
+// middleware.AccessLog menuliskannya bersama request ID (nil diabaikan)
+func logError(c *gin.Context, err error) {
+	if err != nil {
+		c.Error(err)
+	}
+}
+
+// translationLocaleFromQuery memvalidasi locale editor terjemahan
+// Locale tidak dikenal atau bahasa Indonesia diganti locale terjemahan pertama
+func translationLocaleFromQuery(locale string) string {
+	if !i18n.Supported(locale) || locale == i18n.Default {
+		return i18n.Locales[1]
+	}
+	return locale
+}
+
+// flashMessage menerjemahkan key notifikasi di query string (misal: ?success=project_created)
+// sesuai bahasa admin. Teks yang bukan key katalog ditampilkan apa adanya
+func flashMessage(c *gin.Context, param string) string {
+	key := c.Query(param)
+	if key == "" {
+		return ""
+	}
+	if msg, ok := i18n.Lookup(requestLocale(c), "flash."+key); ok {
+		return msg
+	}
+	return key
+}
+
+// messageFilterFromQuery membaca filter kotak masuk dari query string dashboard
+// (q, message_status, from, to, page). Nilai yang tidak valid diabaikan
+func messageFilterFromQuery(values url.Values) model.MessageFilter {
+	page, _ := strconv.Atoi(values.Get("page"))
+	return model.MessageFilter{
+		Query:  strings.TrimSpace(values.Get("q")),
+		Status: values.Get("message_status"),
+		From:   dateFromForm(values.Get("from")),
+		To:     dateFromForm(values.Get("to")),
+		Page:   page,
+	}
+}
+
+// messageFilterValues mengubah filter kotak masuk kembali menjadi query string (tanpa halaman)
+func messageFilterValues(f model.MessageFilter) url.Values {
+	values := url.Values{}
+	if f.Query != "" {
+		values.Set("q", f.Query)
+	}
+	if f.Status != "" {
+		values.Set("message_status", f.Status)
+	}
+	if f.From != nil {
+		values.Set("from", f.From.Format("2006-01-02"))
+	}
+	if f.To != nil {
+		values.Set("to", f.To.Format("2006-01-02"))
+	}
+	return values
+}
+
+// messagePageURL membuat link halaman kotak masuk dengan filter yang sama
+// Mengembalikan string kosong jika halaman di luar jangkauan (< 1)
+func messagePageURL(f model.MessageFilter, page int) string {
+	if page < 1 {
+		return ""
+	}
+	values := messageFilterValues(f)
+	values.Set("page", strconv.Itoa(page))
+	return "/admin?" + values.Encode() + "#messages"
+}
+
+// messageExportURLs membuat link ekspor (per format) untuk semua pesan yang cocok dengan filter
+func messageExportURLs(f model.MessageFilter) map[string]string {
+	urls := make(map[string]string, len(export.Formats))
+	for _, format := range export.Formats {
+		values := messageFilterValues(f)
+		values.Set("format", format)
+		urls[format] = "/admin/messages/export?" + values.Encode()
+	}
+	return urls
+}
+
+// idsFromForm mengubah daftar ID dari form/query (misal: checkbox "ids") menjadi angka
+// Nilai yang bukan angka diabaikan
+func idsFromForm(values []string) []int {
+	var ids []int
+	for _, value := range values {
+		if id, err := strconv.Atoi(value); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// webhookFromForm membaca pengaturan webhook dari form dashboard
+// Event dipilih lewat checkbox bernama "events" (bisa lebih dari satu)
+func webhookFromForm(c *gin.Context) *model.Webhook {
+	return &model.Webhook{
+		Name:   c.PostForm("name"),
+		URL:    c.PostForm("url"),
+		Secret: c.PostForm("secret"),
+		Events: c.PostFormArray("events"),
+		Format: c.PostForm("format"),
+		ChatID: c.PostForm("chat_id"),
+		Active: c.PostForm("active") != "",
+	}
+}
+
+// tagsFromForm memecah input tag comma-separated dari form menjadi daftar tag
+// Pembersihan dan deduplikasi dilakukan di service layer
+func tagsFromForm(value string) []model.Tag {
+	var tags []model.Tag
+	for _, name := range strings.Split(value, ",") {
+		tags = append(tags, model.Tag{Name: name})
+	}
+	return tags
+}
+
+// monthFromForm mem-parsing input <input type="month"> (format YYYY-MM)
+// menjadi tanggal 1 di bulan tersebut. Mengembalikan nil jika kosong atau tidak valid.
+func monthFromForm(value string) *time.Time {
+	t, err := time.Parse("2006-01", strings.TrimSpace(value))
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// publicationFromForm mengubah pilihan status tayang di form menjadi kolom published & publish_at
+// state: published (default), draft, atau scheduled dengan waktu dari <input type="datetime-local">.
+// Jadwal tanpa waktu yang valid disimpan sebagai draft.
+func publicationFromForm(state, at string) (bool, *time.Time) {
+	switch state {
+	case model.PublishStateDraft:
+		return false, nil
+	case model.PublishStateScheduled:
+		t, err := time.ParseInLocation("2006-01-02T15:04", strings.TrimSpace(at), time.Local)
+		if err != nil {
+			return false, nil
+		}
+		return false, &t
+	default:
+		return true, nil
+	}
+}
+
+// dateFromForm mem-parsing input <input type="date"> (format YYYY-MM-DD)
+// Mengembalikan nil jika kosong atau formatnya tidak valid
+func dateFromForm(value string) *time.Time {
+	t, err := time.Parse("2006-01-02", strings.TrimSpace(value))
+	if err != nil {
+		return nil
+	}
+	return &t
 }
@@ -1,50 +1,145 @@
 package handler
 
 import (
+	"errors"
+	"log/slog"
 	"net/http"
+	"portofolio-go/internal/captcha"
+	"portofolio-go/internal/config"
+	"portofolio-go/internal/i18n"
+	"portofolio-go/internal/middleware"
 	"portofolio-go/internal/model"
 	"portofolio-go/internal/service"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 // ContactHandler menangani request terkait form kontak
 type ContactHandler struct {
-	svc *service.Service
+	svc     *service.Service
+	cfg     *config.AppConfig
+	captcha captcha.Verifier // nil = CAPTCHA nonaktif
+	tokens  *middleware.FormTokens
 }
 
 // NewContactHandler membuat instance ContactHandler baru
-func NewContactHandler(svc *service.Service) *ContactHandler {
-	return &ContactHandler{svc: svc}
+// verifier boleh nil jika CAPTCHA tidak dipakai
+func NewContactHandler(svc *service.Service, cfg *config.AppConfig, verifier captcha.Verifier) *ContactHandler {
+	// Token form ditandatangani SESSION_SECRET yang sudah diperiksa PrepareSessionSecret saat startup
+	return &ContactHandler{svc: svc, cfg: cfg, captcha: verifier, tokens: middleware.NewFormTokens(cfg.SessionSecret)}
 }
 
 // SubmitContact menerima dan memproses pesan dari form kontak
-// Validasi dilakukan oleh binding Gin, lalu disimpan ke database
+// Validasi dilakukan oleh binding Gin, lalu disimpan ke database.
+// Rate limit per IP dipasang sebagai middleware di route
 func (h *ContactHandler) SubmitContact(c *gin.Context) {
 	var form model.ContactForm
+	locale := requestLocale(c)
 
 	// Validasi input — Gin akan mengecek required, email format, min/max length
 	if err := c.ShouldBind(&form); err != nil {
+		contactSubmissions.Inc("invalid")
 		c.JSON(http.StatusBadRequest, gin.H{
 			"success": false,
-			"message": "Data tidak valid. Pastikan semua field terisi dengan benar.",
+			"message": i18n.T(locale, "contact.invalid"),
 			"error":   err.Error(),
 		})
 		return
 	}
 
+	// Honeypot terisi — balas seolah berhasil agar bot tidak mencoba cara lain
+	if form.Website != "" {
+		contactSubmissions.Inc("honeypot")
+		slog.InfoContext(c.Request.Context(), "Pesan kontak dibuang (honeypot terisi)", "client_ip", c.ClientIP())
+		c.JSON(http.StatusOK, gin.H{
+			"success": true,
+			"message": i18n.T(locale, "contact.sent"),
+		})
+		return
+	}
+
+	// Tolak form yang dikirim terlalu cepat setelah dirender, tokennya tidak valid, atau sudah dipakai
+	minAge := time.Duration(h.cfg.ContactMinSeconds) * time.Second
+	if err := h.tokens.Verify(form.FormToken, time.Now(), minAge); err != nil {
+		h.rejectFormToken(c, locale, err)
+		return
+	}
+
+	// Verifikasi CAPTCHA (proof-of-work atau widget pihak ketiga)
+	if h.captcha != nil {
+		if err := h.captcha.Verify(c.Request.Context(), form.Captcha, c.ClientIP()); err != nil {
+			contactSubmissions.Inc("captcha_failed")
+			slog.WarnContext(c.Request.Context(), "CAPTCHA pesan kontak ditolak", "client_ip", c.ClientIP(), "error", err)
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"message": i18n.T(locale, "contact.captcha_failed"),
+			})
+			return
+		}
+	}
+
+	// Token hanya berlaku untuk satu pesan; ditandai terpakai setelah lolos CAPTCHA
+	// agar pengunjung yang salah isi CAPTCHA tidak perlu menunggu token baru
+	if err := h.tokens.Use(form.FormToken, time.Now()); err != nil {
+		h.rejectFormToken(c, locale, err)
+		return
+	}
+
+	// Balasan otomatis ke pengunjung memakai bahasa halaman tempat form dikirim
+	form.Locale = locale
+
 	// Simpan pesan melalui service layer
-	if err := h.svc.SubmitContactMessage(&form); err != nil {
+	if err := h.svc.SubmitContactMessage(c.Request.Context(), &form); err != nil {
+		contactSubmissions.Inc("error")
+		c.Error(err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
-			"message": "Gagal mengirim pesan. Silakan coba lagi.",
+			"message": i18n.T(locale, "contact.failed"),
 		})
 		return
 	}
 
-	// Berhasil — kirim response sukses
+	// Berhasil — kirim response sukses (pesan yang masuk folder spam juga dibalas sama)
+	contactSubmissions.Inc("accepted")
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"message": "Pesan berhasil dikirim! Terima kasih sudah menghubungi.",
+		"message": i18n.T(locale, "contact.sent"),
+	})
+}
+
+// rejectFormToken menjawab form kontak yang token-nya ditolak
+func (h *ContactHandler) rejectFormToken(c *gin.Context, locale string, err error) {
+	key, result := "contact.expired", "expired"
+	switch {
+	case errors.Is(err, middleware.ErrFormTooFast):
+		key, result = "contact.too_fast", "too_fast"
+	case errors.Is(err, middleware.ErrFormTokenUsed):
+		result = "replayed"
+	}
+	contactSubmissions.Inc(result)
+	c.JSON(http.StatusBadRequest, gin.H{
+		"success": false,
+		"message": i18n.T(locale, key),
 	})
 }
+
+// FormToken menerbitkan token form kontak bertanda waktu untuk contact.js.
+// Token diambil saat halaman dimuat, bukan dirender di HTML, agar halaman utama
+// bisa di-cache tanpa membuat semua pengunjung berbagi waktu render yang sama
+func (h *ContactHandler) FormToken(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, gin.H{"token": h.tokens.Sign(time.Now())})
+}
+
+// CaptchaChallenge menerbitkan tantangan proof-of-work baru untuk form kontak
+// Hanya tersedia jika provider CAPTCHA menerbitkan tantangannya sendiri
+func (h *ContactHandler) CaptchaChallenge(c *gin.Context) {
+	challenger, ok := h.captcha.(captcha.Challenger)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"success": false})
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, challenger.NewChallenge(time.Now()))
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"portofolio-go/internal/buildinfo"
+	"portofolio-go/internal/database"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readyTimeout adalah batas waktu semua pemeriksaan /readyz
+const readyTimeout = 3 * time.Second
+
+// HealthHandler menangani endpoint pemeriksaan kesehatan (untuk Docker HEALTHCHECK,
+// load balancer, atau orkestrator) dan informasi build
+type HealthHandler struct {
+	db     *sql.DB
+	dbPath string // Path file database; direktorinya harus bisa ditulisi
+}
+
+// NewHealthHandler membuat instance HealthHandler baru
+func NewHealthHandler(db *sql.DB, dbPath string) *HealthHandler {
+	return &HealthHandler{db: db, dbPath: dbPath}
+}
+
+// Healthz menjawab 200 selama proses hidup dan bisa melayani request.
+// Tidak menyentuh database agar gangguan sementara tidak membuat proses di-restart
+func (h *HealthHandler) Healthz(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Readyz memeriksa apakah server siap menerima trafik: database bisa di-ping, semua
+// migration sudah diterapkan, dan direktori database bisa ditulisi. Jawaban 503 jika
+// ada yang gagal; detail error hanya dicatat di log, respons cukup menyebut cek mana
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readyTimeout)
+	defer cancel()
+
+	checks := []struct {
+		name string
+		run  func() error
+	}{
+		{"database", func() error { return h.db.PingContext(ctx) }},
+		{"migrations", func() error {
+			pending, err := database.PendingMigrations(ctx, h.db)
+			if err == nil && len(pending) > 0 {
+				err = fmt.Errorf("migration belum diterapkan: %s", strings.Join(pending, ", "))
+			}
+			return err
+		}},
+		{"storage", func() error { return database.CheckWritable(h.dbPath) }},
+	}
+
+	status := http.StatusOK
+	results := gin.H{}
+	for _, check := range checks {
+		if err := check.run(); err != nil {
+			c.Error(fmt.Errorf("readyz %s: %w", check.name, err))
+			results[check.name] = "fail"
+			status = http.StatusServiceUnavailable
+			continue
+		}
+		results[check.name] = "ok"
+	}
+
+	overall := "ok"
+	if status != http.StatusOK {
+		overall = "fail"
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(status, gin.H{"status": overall, "checks": results})
+}
+
+// Version menampilkan commit VCS dan versi Go dari binary yang sedang berjalan
+func (h *HealthHandler) Version(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, buildinfo.Read())
+}
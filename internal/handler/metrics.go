@@ -0,0 +1,13 @@
+package handler
+
+import "portofolio-go/internal/metrics"
+
+// Metrik hasil form kontak dan login admin. Pesan yang tersimpan (termasuk yang
+// masuk folder spam) dihitung terpisah oleh service; penolakan rate limit dihitung
+// oleh middleware RateLimiter
+var (
+	contactSubmissions = metrics.NewCounterVec("contact_submissions_total",
+		"Jumlah pengiriman form kontak per hasil (accepted, invalid, honeypot, too_fast, expired, replayed, captcha_failed, error).", "result")
+	adminLogins = metrics.NewCounterVec("admin_logins_total",
+		"Jumlah percobaan login admin per hasil (success, invalid, failed).", "result")
+)
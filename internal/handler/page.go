@@ -1,46 +1,184 @@
 package handler
 
 import (
+	"bytes"
+	"fmt"
+	"html/template"
 	"net/http"
+	"portofolio-go/internal/captcha"
+	"portofolio-go/internal/config"
+	"portofolio-go/internal/i18n"
+	"portofolio-go/internal/middleware"
+	"portofolio-go/internal/model"
+	"portofolio-go/internal/pagecache"
 	"portofolio-go/internal/service"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// LocaleCookie menyimpan pilihan bahasa pengunjung (diset saat membuka /id/ atau /en/)
+const LocaleCookie = "lang"
+
+const (
+	pageCacheMaxAge  = time.Hour // Render ulang berkala untuk isi yang bergantung waktu (total masa kerja)
+	pageCacheEntries = 64        // Batas halaman tersimpan (kombinasi path dan locale)
+)
+
 // PageHandler menangani request untuk halaman-halaman utama portofolio
 type PageHandler struct {
-	svc *service.Service
+	svc     *service.Service
+	cfg     *config.AppConfig
+	captcha captcha.Verifier   // nil = form kontak tanpa CAPTCHA
+	tmpl    *template.Template // Template halaman untuk render ke cache
+	cache   *pagecache.Cache   // Halaman utama yang sudah dirender per path dan locale
 }
 
 // NewPageHandler membuat instance PageHandler baru
-func NewPageHandler(svc *service.Service) *PageHandler {
-	return &PageHandler{svc: svc}
+func NewPageHandler(svc *service.Service, cfg *config.AppConfig, verifier captcha.Verifier, tmpl *template.Template) *PageHandler {
+	return &PageHandler{
+		svc:     svc,
+		cfg:     cfg,
+		captcha: verifier,
+		tmpl:    tmpl,
+		cache:   pagecache.New(pageCacheMaxAge, pageCacheEntries),
+	}
 }
 
-// Index menampilkan halaman utama portofolio (flip-book)
-// Mengambil semua data dari database dan merender template index.html
+// Index mengarahkan pengunjung ke halaman portofolio sesuai bahasanya
+// Pilihan bahasa sebelumnya (cookie) diutamakan, lalu header Accept-Language
 func (h *PageHandler) Index(c *gin.Context) {
-	// Ambil semua data portofolio dari service
-	data, err := h.svc.GetPortfolioData()
+	// Hasil redirect bergantung pada header dan cookie, jangan di-cache bersama
+	c.Header("Vary", "Accept-Language, Cookie")
+	c.Redirect(http.StatusFound, "/"+requestLocale(c)+"/")
+}
+
+// Localized mengembalikan handler halaman utama portofolio (flip-book) untuk satu locale
+// Halaman dirender dari template index.html lalu disimpan di cache sampai konten
+// berubah; browser yang masih menyimpan versi sama (If-None-Match) cukup dijawab 304
+func (h *PageHandler) Localized(locale string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// URL absolut di halaman dibangun dari SITE_URL, bukan header Host, jadi
+		// pengunjung tidak bisa mengisi cache dengan link ke host lain
+		key := c.Request.URL.Path + "|" + locale
+		version, now := h.svc.ContentVersion(), time.Now()
+
+		page := h.cache.Get(key, version, now)
+		if page == nil {
+			// Ambil semua data portofolio dari service
+			data, err := h.svc.GetPortfolioData(c.Request.Context(), locale)
+			if err != nil {
+				c.Error(err)
+				c.String(http.StatusInternalServerError, i18n.T(locale, "page.load_failed"))
+				return
+			}
+			var buf bytes.Buffer
+			if err := h.tmpl.ExecuteTemplate(&buf, "index.html", h.pageData(data, locale, false)); err != nil {
+				c.Error(fmt.Errorf("gagal merender halaman %s: %w", locale, err))
+				c.String(http.StatusInternalServerError, i18n.T(locale, "page.load_failed"))
+				return
+			}
+			page = pagecache.NewPage(buf.Bytes(), version, now)
+			h.cache.Put(key, page)
+		}
+
+		// Ingat pilihan bahasa untuk kunjungan berikutnya (setahun)
+		c.SetSameSite(http.SameSiteLaxMode)
+		c.SetCookie(LocaleCookie, locale, 365*24*60*60, "/", "", false, false)
+
+		// Varian gzip sudah disiapkan di cache, jadi middleware Gzip tidak mengompres ulang
+		body, etag := page.Body, page.ETag
+		c.Header("Vary", "Accept-Encoding")
+		if page.Gzip != nil && middleware.AcceptsEncoding(c.Request, "gzip") {
+			body, etag = page.Gzip, page.GzipETag()
+			c.Header("Content-Encoding", "gzip")
+		}
+
+		// Boleh disimpan browser/CDN, tetapi wajib divalidasi ulang agar perubahan admin langsung terlihat
+		c.Header("Cache-Control", "public, no-cache")
+		c.Header("ETag", etag)
+		if pagecache.Matches(c.GetHeader("If-None-Match"), etag) {
+			c.Status(http.StatusNotModified)
+			return
+		}
+		c.Data(http.StatusOK, "text/html; charset=utf-8", body)
+	}
+}
+
+// Preview menampilkan halaman utama termasuk draft dan konten terjadwal
+// Hanya bisa diakses dengan token preview bertanda tangan dari dashboard
+func (h *PageHandler) Preview(c *gin.Context) {
+	if !middleware.VerifyPreviewToken(h.cfg.SessionSecret, c.Query("token"), time.Now()) {
+		c.String(http.StatusForbidden, "Link preview tidak valid atau sudah kedaluwarsa")
+		return
+	}
+
+	locale := c.Query("lang")
+	if !i18n.Supported(locale) {
+		locale = requestLocale(c)
+	}
+	data, err := h.svc.GetPreviewData(c.Request.Context(), locale)
 	if err != nil {
-		c.String(http.StatusInternalServerError, "Gagal memuat data portofolio")
+		c.Error(err)
+		c.String(http.StatusInternalServerError, i18n.T(locale, "page.load_failed"))
 		return
 	}
 
+	// Halaman preview tidak boleh di-cache atau diindeks mesin pencari
+	c.Header("Cache-Control", "no-store")
+	c.Header("X-Robots-Tag", "noindex, nofollow")
+	c.HTML(http.StatusOK, "index.html", h.pageData(data, locale, true))
+}
+
+// pageData menyusun data template index.html dari data portofolio.
+// Hasilnya harus sama untuk setiap pengunjung (halaman publik disimpan di cache),
+// jadi data per pengunjung seperti token form diambil contact.js lewat API
+func (h *PageHandler) pageData(data *model.PortfolioData, locale string, preview bool) gin.H {
 	// Kelompokkan tech stacks berdasarkan kategori untuk template
-	techByCategory := make(map[string][]map[string]string)
+	techByCategory := make(map[string][]model.TechStack)
 	for _, ts := range data.TechStacks {
-		techByCategory[ts.Category] = append(techByCategory[ts.Category], map[string]string{
-			"name":        ts.Name,
-			"description": ts.Description,
+		techByCategory[ts.Category] = append(techByCategory[ts.Category], ts)
+	}
+
+	// Link versi bahasa lain (hreflang) dan kanonik harus berupa URL absolut situs publik
+	baseURL := h.cfg.SiteURL
+	alternates := make([]gin.H, 0, len(i18n.Locales))
+	for _, l := range i18n.Locales {
+		alternates = append(alternates, gin.H{
+			"Locale": l,
+			"Name":   i18n.Name(l),
+			"URL":    baseURL + "/" + l + "/",
 		})
 	}
 
-	// Render halaman utama dengan semua data
-	c.HTML(http.StatusOK, "index.html", gin.H{
-		"config":         data.Config,
-		"experiences":    data.Experiences,
-		"projects":       data.Projects,
-		"techByCategory": techByCategory,
-	})
+	// Konfigurasi CAPTCHA untuk contact.js (nil jika nonaktif)
+	var widget *captcha.Widget
+	if h.captcha != nil {
+		w := h.captcha.Widget()
+		widget = &w
+	}
+
+	return gin.H{
+		"locale":           locale,
+		"preview":          preview,
+		"baseURL":          baseURL,
+		"alternates":       alternates,
+		"jsMessages":       i18n.Catalog(locale, "js."),
+		"captcha":          widget,
+		"consentVersion":   h.cfg.ConsentVersion,
+		"config":           data.Config,
+		"experiences":      data.Experiences,
+		"experienceMonths": data.ExperienceMonths,
+		"projects":         data.Projects,
+		"techByCategory":   techByCategory,
+	}
+}
+
+// requestLocale menentukan bahasa pengunjung: cookie pilihan bahasa,
+// lalu header Accept-Language, lalu bahasa Indonesia
+func requestLocale(c *gin.Context) string {
+	if locale, err := c.Cookie(LocaleCookie); err == nil && i18n.Supported(locale) {
+		return locale
+	}
+	return i18n.Negotiate(c.GetHeader("Accept-Language"))
 }
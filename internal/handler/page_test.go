@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"portofolio-go/internal/config"
+	"portofolio-go/internal/database"
+	"portofolio-go/internal/pagecache"
+	"portofolio-go/internal/repository"
+	"portofolio-go/internal/service"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newTestRouter menyiapkan database sementara, service, dan router Gin dengan
+// halaman /id/ dan /en/. setup bisa mengubah PageHandler sebelum route didaftarkan;
+// middleware dipasang sebelum route seperti di main
+func newTestRouter(tb testing.TB, setup func(h *PageHandler), middleware ...gin.HandlerFunc) *gin.Engine {
+	tb.Helper()
+	tb.Chdir("../..") // migrations/ dan web/templates/ dibaca relatif dari root repo
+	gin.SetMode(gin.TestMode)
+
+	db, err := database.InitDB(filepath.Join(tb.TempDir(), "test.db"))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() { db.Close() })
+
+	cfg := &config.AppConfig{SiteURL: "https://portofolio.example", ConsentVersion: "1"}
+	svc := service.NewService(repository.NewRepository(db), cfg, nil)
+	tmpl, err := LoadTemplates(func(name string) string { return "/static/" + name })
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	h := NewPageHandler(svc, cfg, nil, tmpl)
+	if setup != nil {
+		setup(h)
+	}
+	r := gin.New()
+	r.Use(middleware...)
+	r.GET("/id/", h.Localized("id"))
+	r.GET("/en/", h.Localized("en"))
+	return r
+}
+
+func TestLocalizedUsesSiteURL(t *testing.T) {
+	r := newTestRouter(t, nil)
+
+	// Header Host palsu tidak boleh masuk ke link halaman, termasuk lewat cache
+	for _, host := range []string{"evil.example", "portofolio.example"} {
+		req := httptest.NewRequest(http.MethodGet, "/en/", nil)
+		req.Host = host
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Host %s: status %d", host, w.Code)
+		}
+		body := w.Body.String()
+		if strings.Contains(body, "evil.example") {
+			t.Errorf("Host %s: halaman memuat host dari header Host", host)
+		}
+		for _, want := range []string{
+			`<link rel="canonical" href="https://portofolio.example/en/">`,
+			`hreflang="id" href="https://portofolio.example/id/"`,
+			`hreflang="x-default" href="https://portofolio.example/"`,
+		} {
+			if !strings.Contains(body, want) {
+				t.Errorf("Host %s: halaman tidak memuat %s", host, want)
+			}
+		}
+	}
+}
+
+// BenchmarkLocalized mengukur halaman utama dari cache dan saat harus dirender ulang
+func BenchmarkLocalized(b *testing.B) {
+	for _, bc := range []struct {
+		name  string
+		setup func(h *PageHandler)
+	}{
+		{"cached", nil},
+		// Umur maksimal negatif membuat setiap Get gagal, jadi setiap request dirender ulang
+		{"uncached", func(h *PageHandler) { h.cache = pagecache.New(-1, pageCacheEntries) }},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			r := newTestRouter(b, bc.setup)
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					w := httptest.NewRecorder()
+					r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/en/", nil))
+					if w.Code != http.StatusOK {
+						b.Errorf("status %d", w.Code)
+						return
+					}
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"portofolio-go/internal/model"
+	"portofolio-go/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// cspReportMaxBytes membatasi ukuran body laporan CSP (laporan asli hanya beberapa KB)
+const cspReportMaxBytes = 64 << 10
+
+// SecurityHandler menerima laporan pelanggaran Content Security Policy dari browser
+type SecurityHandler struct {
+	svc *service.Service
+}
+
+// NewSecurityHandler membuat instance SecurityHandler baru
+func NewSecurityHandler(svc *service.Service) *SecurityHandler {
+	return &SecurityHandler{svc: svc}
+}
+
+// legacyCSPReport adalah format laporan directive report-uri (Content-Type application/csp-report)
+type legacyCSPReport struct {
+	Report struct {
+		DocumentURI        string `json:"document-uri"`
+		ViolatedDirective  string `json:"violated-directive"`
+		EffectiveDirective string `json:"effective-directive"`
+		BlockedURI         string `json:"blocked-uri"`
+		SourceFile         string `json:"source-file"`
+		LineNumber         int    `json:"line-number"`
+		ScriptSample       string `json:"script-sample"`
+		Disposition        string `json:"disposition"`
+	} `json:"csp-report"`
+}
+
+// reportingAPIReport adalah satu laporan Reporting API dari directive report-to
+// (Content-Type application/reports+json, body berupa array laporan berbagai jenis)
+type reportingAPIReport struct {
+	Type string `json:"type"`
+	Body struct {
+		DocumentURL        string `json:"documentURL"`
+		EffectiveDirective string `json:"effectiveDirective"`
+		BlockedURL         string `json:"blockedURL"`
+		SourceFile         string `json:"sourceFile"`
+		LineNumber         int    `json:"lineNumber"`
+		Sample             string `json:"sample"`
+		Disposition        string `json:"disposition"`
+	} `json:"body"`
+}
+
+// CSPReport menerima laporan pelanggaran CSP (format report-uri maupun Reporting API)
+// dan menyimpannya untuk ditinjau di dashboard. Selalu dijawab 204 kecuali body
+// tidak bisa dibaca, karena browser tidak melakukan apa pun dengan responsnya
+func (h *SecurityHandler) CSPReport(c *gin.Context) {
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, cspReportMaxBytes))
+	if err != nil {
+		c.Status(http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	for _, report := range parseCSPReports(body) {
+		if err := h.svc.RecordCSPReport(c.Request.Context(), &report); err != nil && !errors.Is(err, service.ErrInvalidCSPReport) {
+			slog.ErrorContext(c.Request.Context(), "Gagal menyimpan laporan CSP", "error", err)
+		}
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// parseCSPReports membaca body laporan CSP: objek {"csp-report": ...} dari report-uri
+// atau array Reporting API (hanya laporan bertipe csp-violation yang diambil)
+func parseCSPReports(body []byte) []model.CSPReport {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return nil
+	}
+
+	if body[0] == '[' {
+		var batch []reportingAPIReport
+		if json.Unmarshal(body, &batch) != nil {
+			return nil
+		}
+		var reports []model.CSPReport
+		for _, r := range batch {
+			if r.Type != "csp-violation" {
+				continue
+			}
+			reports = append(reports, model.CSPReport{
+				DocumentURI: r.Body.DocumentURL,
+				Directive:   r.Body.EffectiveDirective,
+				BlockedURI:  r.Body.BlockedURL,
+				SourceFile:  r.Body.SourceFile,
+				LineNumber:  r.Body.LineNumber,
+				Sample:      r.Body.Sample,
+				Disposition: r.Body.Disposition,
+			})
+		}
+		return reports
+	}
+
+	var legacy legacyCSPReport
+	if json.Unmarshal(body, &legacy) != nil {
+		return nil
+	}
+	r := legacy.Report
+	directive := r.EffectiveDirective
+	if directive == "" {
+		directive = r.ViolatedDirective
+	}
+	return []model.CSPReport{{
+		DocumentURI: r.DocumentURI,
+		Directive:   directive,
+		BlockedURI:  r.BlockedURI,
+		SourceFile:  r.SourceFile,
+		LineNumber:  r.LineNumber,
+		Sample:      r.ScriptSample,
+		Disposition: r.Disposition,
+	}}
+}
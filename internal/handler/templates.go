@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"fmt"
+	"html/template"
+	"portofolio-go/internal/i18n"
+)
+
+// templateFiles adalah template HTML yang dimuat saat startup
+// (dimuat per file karena LoadHTMLGlob tidak mendukung nested directory dengan baik)
+var templateFiles = []string{
+	"web/templates/pages/index.html",
+	"web/templates/admin/login.html",
+	"web/templates/admin/dashboard.html",
+	"web/templates/admin/history.html",
+}
+
+// LoadTemplates memuat semua template HTML beserta custom template functions.
+// asset mengubah nama file statis menjadi URL ber-hash (lihat assets.Pipeline.URL)
+func LoadTemplates(asset func(name string) string) (*template.Template, error) {
+	funcMap := template.FuncMap{
+		// add menjumlahkan dua angka (untuk kalkulasi di template)
+		"add": func(a, b int) int {
+			return a + b
+		},
+		// period memformat rentang tanggal pengalaman/proyek sesuai locale
+		"period": i18n.Period,
+		// monthYear memformat tanggal menjadi "Bln YYYY" sesuai locale
+		"monthYear": i18n.MonthYear,
+		// tenure memformat durasi (dalam bulan) menjadi teks sesuai locale
+		"tenure": i18n.Tenure,
+		// t menerjemahkan teks UI dari katalog pesan sesuai locale
+		"t": i18n.T,
+		// tn seperti t, dengan bentuk tunggal/jamak berdasarkan angka
+		"tn": i18n.TN,
+		// localeName menampilkan nama bahasa (misal: "English")
+		"localeName": i18n.Name,
+		// asset mengembalikan URL ber-hash file statis (misal: "css/notebook.css")
+		"asset": asset,
+		// safe menandai string sebagai HTML yang aman (tidak di-escape)
+		// Hanya gunakan untuk konten yang sudah disanitasi
+		"safe": func(s string) template.HTML {
+			return template.HTML(s)
+		},
+	}
+
+	tmpl, err := template.New("").Funcs(funcMap).ParseFiles(templateFiles...)
+	if err != nil {
+		return nil, fmt.Errorf("gagal memuat template: %w", err)
+	}
+	return tmpl, nil
+}
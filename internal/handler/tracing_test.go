@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"portofolio-go/internal/tracing"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TestPageTrace memastikan satu request halaman menghasilkan satu trace berantai:
+// span request (otelgin) → service.GetPortfolioData → repository.*
+func TestPageTrace(t *testing.T) {
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	exporter := tracetest.NewInMemoryExporter()
+	provider := tracing.Install(exporter, "portofolio-go-test", "test")
+	t.Cleanup(func() {
+		provider.Shutdown(t.Context())
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+
+	r := newTestRouter(t, nil, otelgin.Middleware("portofolio-go-test"))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/en/", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d", w.Code)
+	}
+	if err := provider.ForceFlush(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := exporter.GetSpans()
+	byID := make(map[trace.SpanID]tracetest.SpanStub, len(spans))
+	var root, svc *tracetest.SpanStub
+	var repo []tracetest.SpanStub
+	for i, s := range spans {
+		byID[s.SpanContext.SpanID()] = s
+		switch {
+		case s.Name == "GET /en/":
+			root = &spans[i]
+		case s.Name == "service.GetPortfolioData":
+			svc = &spans[i]
+		case strings.HasPrefix(s.Name, "repository."):
+			repo = append(repo, s)
+		}
+	}
+	if root == nil || svc == nil || len(repo) == 0 {
+		names := make([]string, len(spans))
+		for i, s := range spans {
+			names[i] = s.Name
+		}
+		t.Fatalf("span request, service, dan repository harus ada; didapat %v", names)
+	}
+
+	if root.Parent.IsValid() || root.SpanKind != trace.SpanKindServer {
+		t.Errorf("span request: parent=%v kind=%v, want root server", root.Parent.SpanID(), root.SpanKind)
+	}
+	if svc.Parent.SpanID() != root.SpanContext.SpanID() {
+		t.Errorf("parent service.GetPortfolioData = %s, want span request %s", svc.Parent.SpanID(), root.SpanContext.SpanID())
+	}
+
+	// Setiap query adalah turunan span service dalam trace yang sama, tidak pernah root sendiri
+	for _, s := range repo {
+		if !s.Parent.IsValid() {
+			t.Errorf("%s adalah root span", s.Name)
+			continue
+		}
+		if s.SpanContext.TraceID() != root.SpanContext.TraceID() {
+			t.Errorf("%s berada di trace lain", s.Name)
+		}
+		ancestor, ok := byID[s.Parent.SpanID()]
+		for ok && ancestor.SpanContext.SpanID() != svc.SpanContext.SpanID() {
+			ancestor, ok = byID[ancestor.Parent.SpanID()]
+		}
+		if !ok {
+			t.Errorf("%s bukan turunan service.GetPortfolioData", s.Name)
+		}
+	}
+}
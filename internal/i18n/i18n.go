@@ -0,0 +1,129 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Locale yang didukung aplikasi
+const (
+	ID      = "id" // Bahasa Indonesia
+	EN      = "en" // Bahasa Inggris
+	Default = ID   // Locale default jika tidak diketahui
+)
+
+// shortMonths berisi singkatan nama bulan per locale (Januari = indeks 0)
+var shortMonths = map[string][12]string{
+	ID: {"Jan", "Feb", "Mar", "Apr", "Mei", "Jun", "Jul", "Agu", "Sep", "Okt", "Nov", "Des"},
+	EN: {"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+}
+
+// longMonths berisi nama bulan lengkap per locale (Januari = indeks 0)
+var longMonths = map[string][12]string{
+	ID: {"Januari", "Februari", "Maret", "April", "Mei", "Juni", "Juli", "Agustus", "September", "Oktober", "November", "Desember"},
+	EN: {"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+}
+
+// presentWords adalah label "sampai sekarang" per locale
+var presentWords = map[string]string{
+	ID: "Sekarang",
+	EN: "Present",
+}
+
+// normalize mengembalikan locale yang didukung, fallback ke Default
+func normalize(locale string) string {
+	if _, ok := shortMonths[locale]; ok {
+		return locale
+	}
+	return Default
+}
+
+// MonthYear memformat tanggal menjadi "Bln YYYY" sesuai locale (misal: "Agu 2023")
+func MonthYear(t time.Time, locale string) string {
+	return fmt.Sprintf("%s %d", shortMonths[normalize(locale)][t.Month()-1], t.Year())
+}
+
+// Period memformat rentang waktu pengalaman/proyek sesuai locale
+// Contoh: "Jan 2023 - Sekarang" atau "Mar 2021 - Dec 2022".
+// Mengembalikan string kosong jika tanggal mulai belum diisi.
+func Period(start, end *time.Time, isCurrent bool, locale string) string {
+	if start == nil {
+		return ""
+	}
+	locale = normalize(locale)
+
+	to := presentWords[locale]
+	if !isCurrent && end != nil {
+		to = MonthYear(*end, locale)
+	}
+	return MonthYear(*start, locale) + " - " + to
+}
+
+// Tenure memformat durasi dalam bulan menjadi teks yang mudah dibaca
+// Contoh: "5 tahun 3 bulan" (id) atau "5 years 3 months" (en)
+func Tenure(months int, locale string) string {
+	years, rest := months/12, months%12
+
+	var parts []string
+	switch normalize(locale) {
+	case EN:
+		if years > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", years, plural(years, "year", "years")))
+		}
+		if rest > 0 || years == 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", rest, plural(rest, "month", "months")))
+		}
+	default:
+		if years > 0 {
+			parts = append(parts, fmt.Sprintf("%d tahun", years))
+		}
+		if rest > 0 || years == 0 {
+			parts = append(parts, fmt.Sprintf("%d bulan", rest))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// plural memilih bentuk tunggal/jamak untuk bahasa Inggris
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
+
+// ParseMonth mengenali nama bulan (lengkap atau singkatan) dalam
+// bahasa Indonesia maupun Inggris, tanpa membedakan huruf besar/kecil
+func ParseMonth(name string) (time.Month, bool) {
+	name = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(name), "."))
+	if name == "" {
+		return 0, false
+	}
+	for _, table := range []map[string][12]string{longMonths, shortMonths} {
+		for _, months := range table {
+			for i, m := range months {
+				if strings.ToLower(m) == name {
+					return time.Month(i + 1), true
+				}
+			}
+		}
+	}
+	// Singkatan tidak baku yang sering dipakai (misal: "Agt", "Sept")
+	switch name {
+	case "agt":
+		return time.August, true
+	case "sept":
+		return time.September, true
+	}
+	return 0, false
+}
+
+// IsPresentWord mengenali kata "sampai sekarang" dalam bahasa Indonesia maupun Inggris
+func IsPresentWord(word string) bool {
+	switch strings.ToLower(strings.TrimSpace(word)) {
+	case "sekarang", "saat ini", "kini", "present", "now", "current", "today":
+		return true
+	}
+	return false
+}
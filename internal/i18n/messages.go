@@ -0,0 +1,449 @@
+package i18n
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Locales adalah daftar semua locale yang didukung, locale default paling awal
+var Locales = []string{ID, EN}
+
+// names berisi nama setiap locale dalam bahasanya sendiri
+var names = map[string]string{
+	ID: "Bahasa Indonesia",
+	EN: "English",
+}
+
+// messages adalah katalog teks UI statis per locale
+// Key diawali "js." dikirim ke browser untuk dipakai script di halaman utama,
+// key diawali "flash." adalah pesan notifikasi dashboard admin
+var messages = map[string]map[string]string{
+	ID: {
+		// Halaman utama
+		"page.title":                "Portofolio",
+		"page.load_failed":          "Gagal memuat data portofolio",
+		"preview.banner":            "👁 Mode preview — konten bertanda %s belum tampil untuk pengunjung",
+		"badge.draft":               "draft",
+		"cover.hint":                "Klik untuk membuka →",
+		"cover.dedication":          "\"Setiap baris kode adalah paragraf dalam cerita yang saya tulis.\"",
+		"chapter":                   "Bab %d",
+		"about.title":               "Tentang Saya",
+		"about.photo_alt":           "Foto %s",
+		"about.avatar_alt":          "Avatar Default",
+		"about.photo_caption":       "↑ itu saya",
+		"about.find_me":             "Temukan saya di:",
+		"experience.title":          "Pengalaman Kerja",
+		"experience.tenure":         "± %s berkarya",
+		"experience.note":           "📝 catatan: pengalaman membentuk perspektif, bukan sekadar daftar riwayat hidup.",
+		"projects.title":            "Proyek",
+		"projects.archived":         "arsip",
+		"projects.view":             "→ lihat proyek",
+		"projects.github":           "→ lihat github",
+		"projects.note":             "✨ Setiap proyek punya cerita. Yang penting bukan hanya apa yang dibangun, tapi mengapa dan dampaknya.",
+		"techstack.title":           "Tech Stack",
+		"techstack.project_count":   "(%d proyek)",
+		"techstack.note":            "🔧 Teknologi hanyalah alat. Yang penting adalah masalah apa yang bisa dipecahkan dengannya.",
+		"contact.title":             "Hubungi Saya",
+		"contact.intro":             "Punya proyek menarik? Mau kolaborasi? Atau sekadar ngobrol soal teknologi? Tulis pesan di bawah!",
+		"contact.name":              "Nama:",
+		"contact.name_placeholder":  "Siapa nama kamu?",
+		"contact.email":             "Email:",
+		"contact.email_placeholder": "alamat@email.com",
+		"contact.message":           "Pesan:",
+		"contact.message_hint":      "Tulis sesuatu...",
+		"contact.submit":            "Kirim Pesan ✉",
+		"contact.invalid":           "Data tidak valid. Pastikan semua field terisi dengan benar.",
+		"contact.failed":            "Gagal mengirim pesan. Silakan coba lagi.",
+		"contact.sent":              "Pesan berhasil dikirim! Terima kasih sudah menghubungi.",
+		"contact.rate_limited":      "Terlalu banyak pesan dalam waktu singkat. Coba lagi nanti.",
+		"contact.too_fast":          "Pesan dikirim terlalu cepat. Tunggu sebentar lalu coba lagi.",
+		"contact.expired":           "Form sudah kedaluwarsa. Muat ulang halaman lalu coba lagi.",
+		"contact.captcha_failed":    "Verifikasi anti-robot gagal. Silakan coba lagi.",
+		"contact.consent":           "Saya setuju nama, email, dan pesan ini disimpan untuk membalas pesan saya.",
+		"back.thanks":               "Terima kasih sudah membaca sampai halaman terakhir! 📚",
+		"back.made_with":            "— dibuat dengan ☕ dan Go",
+		"back.easter_egg_title":     "Kamu menemukan easter egg! 🎉",
+		"back.easter_egg":           "404: Halaman berikutnya tidak ditemukan. Mungkin kita bisa menulisnya bersama?",
+		"back.isbn":                 "ISBN: BUKAN-BUKU-BENERAN",
+		"nav.prev":                  "Halaman sebelumnya",
+		"nav.next":                  "Halaman berikutnya",
+		"nav.darkmode":              "Mode gelap",
+		"nav.language":              "Baca dalam bahasa lain",
+		"mobile.cover":              "📕 Cover",
+		"mobile.about":              "👤 About",
+		"mobile.experience":         "💼 Exp",
+		"mobile.projects":           "🚀 Projects",
+		"mobile.techstack":          "🔧 Tech",
+		"mobile.contact":            "✉ Contact",
+		"js.page.cover":             "Cover",
+		"js.page.about":             "About Me",
+		"js.page.experience":        "Experience",
+		"js.page.projects":          "Projects",
+		"js.page.techstack":         "Tech Stack",
+		"js.page.contact":           "Contact",
+		"js.page.back_cover":        "Back Cover",
+		"js.page.n":                 "Halaman ",
+		"js.view.book":              "Kembali ke Buku",
+		"js.view.scroll":            "Mode HP (Scroll)",
+		"js.theme.dark":             "Mode gelap",
+		"js.theme.light":            "Mode terang",
+		"js.contact.name_short":     "Nama minimal 2 karakter ya!",
+		"js.contact.email_invalid":  "Format email tidak valid.",
+		"js.contact.message_short":  "Pesan minimal 10 karakter. Cerita lebih banyak dong!",
+		"js.contact.message_long":   "Pesan terlalu panjang (maks 2000 karakter).",
+		"js.contact.sending":        "Mengirim...",
+		"js.contact.verifying":      "Memverifikasi...",
+		"js.contact.captcha_needed": "Selesaikan verifikasi anti-robot dulu ya.",
+		"js.contact.sent":           "Pesan berhasil dikirim!",
+		"js.contact.failed":         "Gagal mengirim pesan.",
+		"js.contact.network_error":  "Terjadi kesalahan jaringan. Coba lagi nanti.",
+		"js.contact.consent_needed": "Centang persetujuan penyimpanan data dulu ya.",
+
+		// Email notifikasi
+		"email.owner_subject":      "Pesan baru dari %s",
+		"email.owner_intro":        "Ada pesan baru dari form kontak portofolio:",
+		"email.from":               "Dari",
+		"email.sent_at":            "Dikirim",
+		"email.open_dashboard":     "Buka dashboard",
+		"email.auto_reply_subject": "Terima kasih sudah menghubungi %s",
+		"email.greeting":           "Halo %s,",
+		"email.auto_reply_body":    "Terima kasih sudah menulis pesan! Pesanmu sudah saya terima dan akan saya balas secepatnya.",
+		"email.your_message":       "Salinan pesanmu:",
+		"email.reply_subject":      "Re: Pesanmu untuk %s",
+		"email.reply_quote":        "Pada %s, kamu menulis:",
+
+		// Ringkasan event webhook (untuk preset Slack/Discord/Telegram)
+		"webhook.message_created":   "✉ Pesan baru dari %s: %s",
+		"webhook.created":           "➕ %s baru: %s",
+		"webhook.updated":           "✏ %s diperbarui: %s",
+		"webhook.deleted":           "🗑 %s dipindah ke sampah: %s",
+		"webhook.config_updated":    "⚙ Konfigurasi situs diperbarui: %s",
+		"webhook.entity.experience": "Pengalaman kerja",
+		"webhook.entity.project":    "Proyek",
+		"webhook.entity.techstack":  "Tech stack",
+
+		// Notifikasi dashboard admin
+		"flash.experience_created":       "Experience berhasil ditambahkan",
+		"flash.experience_updated":       "Experience berhasil diupdate",
+		"flash.experience_trashed":       "Experience dipindahkan ke sampah",
+		"flash.experience_create_failed": "Gagal menambah experience",
+		"flash.experience_update_failed": "Gagal update experience",
+		"flash.experience_delete_failed": "Gagal hapus experience",
+		"flash.project_created":          "Project berhasil ditambahkan",
+		"flash.project_updated":          "Project berhasil diupdate",
+		"flash.project_trashed":          "Project dipindahkan ke sampah",
+		"flash.project_create_failed":    "Gagal menambah project",
+		"flash.project_update_failed":    "Gagal update project",
+		"flash.project_delete_failed":    "Gagal hapus project",
+		"flash.techstack_created":        "Tech stack berhasil ditambahkan",
+		"flash.techstack_updated":        "Tech stack berhasil diupdate",
+		"flash.techstack_trashed":        "Tech stack dipindahkan ke sampah",
+		"flash.techstack_create_failed":  "Gagal menambah tech stack",
+		"flash.techstack_update_failed":  "Gagal update tech stack",
+		"flash.techstack_delete_failed":  "Gagal hapus tech stack",
+		"flash.config_updated":           "Konfigurasi berhasil diupdate",
+		"flash.config_update_failed":     "Gagal update konfigurasi",
+		"flash.message_read":             "Pesan ditandai dibaca",
+		"flash.message_trashed":          "Pesan dipindahkan ke sampah",
+		"flash.message_spam":             "Pesan dipindahkan ke folder spam",
+		"flash.message_not_spam":         "Pesan dipindahkan ke kotak masuk",
+		"flash.message_archived":         "Pesan diarsipkan",
+		"flash.message_unarchived":       "Pesan dikembalikan ke kotak masuk",
+		"flash.message_update_failed":    "Gagal memperbarui pesan",
+		"flash.message_replied":          "Balasan masuk antrean kirim",
+		"flash.message_reply_failed":     "Gagal mengirim balasan",
+		"flash.message_reply_disabled":   "Balasan butuh SMTP (isi SMTP_HOST di konfigurasi server)",
+		"flash.messages_bulk_read":       "Pesan terpilih ditandai dibaca",
+		"flash.messages_bulk_archive":    "Pesan terpilih diarsipkan",
+		"flash.messages_bulk_delete":     "Pesan terpilih dipindahkan ke sampah",
+		"flash.messages_bulk_empty":      "Pilih minimal satu pesan",
+		"flash.messages_bulk_failed":     "Gagal memproses pesan terpilih",
+		"flash.messages_export_failed":   "Gagal mengekspor pesan",
+		"flash.privacy_erased":           "Semua data untuk email tersebut dihapus permanen",
+		"flash.privacy_anonymized":       "Data untuk email tersebut dianonimkan",
+		"flash.privacy_nothing":          "Tidak ada data untuk email tersebut (permintaan tetap dicatat)",
+		"flash.privacy_failed":           "Gagal memproses permintaan privasi. Periksa alamat email.",
+		"flash.privacy_secret_required":  "SESSION_SECRET belum diisi; permintaan privasi tidak bisa dicatat dengan aman. Isi SESSION_SECRET lalu restart server.",
+		"flash.csp_reports_cleared":      "Laporan pelanggaran CSP dibersihkan",
+		"flash.csp_reports_clear_failed": "Gagal membersihkan laporan CSP",
+		"flash.trash_restored":           "Konten berhasil dipulihkan",
+		"flash.trash_restore_failed":     "Gagal memulihkan konten",
+		"flash.trash_purged":             "Konten dihapus permanen",
+		"flash.trash_purge_failed":       "Gagal menghapus permanen",
+		"flash.revision_restored":        "Versi berhasil dipulihkan",
+		"flash.revision_restore_failed":  "Gagal memulihkan revisi",
+		"flash.history_not_found":        "Riwayat konten tidak ditemukan",
+		"flash.translation_saved":        "Terjemahan berhasil disimpan",
+		"flash.translation_save_failed":  "Gagal menyimpan terjemahan",
+		"flash.reorder_saved":            "Urutan berhasil disimpan.",
+		"flash.reorder_invalid":          "Daftar urutan tidak valid.",
+		"flash.reorder_failed":           "Gagal menyimpan urutan.",
+		"flash.login_invalid":            "Username dan password harus diisi.",
+		"flash.login_failed":             "Username atau password salah.",
+		"flash.webhook_created":          "Webhook berhasil ditambahkan",
+		"flash.webhook_updated":          "Webhook berhasil diupdate",
+		"flash.webhook_deleted":          "Webhook dihapus",
+		"flash.webhook_save_failed":      "Gagal menyimpan webhook (cek URL, format, dan event)",
+		"flash.webhook_delete_failed":    "Gagal hapus webhook",
+		"flash.webhook_redelivered":      "Delivery dijadwalkan ulang",
+		"flash.webhook_redeliver_failed": "Gagal menjadwalkan ulang delivery",
+	},
+	EN: {
+		// Halaman utama
+		"page.title":                  "Portfolio",
+		"page.load_failed":            "Failed to load portfolio data",
+		"preview.banner":              "👁 Preview mode — content marked %s is not visible to visitors yet",
+		"badge.draft":                 "draft",
+		"cover.hint":                  "Click to open →",
+		"cover.dedication":            "\"Every line of code is a paragraph in the story I write.\"",
+		"chapter":                     "Chapter %d",
+		"about.title":                 "About Me",
+		"about.photo_alt":             "Photo of %s",
+		"about.avatar_alt":            "Default avatar",
+		"about.photo_caption":         "↑ that's me",
+		"about.find_me":               "Find me on:",
+		"experience.title":            "Work Experience",
+		"experience.tenure":           "± %s of experience",
+		"experience.note":             "📝 note: experience shapes perspective — it is more than a list on a résumé.",
+		"projects.title":              "Projects",
+		"projects.archived":           "archived",
+		"projects.view":               "→ view project",
+		"projects.github":             "→ view on github",
+		"projects.note":               "✨ Every project has a story. What matters is not only what was built, but why, and the impact it made.",
+		"techstack.title":             "Tech Stack",
+		"techstack.project_count":     "(%d projects)",
+		"techstack.project_count.one": "(%d project)",
+		"techstack.note":              "🔧 Technology is just a tool. What matters is which problems it can solve.",
+		"contact.title":               "Get in Touch",
+		"contact.intro":               "Have an interesting project? Want to collaborate? Or just talk tech? Leave a message below!",
+		"contact.name":                "Name:",
+		"contact.name_placeholder":    "What's your name?",
+		"contact.email":               "Email:",
+		"contact.email_placeholder":   "you@email.com",
+		"contact.message":             "Message:",
+		"contact.message_hint":        "Write something...",
+		"contact.submit":              "Send Message ✉",
+		"contact.invalid":             "Invalid data. Please make sure every field is filled in correctly.",
+		"contact.failed":              "Failed to send your message. Please try again.",
+		"contact.sent":                "Message sent! Thanks for getting in touch.",
+		"contact.rate_limited":        "Too many messages in a short time. Please try again later.",
+		"contact.too_fast":            "That was sent a little too fast. Please wait a moment and try again.",
+		"contact.expired":             "This form has expired. Please reload the page and try again.",
+		"contact.captcha_failed":      "Human verification failed. Please try again.",
+		"contact.consent":             "I agree to this name, email and message being stored so you can reply to me.",
+		"back.thanks":                 "Thanks for reading all the way to the last page! 📚",
+		"back.made_with":              "— made with ☕ and Go",
+		"back.easter_egg_title":       "You found an easter egg! 🎉",
+		"back.easter_egg":             "404: Next page not found. Maybe we could write it together?",
+		"back.isbn":                   "ISBN: NOT-A-REAL-BOOK",
+		"nav.prev":                    "Previous page",
+		"nav.next":                    "Next page",
+		"nav.darkmode":                "Dark mode",
+		"nav.language":                "Read in another language",
+		"mobile.cover":                "📕 Cover",
+		"mobile.about":                "👤 About",
+		"mobile.experience":           "💼 Exp",
+		"mobile.projects":             "🚀 Projects",
+		"mobile.techstack":            "🔧 Tech",
+		"mobile.contact":              "✉ Contact",
+		"js.page.cover":               "Cover",
+		"js.page.about":               "About Me",
+		"js.page.experience":          "Experience",
+		"js.page.projects":            "Projects",
+		"js.page.techstack":           "Tech Stack",
+		"js.page.contact":             "Contact",
+		"js.page.back_cover":          "Back Cover",
+		"js.page.n":                   "Page ",
+		"js.view.book":                "Back to book",
+		"js.view.scroll":              "Mobile mode (scroll)",
+		"js.theme.dark":               "Dark mode",
+		"js.theme.light":              "Light mode",
+		"js.contact.name_short":       "Your name needs at least 2 characters.",
+		"js.contact.email_invalid":    "That email address doesn't look valid.",
+		"js.contact.message_short":    "Your message needs at least 10 characters. Tell me more!",
+		"js.contact.message_long":     "Your message is too long (max 2000 characters).",
+		"js.contact.sending":          "Sending...",
+		"js.contact.verifying":        "Verifying...",
+		"js.contact.captcha_needed":   "Please complete the human verification first.",
+		"js.contact.sent":             "Message sent!",
+		"js.contact.failed":           "Failed to send your message.",
+		"js.contact.network_error":    "A network error occurred. Please try again later.",
+		"js.contact.consent_needed":   "Please tick the data storage consent box first.",
+
+		// Email notifikasi
+		"email.owner_subject":      "New message from %s",
+		"email.owner_intro":        "You have a new message from the portfolio contact form:",
+		"email.from":               "From",
+		"email.sent_at":            "Sent",
+		"email.open_dashboard":     "Open dashboard",
+		"email.auto_reply_subject": "Thanks for getting in touch with %s",
+		"email.greeting":           "Hi %s,",
+		"email.auto_reply_body":    "Thanks for your message! I have received it and will get back to you as soon as I can.",
+		"email.your_message":       "A copy of your message:",
+		"email.reply_subject":      "Re: Your message to %s",
+		"email.reply_quote":        "On %s, you wrote:",
+
+		// Ringkasan event webhook (untuk preset Slack/Discord/Telegram)
+		"webhook.message_created":   "✉ New message from %s: %s",
+		"webhook.created":           "➕ New %s: %s",
+		"webhook.updated":           "✏ %s updated: %s",
+		"webhook.deleted":           "🗑 %s moved to trash: %s",
+		"webhook.config_updated":    "⚙ Site configuration updated: %s",
+		"webhook.entity.experience": "Experience",
+		"webhook.entity.project":    "Project",
+		"webhook.entity.techstack":  "Tech stack",
+
+		// Notifikasi dashboard admin
+		"flash.experience_created":       "Experience added",
+		"flash.experience_updated":       "Experience updated",
+		"flash.experience_trashed":       "Experience moved to trash",
+		"flash.experience_create_failed": "Failed to add experience",
+		"flash.experience_update_failed": "Failed to update experience",
+		"flash.experience_delete_failed": "Failed to delete experience",
+		"flash.project_created":          "Project added",
+		"flash.project_updated":          "Project updated",
+		"flash.project_trashed":          "Project moved to trash",
+		"flash.project_create_failed":    "Failed to add project",
+		"flash.project_update_failed":    "Failed to update project",
+		"flash.project_delete_failed":    "Failed to delete project",
+		"flash.techstack_created":        "Tech stack added",
+		"flash.techstack_updated":        "Tech stack updated",
+		"flash.techstack_trashed":        "Tech stack moved to trash",
+		"flash.techstack_create_failed":  "Failed to add tech stack",
+		"flash.techstack_update_failed":  "Failed to update tech stack",
+		"flash.techstack_delete_failed":  "Failed to delete tech stack",
+		"flash.config_updated":           "Site settings updated",
+		"flash.config_update_failed":     "Failed to update site settings",
+		"flash.message_read":             "Message marked as read",
+		"flash.message_trashed":          "Message moved to trash",
+		"flash.message_spam":             "Message moved to spam",
+		"flash.message_not_spam":         "Message moved to inbox",
+		"flash.message_archived":         "Message archived",
+		"flash.message_unarchived":       "Message moved back to inbox",
+		"flash.message_update_failed":    "Failed to update the message",
+		"flash.message_replied":          "Reply queued for sending",
+		"flash.message_reply_failed":     "Failed to send reply",
+		"flash.message_reply_disabled":   "Replies need SMTP (set SMTP_HOST in the server config)",
+		"flash.messages_bulk_read":       "Selected messages marked as read",
+		"flash.messages_bulk_archive":    "Selected messages archived",
+		"flash.messages_bulk_delete":     "Selected messages moved to trash",
+		"flash.messages_bulk_empty":      "Select at least one message",
+		"flash.messages_bulk_failed":     "Failed to process the selected messages",
+		"flash.messages_export_failed":   "Failed to export messages",
+		"flash.privacy_erased":           "All data for that email was permanently deleted",
+		"flash.privacy_anonymized":       "Data for that email was anonymized",
+		"flash.privacy_nothing":          "No data found for that email (the request was still logged)",
+		"flash.privacy_failed":           "Failed to process the privacy request. Check the email address.",
+		"flash.privacy_secret_required":  "SESSION_SECRET is not set, so privacy requests cannot be recorded safely. Set SESSION_SECRET and restart the server.",
+		"flash.csp_reports_cleared":      "CSP violation reports cleared",
+		"flash.csp_reports_clear_failed": "Failed to clear CSP reports",
+		"flash.trash_restored":           "Content restored",
+		"flash.trash_restore_failed":     "Failed to restore content",
+		"flash.trash_purged":             "Content permanently deleted",
+		"flash.trash_purge_failed":       "Failed to permanently delete content",
+		"flash.revision_restored":        "Version restored",
+		"flash.revision_restore_failed":  "Failed to restore revision",
+		"flash.history_not_found":        "Content history not found",
+		"flash.translation_saved":        "Translation saved",
+		"flash.translation_save_failed":  "Failed to save translation",
+		"flash.reorder_saved":            "Order saved.",
+		"flash.reorder_invalid":          "Invalid order list.",
+		"flash.reorder_failed":           "Failed to save order.",
+		"flash.login_invalid":            "Username and password are required.",
+		"flash.login_failed":             "Wrong username or password.",
+		"flash.webhook_created":          "Webhook added",
+		"flash.webhook_updated":          "Webhook updated",
+		"flash.webhook_deleted":          "Webhook deleted",
+		"flash.webhook_save_failed":      "Failed to save webhook (check URL, format and events)",
+		"flash.webhook_delete_failed":    "Failed to delete webhook",
+		"flash.webhook_redelivered":      "Delivery rescheduled",
+		"flash.webhook_redeliver_failed": "Failed to reschedule delivery",
+	},
+}
+
+// Supported memeriksa apakah locale termasuk yang didukung aplikasi
+func Supported(locale string) bool {
+	_, ok := messages[locale]
+	return ok
+}
+
+// Name mengembalikan nama locale dalam bahasanya sendiri (misal: "English")
+func Name(locale string) string {
+	return names[normalize(locale)]
+}
+
+// Lookup mencari teks di katalog locale, dengan fallback ke locale default
+// ok bernilai false jika key tidak ada di katalog mana pun
+func Lookup(locale, key string) (string, bool) {
+	if msg, ok := messages[normalize(locale)][key]; ok {
+		return msg, true
+	}
+	msg, ok := messages[Default][key]
+	return msg, ok
+}
+
+// T menerjemahkan key katalog ke teks sesuai locale
+// args (opsional) diformat dengan fmt.Sprintf. Key yang tidak dikenal dikembalikan apa adanya
+func T(locale, key string, args ...any) string {
+	msg, ok := Lookup(locale, key)
+	if !ok {
+		return key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// TN seperti T, tetapi memilih bentuk tunggal (key + ".one") jika n == 1
+// Locale tanpa bentuk tunggal khusus memakai key biasa
+func TN(locale, key string, n int) string {
+	if n == 1 {
+		if msg, ok := messages[normalize(locale)][key+".one"]; ok {
+			return fmt.Sprintf(msg, n)
+		}
+	}
+	return T(locale, key, n)
+}
+
+// Catalog mengembalikan semua teks locale dengan awalan prefix
+// Dipakai untuk mengirim teks "js." ke script di browser
+func Catalog(locale, prefix string) map[string]string {
+	result := make(map[string]string)
+	for key, msg := range messages[Default] {
+		if strings.HasPrefix(key, prefix) {
+			result[key] = msg
+		}
+	}
+	for key, msg := range messages[normalize(locale)] {
+		if strings.HasPrefix(key, prefix) {
+			result[key] = msg
+		}
+	}
+	return result
+}
+
+// Negotiate memilih locale terbaik dari header Accept-Language
+// Contoh: "en-US,en;q=0.9,id;q=0.8" → "en". Fallback ke Default jika tidak ada yang cocok
+func Negotiate(acceptLanguage string) string {
+	best, bestQ := Default, 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		// Ambil bahasa utama saja: "en-US" → "en"
+		lang, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if Supported(lang) && q > bestQ {
+			best, bestQ = lang, q
+		}
+	}
+	return best
+}
@@ -0,0 +1,74 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"portofolio-go/internal/logging"
+	"portofolio-go/internal/metrics"
+	"sync"
+	"time"
+)
+
+// Metrik hasil setiap putaran job (per nama job)
+var (
+	jobRuns = metrics.NewCounterVec("job_runs_total",
+		"Jumlah putaran background job per hasil (success, error).", "job", "result")
+	jobDuration = metrics.NewHistogramVec("job_duration_seconds",
+		"Durasi satu putaran background job.", []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 120}, "job")
+	jobLastSuccess = metrics.NewGaugeVec("job_last_success_timestamp_seconds",
+		"Waktu (Unix) putaran terakhir background job yang berhasil.", "job")
+)
+
+// Runner menjalankan pekerjaan latar belakang (background job) secara berkala
+// Semua job berhenti ketika Stop dipanggil; Stop menunggu job yang sedang berjalan selesai
+type Runner struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewRunner membuat instance Runner baru
+func NewRunner() *Runner {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Runner{ctx: ctx, cancel: cancel}
+}
+
+// Every menjadwalkan fn untuk dijalankan sekali saat start lalu setiap interval
+// Error dari fn hanya dicatat di log agar job tetap berjalan di putaran berikutnya
+func (r *Runner) Every(name string, interval time.Duration, fn func(ctx context.Context) error) {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		// Nama job ikut di setiap log yang ditulis fn lewat context
+		ctx := logging.With(r.ctx, slog.String("job", name))
+		for {
+			start := time.Now()
+			err := fn(ctx)
+			switch {
+			case err == nil:
+				jobRuns.Inc(name, "success")
+				jobLastSuccess.Set(float64(time.Now().Unix()), name)
+			case ctx.Err() == nil:
+				jobRuns.Inc(name, "error")
+				slog.ErrorContext(ctx, "Job gagal", "error", err)
+			}
+			jobDuration.ObserveSince(start, name)
+
+			select {
+			case <-r.ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop menghentikan semua job dan menunggu sampai semuanya selesai
+func (r *Runner) Stop() {
+	r.cancel()
+	r.wg.Wait()
+}
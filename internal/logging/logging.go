@@ -0,0 +1,109 @@
+// Package logging menyiapkan log terstruktur (log/slog) untuk seluruh aplikasi.
+// Atribut yang ditempel ke context (misal: request_id dari middleware.RequestID atau
+// nama job) otomatis ikut di setiap baris log yang ditulis dengan slog.*Context(ctx, ...),
+// sehingga semua log dari satu request bisa dicari dengan satu ID
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Format output log
+const (
+	FormatJSON = "json" // Satu objek JSON per baris (untuk produksi / agregator log)
+	FormatText = "text" // key=value yang mudah dibaca manusia (untuk development)
+)
+
+// requestIDKey adalah nama atribut log untuk request ID
+const requestIDKey = "request_id"
+
+// contextKey adalah key context untuk atribut log milik request/job saat ini
+type contextKey struct{}
+
+// New membuat logger dengan format (json/text) dan level minimum (debug/info/warn/error)
+func New(w io.Writer, format, level string) (*slog.Logger, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("LOG_LEVEL tidak dikenal: %q (pilihan: debug, info, warn, error)", level)
+	}
+	opts := &slog.HandlerOptions{Level: lvl}
+
+	var h slog.Handler
+	switch strings.ToLower(format) {
+	case FormatJSON:
+		h = slog.NewJSONHandler(w, opts)
+	case FormatText:
+		h = slog.NewTextHandler(w, opts)
+	default:
+		return nil, fmt.Errorf("LOG_FORMAT tidak dikenal: %q (pilihan: json, text)", format)
+	}
+	return slog.New(contextHandler{h}), nil
+}
+
+// With mengembalikan context turunan yang membawa atribut log tambahan
+// (misal: logging.With(ctx, slog.String("job", name))); atribut lama tetap ikut
+func With(ctx context.Context, attrs ...slog.Attr) context.Context {
+	return context.WithValue(ctx, contextKey{}, append(slices.Clip(attrsFrom(ctx)), attrs...))
+}
+
+// WithRequestID menempelkan request ID ke context untuk log dan pemanggilan berikutnya
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return With(ctx, slog.String(requestIDKey, id))
+}
+
+// RequestID mengembalikan request ID yang ditempel WithRequestID (kosong jika tidak ada)
+func RequestID(ctx context.Context) string {
+	for _, a := range attrsFrom(ctx) {
+		if a.Key == requestIDKey {
+			return a.Value.String()
+		}
+	}
+	return ""
+}
+
+// attrsFrom mengambil atribut log yang tersimpan di context
+func attrsFrom(ctx context.Context) []slog.Attr {
+	if ctx == nil {
+		return nil
+	}
+	attrs, _ := ctx.Value(contextKey{}).([]slog.Attr)
+	return attrs
+}
+
+// contextHandler menambahkan atribut dari context ke setiap record sebelum ditulis
+type contextHandler struct {
+	slog.Handler
+}
+
+// Handle menulis record beserta atribut context (request_id, job, dst) dan trace_id/span_id
+// jika context berada di dalam trace OpenTelemetry, agar log bisa dicocokkan dengan trace
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	attrs := attrsFrom(ctx)
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		attrs = append(slices.Clip(attrs),
+			slog.String("trace_id", sc.TraceID().String()),
+			slog.String("span_id", sc.SpanID().String()))
+	}
+	if len(attrs) > 0 {
+		r = r.Clone()
+		r.AddAttrs(attrs...)
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs membungkus ulang handler agar atribut context tetap ditambahkan
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup membungkus ulang handler agar atribut context tetap ditambahkan
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
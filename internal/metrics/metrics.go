@@ -0,0 +1,116 @@
+// Package metrics menyediakan metrik aplikasi untuk Prometheus lewat client_golang.
+// Metrik didaftarkan sekali sebagai variabel package di tempat ia diukur (misal: durasi
+// query di repository) ke registry bawaan, lalu disajikan bersama metrik runtime Go
+// (go_*) dan proses (process_*) oleh Handler di /metrics.
+// Package ini hanya membungkus client_golang dengan API label berurutan yang ringkas
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// DefBuckets adalah batas bucket histogram durasi request (detik), sama dengan bawaan Prometheus
+var DefBuckets = prometheus.DefBuckets
+
+// Handler menyajikan semua metrik terdaftar (termasuk collector Go dan proses bawaan
+// registry default) dalam format yang dinegosiasikan dengan scraper
+func Handler() http.Handler {
+	h := promhttp.Handler()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-store")
+		h.ServeHTTP(w, r)
+	})
+}
+
+// ============================================
+// VEC — Metrik dengan Label
+// ============================================
+
+// CounterVec adalah counter (hanya bisa naik) per kombinasi label
+type CounterVec struct {
+	vec *prometheus.CounterVec
+}
+
+// NewCounterVec mendaftarkan counter baru dengan nama label yang diberikan.
+// Nama ganda adalah kesalahan program sehingga panic
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	vec := prometheus.NewCounterVec(prometheus.CounterOpts{Name: name, Help: help}, labels)
+	prometheus.MustRegister(vec)
+	return &CounterVec{vec: vec}
+}
+
+// Inc menambah counter sebanyak 1 untuk nilai label yang diberikan
+func (c *CounterVec) Inc(labels ...string) {
+	c.vec.WithLabelValues(labels...).Inc()
+}
+
+// Add menambah counter sebanyak delta (delta negatif diabaikan)
+func (c *CounterVec) Add(delta float64, labels ...string) {
+	if delta < 0 {
+		return
+	}
+	c.vec.WithLabelValues(labels...).Add(delta)
+}
+
+// GaugeVec adalah nilai yang bisa naik turun per kombinasi label
+type GaugeVec struct {
+	vec *prometheus.GaugeVec
+}
+
+// NewGaugeVec mendaftarkan gauge baru dengan nama label yang diberikan
+func NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+	vec := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: name, Help: help}, labels)
+	prometheus.MustRegister(vec)
+	return &GaugeVec{vec: vec}
+}
+
+// Set mengisi nilai gauge untuk nilai label yang diberikan
+func (g *GaugeVec) Set(value float64, labels ...string) {
+	g.vec.WithLabelValues(labels...).Set(value)
+}
+
+// ============================================
+// HISTOGRAM — Distribusi Durasi
+// ============================================
+
+// HistogramVec menghitung distribusi nilai (biasanya durasi dalam detik) per kombinasi label
+type HistogramVec struct {
+	vec *prometheus.HistogramVec
+}
+
+// NewHistogramVec mendaftarkan histogram baru; buckets adalah batas atas (inklusif) tiap bucket
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	vec := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: name, Help: help, Buckets: buckets}, labels)
+	prometheus.MustRegister(vec)
+	return &HistogramVec{vec: vec}
+}
+
+// Observe mencatat satu nilai untuk nilai label yang diberikan
+func (h *HistogramVec) Observe(value float64, labels ...string) {
+	h.vec.WithLabelValues(labels...).Observe(value)
+}
+
+// ObserveSince mencatat durasi sejak start dalam detik
+func (h *HistogramVec) ObserveSince(start time.Time, labels ...string) {
+	h.Observe(time.Since(start).Seconds(), labels...)
+}
+
+// ============================================
+// FUNC — Nilai yang Dibaca Saat Scrape
+// ============================================
+
+// NewGaugeFunc mendaftarkan gauge tanpa label yang nilainya diambil dari fn setiap kali
+// di-scrape (misal: statistik pool koneksi database atau jumlah session aktif)
+func NewGaugeFunc(name, help string, fn func() float64) {
+	prometheus.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{Name: name, Help: help}, fn))
+}
+
+// NewCounterFunc mendaftarkan counter yang nilainya diambil dari fn setiap kali di-scrape
+// (fn harus mengembalikan total kumulatif yang tidak pernah turun)
+func NewCounterFunc(name, help string, fn func() float64) {
+	prometheus.MustRegister(prometheus.NewCounterFunc(prometheus.CounterOpts{Name: name, Help: help}, fn))
+}
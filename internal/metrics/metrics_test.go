@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// scrape mengambil isi /metrics dalam format teks seperti Prometheus
+func scrape(t *testing.T) string {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("Accept", "text/plain")
+	w := httptest.NewRecorder()
+	Handler().ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d", w.Code)
+	}
+	if got := w.Header().Get("Cache-Control"); got != "no-store" {
+		t.Errorf("Cache-Control = %q, want no-store", got)
+	}
+	body, _ := io.ReadAll(w.Body)
+	return string(body)
+}
+
+func TestHandler(t *testing.T) {
+	requests := NewCounterVec("test_requests_total", "Jumlah request uji.", "route", "status")
+	requests.Inc("/api/contact", "200")
+	requests.Add(2, "/api/contact", "200")
+	requests.Add(-5, "/api/contact", "200") // Diabaikan: counter tidak boleh turun
+	requests.Inc(`/a"b\c`+"\n", "500")
+
+	jobs := NewGaugeVec("test_last_success_seconds", "Waktu sukses terakhir.", "job")
+	jobs.Set(1.5e9, "cleanup")
+
+	duration := NewHistogramVec("test_duration_seconds", "Durasi uji.", []float64{0.1, 1}, "method")
+	duration.Observe(0.05, "GET")
+	duration.Observe(0.5, "GET")
+	duration.Observe(3, "GET")
+
+	NewGaugeFunc("test_sessions_active", "Session aktif.", func() float64 { return 4 })
+	NewCounterFunc("test_waits_total", "Total menunggu.", func() float64 { return 7 })
+
+	body := scrape(t)
+	for _, want := range []string{
+		"# HELP test_requests_total Jumlah request uji.\n# TYPE test_requests_total counter\n",
+		`test_requests_total{route="/api/contact",status="200"} 3` + "\n",
+		`test_requests_total{route="/a\"b\\c\n",status="500"} 1` + "\n",
+
+		"# TYPE test_last_success_seconds gauge\n",
+		`test_last_success_seconds{job="cleanup"} 1.5e+09` + "\n",
+
+		"# TYPE test_duration_seconds histogram\n",
+		`test_duration_seconds_bucket{method="GET",le="0.1"} 1` + "\n",
+		`test_duration_seconds_bucket{method="GET",le="1"} 2` + "\n",
+		`test_duration_seconds_bucket{method="GET",le="+Inf"} 3` + "\n",
+		`test_duration_seconds_sum{method="GET"} 3.55` + "\n",
+		`test_duration_seconds_count{method="GET"} 3` + "\n",
+
+		"# TYPE test_sessions_active gauge\ntest_sessions_active 4\n",
+		"# TYPE test_waits_total counter\ntest_waits_total 7\n",
+
+		// Collector runtime Go dan proses dari registry default
+		"# TYPE go_goroutines gauge\n",
+		"# TYPE process_cpu_seconds_total counter\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("/metrics tidak memuat:\n%s", want)
+		}
+	}
+}
+
+func TestRegisterTwicePanics(t *testing.T) {
+	NewCounterVec("test_duplicate_total", "Pertama.")
+	defer func() {
+		if recover() == nil {
+			t.Fatal("mendaftarkan nama metrik yang sama dua kali tidak panic")
+		}
+	}()
+	NewCounterVec("test_duplicate_total", "Kedua.")
+}
@@ -58,9 +58,9 @@ func CreateSession(c *gin.Context, username string) string {
 		token,
 		int(sessionDuration.Seconds()),
 		"/",
-		"",    // Domain kosong = domain saat ini
-		false, // Secure = false untuk development (set true di production)
-		true,  // HttpOnly = true untuk mencegah JS mengakses cookie
+		"",                   // Domain kosong = domain saat ini
+		c.Request.TLS != nil, // Secure jika diakses lewat HTTPS (lihat TLS_CERT_FILE/ACME_DOMAINS)
+		true,                 // HttpOnly = true untuk mencegah JS mengakses cookie
 	)
 
 	return token
@@ -80,7 +80,7 @@ func DestroySession(c *gin.Context) {
 	delete(sessionStore.sessions, token)
 
 	// Hapus cookie dari browser
-	c.SetCookie(sessionCookieName, "", -1, "/", "", false, true)
+	c.SetCookie(sessionCookieName, "", -1, "/", "", c.Request.TLS != nil, true)
 }
 
 // AuthRequired adalah middleware yang memastikan request berasal dari admin yang sudah login
@@ -121,6 +121,20 @@ func AuthRequired() gin.HandlerFunc {
 	}
 }
 
+// ActiveSessions menghitung session admin yang belum kadaluarsa (untuk metrik)
+func ActiveSessions() int {
+	sessionStore.mu.RLock()
+	defer sessionStore.mu.RUnlock()
+
+	now, n := time.Now(), 0
+	for _, session := range sessionStore.sessions {
+		if now.Before(session.ExpiresAt) {
+			n++
+		}
+	}
+	return n
+}
+
 // generateToken membuat token random 32 byte (64 karakter hex)
 // menggunakan crypto/rand yang aman secara kriptografi
 func generateToken() string {
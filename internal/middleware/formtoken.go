@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Umur maksimal token form kontak. contact.js mengambil token baru secara berkala,
+// jadi hanya halaman yang ditinggal (misal: laptop tidur) yang perlu dimuat ulang
+const FormTokenMaxAge = 30 * time.Minute
+
+// Error verifikasi token form kontak
+var (
+	ErrFormTokenInvalid = errors.New("token form tidak valid")
+	ErrFormTooFast      = errors.New("form dikirim terlalu cepat")
+	ErrFormExpired      = errors.New("token form sudah kedaluwarsa")
+	ErrFormTokenUsed    = errors.New("token form sudah dipakai")
+)
+
+// FormTokens menerbitkan dan memverifikasi token form kontak.
+// Token bersifat stateless (ditandatangani HMAC); hanya token yang sudah dipakai
+// untuk mengirim pesan yang disimpan di memory sampai kedaluwarsa untuk mencegah replay
+type FormTokens struct {
+	secret string
+	used   map[string]time.Time // Token terpakai → waktu kedaluwarsanya
+	mu     sync.Mutex
+}
+
+// NewFormTokens membuat penerbit token form kontak yang ditandatangani dengan secret.
+// secret harus sudah lolos config.PrepareSessionSecret; secret kosong adalah kesalahan
+// program (siapa pun bisa membuat token bertanggal lama untuk melewati cek terlalu cepat)
+func NewFormTokens(secret string) *FormTokens {
+	if secret == "" {
+		panic("middleware: secret token form kontak kosong")
+	}
+	return &FormTokens{secret: secret, used: make(map[string]time.Time)}
+}
+
+// Sign membuat token berisi waktu form kontak dirender dan nonce acak
+// Format token: "<unix-render>.<hex nonce>.<hex HMAC-SHA256>"
+func (t *FormTokens) Sign(renderedAt time.Time) string {
+	nonce := make([]byte, 12)
+	rand.Read(nonce)
+	payload := strconv.FormatInt(renderedAt.Unix(), 10) + "." + hex.EncodeToString(nonce)
+	return payload + "." + signature(t.secret, "contact:"+payload)
+}
+
+// Verify memeriksa tanda tangan token, umur form, dan apakah token sudah pernah dipakai.
+// Form yang dikirim kurang dari minAge setelah dirender hampir pasti diisi bot.
+// Verify tidak menandai token terpakai; panggil Use setelah pesan lolos semua pemeriksaan
+func (t *FormTokens) Verify(token string, now time.Time, minAge time.Duration) error {
+	renderedAt, err := t.parse(token)
+	if err != nil {
+		return err
+	}
+
+	age := now.Sub(renderedAt)
+	switch {
+	case age < minAge:
+		return ErrFormTooFast
+	case age > FormTokenMaxAge:
+		return ErrFormExpired
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, dup := t.used[token]; dup {
+		return ErrFormTokenUsed
+	}
+	return nil
+}
+
+// Use menandai token terpakai sehingga tidak bisa dikirim ulang.
+// Pemeriksaan dan penandaan dilakukan sekaligus agar dua request bersamaan
+// dengan token yang sama tidak sama-sama lolos
+func (t *FormTokens) Use(token string, now time.Time) error {
+	renderedAt, err := t.parse(token)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for key, exp := range t.used {
+		if now.After(exp) {
+			delete(t.used, key)
+		}
+	}
+	if _, dup := t.used[token]; dup {
+		return ErrFormTokenUsed
+	}
+	t.used[token] = renderedAt.Add(FormTokenMaxAge)
+	return nil
+}
+
+// parse memeriksa format dan tanda tangan token lalu mengembalikan waktu render
+func (t *FormTokens) parse(token string) (time.Time, error) {
+	ts, rest, ok := strings.Cut(token, ".")
+	if !ok {
+		return time.Time{}, ErrFormTokenInvalid
+	}
+	nonce, sig, ok := strings.Cut(rest, ".")
+	if !ok || nonce == "" {
+		return time.Time{}, ErrFormTokenInvalid
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || !hmac.Equal([]byte(sig), []byte(signature(t.secret, "contact:"+ts+"."+nonce))) {
+		return time.Time{}, ErrFormTokenInvalid
+	}
+	return time.Unix(unix, 0), nil
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestFormTokens(t *testing.T) {
+	const minAge = 3 * time.Second
+	tokens := NewFormTokens("c2f1a9e07b4d4e3f8a6b5c4d3e2f1a0b")
+	renderedAt := time.Unix(1_800_000_000, 0)
+	token := tokens.Sign(renderedAt)
+
+	// Token yang ditandatangani dengan default lama dari repo tidak boleh diterima
+	forged := NewFormTokens("default-secret-ganti-ini").Sign(renderedAt.Add(-time.Hour))
+
+	tests := []struct {
+		name  string
+		token string
+		now   time.Time
+		want  error
+	}{
+		{"valid", token, renderedAt.Add(10 * time.Second), nil},
+		{"terlalu cepat", token, renderedAt.Add(time.Second), ErrFormTooFast},
+		{"kedaluwarsa", token, renderedAt.Add(FormTokenMaxAge + time.Second), ErrFormExpired},
+		{"secret lain", forged, renderedAt.Add(10 * time.Second), ErrFormTokenInvalid},
+		{"waktu diubah", strconv.FormatInt(renderedAt.Unix()-600, 10) + token[len("1800000000"):], renderedAt.Add(10 * time.Second), ErrFormTokenInvalid},
+		{"tanpa nonce", "1800000000.abc", renderedAt.Add(10 * time.Second), ErrFormTokenInvalid},
+		{"kosong", "", renderedAt, ErrFormTokenInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tokens.Verify(tt.token, tt.now, minAge); !errors.Is(err, tt.want) {
+				t.Fatalf("Verify = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormTokensSingleUse(t *testing.T) {
+	tokens := NewFormTokens("c2f1a9e07b4d4e3f8a6b5c4d3e2f1a0b")
+	renderedAt := time.Now().Add(-time.Minute)
+	token := tokens.Sign(renderedAt)
+	now := time.Now()
+
+	if err := tokens.Use(token, now); err != nil {
+		t.Fatalf("Use pertama: %v", err)
+	}
+	if err := tokens.Verify(token, now, 0); !errors.Is(err, ErrFormTokenUsed) {
+		t.Fatalf("Verify setelah dipakai = %v, want ErrFormTokenUsed", err)
+	}
+	if err := tokens.Use(token, now); !errors.Is(err, ErrFormTokenUsed) {
+		t.Fatalf("Use kedua = %v, want ErrFormTokenUsed", err)
+	}
+
+	// Token lain dari waktu render yang sama tetap berlaku (nonce berbeda)
+	if err := tokens.Use(tokens.Sign(renderedAt), now); err != nil {
+		t.Fatalf("Use token baru: %v", err)
+	}
+
+	// Catatan token terpakai dibuang setelah token kedaluwarsa
+	tokens.Use(tokens.Sign(now), now.Add(FormTokenMaxAge+2*time.Minute))
+	if n := len(tokens.used); n != 1 {
+		t.Errorf("token terpakai tersimpan = %d, want 1 setelah sweep", n)
+	}
+}
+
+func TestNewFormTokensEmptySecret(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("NewFormTokens menerima secret kosong")
+		}
+	}()
+	NewFormTokens("")
+}
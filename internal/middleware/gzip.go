@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// gzipTypes adalah Content-Type respons dinamis yang dikompres middleware Gzip.
+// File statis tidak termasuk karena sudah punya varian terkompresi sendiri (lihat internal/assets)
+var gzipTypes = []string{"text/html", "application/json"}
+
+// gzipPool menyimpan gzip.Writer agar tidak dialokasikan ulang di setiap request
+var gzipPool = sync.Pool{
+	New: func() any {
+		gz, _ := gzip.NewWriterLevel(io.Discard, gzip.DefaultCompression)
+		return gz
+	},
+}
+
+// Gzip mengompres respons HTML dan JSON dengan gzip jika browser mendukungnya.
+// Respons yang sudah punya Content-Encoding (misal: cache halaman yang sudah
+// terkompres) dan tipe lain seperti ekspor CSV yang di-stream dikirim apa adanya
+func Gzip() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		w := &gzipWriter{ResponseWriter: c.Writer, accepts: AcceptsEncoding(c.Request, "gzip")}
+		c.Writer = w
+		defer w.close()
+		c.Next()
+	}
+}
+
+// AcceptsEncoding mengecek apakah header Accept-Encoding request menerima coding
+// (misal: gzip, br). Coding dengan q=0 dianggap ditolak
+func AcceptsEncoding(r *http.Request, coding string) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if !strings.EqualFold(name, coding) && name != "*" {
+			continue
+		}
+		if _, q, ok := strings.Cut(strings.TrimSpace(params), "q="); ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil && v == 0 {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// gzipWriter menunda keputusan kompresi sampai byte pertama ditulis,
+// karena handler gin baru mengisi Content-Type tepat sebelum menulis body
+type gzipWriter struct {
+	gin.ResponseWriter
+	accepts bool         // Browser menerima gzip
+	decided bool         // Keputusan kompresi sudah diambil
+	gz      *gzip.Writer // nil = respons dikirim tanpa kompresi
+}
+
+// decide memutuskan apakah respons dikompres berdasarkan status dan header-nya
+func (w *gzipWriter) decide() {
+	if w.decided {
+		return
+	}
+	w.decided = true
+
+	h := w.Header()
+	mediaType, _, _ := mime.ParseMediaType(h.Get("Content-Type"))
+	switch {
+	case !slices.Contains(gzipTypes, mediaType), h.Get("Content-Encoding") != "":
+		return
+	case w.Status() < http.StatusOK, w.Status() == http.StatusNoContent, w.Status() == http.StatusNotModified:
+		return
+	}
+	if !slices.ContainsFunc(h.Values("Vary"), func(v string) bool { return strings.EqualFold(v, "Accept-Encoding") }) {
+		h.Add("Vary", "Accept-Encoding")
+	}
+	if !w.accepts {
+		return
+	}
+
+	h.Set("Content-Encoding", "gzip")
+	h.Del("Content-Length")
+	w.gz = gzipPool.Get().(*gzip.Writer)
+	w.gz.Reset(w.ResponseWriter)
+}
+
+func (w *gzipWriter) Write(data []byte) (int, error) {
+	w.decide()
+	if w.gz == nil {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.gz.Write(data)
+}
+
+func (w *gzipWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// WriteHeaderNow dipanggil gin untuk respons tanpa body; header harus sudah final
+func (w *gzipWriter) WriteHeaderNow() {
+	w.decide()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *gzipWriter) Flush() {
+	if w.gz != nil {
+		w.gz.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// Unwrap memberi akses ke writer asli (untuk http.ResponseController)
+func (w *gzipWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// close menulis penutup stream gzip dan mengembalikan writer ke pool
+func (w *gzipWriter) close() {
+	if w.gz == nil {
+		return
+	}
+	w.gz.Close()
+	w.gz.Reset(io.Discard)
+	gzipPool.Put(w.gz)
+	w.gz = nil
+}
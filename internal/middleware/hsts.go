@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HSTS memasang header Strict-Transport-Security pada respons HTTPS agar browser
+// selalu memakai HTTPS untuk domain ini selama maxAge detik. Respons HTTP biasa
+// tidak diberi header (browser mengabaikannya dan bisa mengunci situs tanpa sertifikat)
+func HSTS(maxAge int) gin.HandlerFunc {
+	value := "max-age=" + strconv.Itoa(maxAge)
+	return func(c *gin.Context) {
+		if c.Request.TLS != nil {
+			c.Header("Strict-Transport-Security", value)
+		}
+		c.Next()
+	}
+}
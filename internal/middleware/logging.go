@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"portofolio-go/internal/logging"
+	"regexp"
+	"runtime/debug"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader adalah header request/respons pembawa request ID
+const RequestIDHeader = "X-Request-ID"
+
+// validRequestID membatasi request ID dari luar (misal: dari reverse proxy)
+// agar tidak bisa menyisipkan teks sembarang ke log
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,64}$`)
+
+// RequestID memberi setiap request sebuah ID: diambil dari header X-Request-ID jika
+// reverse proxy sudah mengisinya, selain itu dibuat acak. ID dikirim balik di header
+// respons dan ditempel ke context request, sehingga ikut di semua log handler,
+// service, dan repository yang memakai context tersebut
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = newRequestID()
+		}
+		c.Header(RequestIDHeader, id)
+		c.Request = c.Request.WithContext(logging.WithRequestID(c.Request.Context(), id))
+		c.Next()
+	}
+}
+
+// newRequestID membuat request ID acak 128-bit (hex)
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// AccessLog mencatat satu baris log per request (menggantikan logger bawaan Gin).
+// Query string tidak dicatat karena bisa berisi token (misal: link preview draft).
+// Error yang dicatat handler lewat c.Error ikut ditulis; request dengan error atau
+// status 5xx dicatat di level warn/error agar mudah disaring. Request sukses ke quietPaths
+// (misal: /healthz yang di-probe tiap beberapa detik) hanya dicatat di level debug
+func AccessLog(quietPaths ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.String("route", c.FullPath()),
+			slog.Int("status", status),
+			slog.Int("bytes", max(c.Writer.Size(), 0)),
+			slog.Duration("duration", time.Since(start)),
+			slog.String("client_ip", c.ClientIP()),
+		}
+
+		level := slog.LevelInfo
+		if status < 400 && slices.Contains(quietPaths, c.Request.URL.Path) {
+			level = slog.LevelDebug
+		}
+		if len(c.Errors) > 0 {
+			level = slog.LevelWarn
+			attrs = append(attrs, slog.Any("errors", c.Errors.Errors()))
+		}
+		if status >= 500 {
+			level = slog.LevelError
+		}
+		slog.LogAttrs(c.Request.Context(), level, "Request", attrs...)
+	}
+}
+
+// Recovery menangkap panic di handler, mencatatnya beserta stack trace lewat slog,
+// lalu menjawab 500 (menggantikan gin.Recovery yang menulis langsung ke stderr)
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, err any) {
+		slog.ErrorContext(c.Request.Context(), "Panic saat menangani request",
+			"panic", err, "path", c.Request.URL.Path, "stack", string(debug.Stack()))
+		c.AbortWithStatus(http.StatusInternalServerError)
+	})
+}
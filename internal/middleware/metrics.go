@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/http"
+	"portofolio-go/internal/metrics"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	httpRequests = metrics.NewCounterVec("http_requests_total",
+		"Jumlah request HTTP per route, method, dan status.", "method", "route", "status")
+	httpDuration = metrics.NewHistogramVec("http_request_duration_seconds",
+		"Durasi penanganan request HTTP per route dan method.", metrics.DefBuckets, "method", "route")
+)
+
+// Metrics mencatat jumlah request dan durasinya per route. Label route memakai pola
+// rute Gin (misal: /admin/message/:id/read), bukan path asli, agar jumlah seri tetap kecil;
+// request ke path yang tidak terdaftar digabung sebagai "unmatched"
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		httpRequests.Inc(c.Request.Method, route, strconv.Itoa(c.Writer.Status()))
+		httpDuration.ObserveSince(start, c.Request.Method, route)
+	}
+}
+
+// MetricsAccess mengatur siapa yang boleh membaca /metrics.
+// Jika keduanya kosong, endpoint terbuka untuk siapa saja
+type MetricsAccess struct {
+	Username   string   // Username basic auth (kosong = tanpa basic auth)
+	Password   string   // Password basic auth
+	AllowedIPs []string // IP atau CIDR yang boleh scrape (kosong = semua IP)
+}
+
+// MetricsGuard membatasi akses /metrics dengan IP allowlist dan/atau basic auth.
+// Allowlist dicek terhadap alamat koneksi langsung, bukan X-Forwarded-For yang bisa
+// dipalsukan; jika scraper lewat reverse proxy, pakai basic auth. Jika keduanya diset,
+// request harus lolos keduanya
+func MetricsGuard(access MetricsAccess) (gin.HandlerFunc, error) {
+	var networks []*net.IPNet
+	for _, entry := range access.AllowedIPs {
+		cidr := entry
+		if !strings.Contains(cidr, "/") {
+			if ip := net.ParseIP(cidr); ip != nil && ip.To4() != nil {
+				cidr += "/32"
+			} else {
+				cidr += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("METRICS_ALLOWED_IPS berisi alamat tidak valid: %q", entry)
+		}
+		networks = append(networks, network)
+	}
+
+	return func(c *gin.Context) {
+		if len(networks) > 0 && !ipAllowed(c.RemoteIP(), networks) {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+		if access.Username != "" {
+			user, pass, ok := c.Request.BasicAuth()
+			if !ok ||
+				subtle.ConstantTimeCompare([]byte(user), []byte(access.Username)) != 1 ||
+				subtle.ConstantTimeCompare([]byte(pass), []byte(access.Password)) != 1 {
+				c.Header("WWW-Authenticate", `Basic realm="metrics"`)
+				c.AbortWithStatus(http.StatusUnauthorized)
+				return
+			}
+		}
+		c.Next()
+	}, nil
+}
+
+// ipAllowed mengecek apakah ip termasuk salah satu jaringan allowlist
+func ipAllowed(ip string, networks []*net.IPNet) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, network := range networks {
+		if network.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Durasi berlaku link preview yang dibuat dari dashboard
+const PreviewTokenDuration = 24 * time.Hour
+
+// SignPreviewToken membuat token preview yang berlaku sampai expires
+// Format token: "<unix-expiry>.<hex HMAC-SHA256>", ditandatangani dengan secret
+func SignPreviewToken(secret string, expires time.Time) string {
+	exp := strconv.FormatInt(expires.Unix(), 10)
+	return exp + "." + signature(secret, "preview:"+exp)
+}
+
+// VerifyPreviewToken memeriksa tanda tangan dan masa berlaku token preview
+func VerifyPreviewToken(secret, token string, now time.Time) bool {
+	exp, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return false
+	}
+	unix, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil || now.After(time.Unix(unix, 0)) {
+		return false
+	}
+	// Bandingkan dengan waktu konstan untuk mencegah timing attack
+	return hmac.Equal([]byte(sig), []byte(signature(secret, "preview:"+exp)))
+}
+
+// signature menghitung HMAC-SHA256 (hex) untuk payload token bertanda tangan
+// Payload diawali tujuan token (misal: "preview:") agar token satu fitur
+// tidak bisa dipakai di fitur lain
+func signature(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"portofolio-go/internal/i18n"
+	"portofolio-go/internal/metrics"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// rateLimited menghitung request yang ditolak rate limiter per route
+var rateLimited = metrics.NewCounterVec("rate_limit_rejections_total",
+	"Jumlah request yang ditolak rate limiter per route.", "route")
+
+// RateLimiter membatasi jumlah request per IP dengan algoritma token bucket.
+// Setiap IP punya "ember" berisi maksimal limit token yang terisi ulang
+// secara merata selama per; setiap request mengambil satu token
+type RateLimiter struct {
+	limit     float64            // Kapasitas ember (jumlah request beruntun yang diizinkan)
+	rate      float64            // Token yang terisi per detik
+	buckets   map[string]*bucket // Ember per IP
+	lastSweep time.Time          // Waktu terakhir ember penuh dibersihkan
+	mu        sync.Mutex         // Mutex untuk thread-safety
+}
+
+// bucket menyimpan sisa token satu IP
+type bucket struct {
+	tokens float64   // Sisa token
+	last   time.Time // Waktu terakhir token dihitung ulang
+}
+
+// NewRateLimiter membuat rate limiter yang mengizinkan limit request per durasi per
+func NewRateLimiter(limit int, per time.Duration) *RateLimiter {
+	return &RateLimiter{
+		limit:   float64(limit),
+		rate:    float64(limit) / per.Seconds(),
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Allow mengambil satu token untuk key (biasanya IP)
+// Jika ember kosong, mengembalikan false beserta waktu tunggu sampai token berikutnya
+func (l *RateLimiter) Allow(key string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.limit, last: now}
+		l.buckets[key] = b
+	}
+
+	// Isi ulang token sesuai waktu yang berlalu sejak request terakhir
+	b.tokens = math.Min(l.limit, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+		return false, wait
+	}
+	b.tokens--
+	return true, 0
+}
+
+// sweep menghapus ember yang sudah terisi penuh agar map tidak tumbuh terus
+// Dijalankan paling sering sekali per menit
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.limit {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// Middleware mengembalikan middleware Gin yang menolak request berlebih dengan 429
+// Respons berupa JSON dengan format yang sama seperti API kontak
+func (l *RateLimiter) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Kunci bucket adalah alamat koneksi; X-Forwarded-For hanya dipakai jika koneksi datang
+		// dari TRUSTED_PROXIES (lihat SetTrustedProxies di main), sehingga header palsu tidak
+		// bisa dipakai untuk mendapat bucket baru di setiap request
+		ok, wait := l.Allow(c.ClientIP(), time.Now())
+		if !ok {
+			rateLimited.Inc(c.FullPath())
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"success": false,
+				"message": i18n.T(i18n.Negotiate(c.GetHeader("Accept-Language")), "contact.rate_limited"),
+			})
+			return
+		}
+		c.Next()
+	}
+}
@@ -1,56 +1,348 @@
 package model
 
-import "time"
+import (
+	"slices"
+	"strings"
+	"time"
+)
 
 // Experience merepresentasikan pengalaman kerja
 // Data ini ditampilkan di halaman Experience dalam format timeline
 type Experience struct {
-	ID          int       `json:"id"`
-	Company     string    `json:"company"`     // Nama perusahaan
-	Role        string    `json:"role"`        // Posisi/jabatan
-	Period      string    `json:"period"`      // Periode kerja
-	Description string    `json:"description"` // Deskripsi narasi pengalaman
-	SortOrder   int       `json:"sort_order"`  // Urutan tampil
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID           int        `json:"id"`
+	Company      string     `json:"company"`                 // Nama perusahaan
+	Role         string     `json:"role"`                    // Posisi/jabatan
+	StartDate    *time.Time `json:"start_date"`              // Bulan mulai (nil jika periode lama gagal di-parse)
+	EndDate      *time.Time `json:"end_date"`                // Bulan selesai (nil jika masih bekerja)
+	IsCurrent    bool       `json:"is_current"`              // Masih bekerja di sini
+	LegacyPeriod string     `json:"legacy_period,omitempty"` // Teks periode lama yang gagal di-parse (kosong setelah tanggal diisi)
+	Description  string     `json:"description"`             // Deskripsi narasi pengalaman
+	SortOrder    int        `json:"sort_order"`              // Urutan tampil
+	Published    bool       `json:"published"`               // Tampil di halaman publik (false = draft/terjadwal)
+	PublishAt    *time.Time `json:"publish_at"`              // Jadwal terbit otomatis (nil = tidak dijadwalkan)
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// PublishState mengembalikan status tayang experience (published/scheduled/draft)
+func (e Experience) PublishState() string {
+	return publishState(e.Published, e.PublishAt)
 }
 
+// Status tayang konten di dashboard (lihat kolom published & publish_at)
+const (
+	PublishStatePublished = "published" // Tampil di halaman publik
+	PublishStateScheduled = "scheduled" // Draft yang akan terbit otomatis di publish_at
+	PublishStateDraft     = "draft"     // Hanya terlihat di dashboard dan preview
+)
+
+// publishState menurunkan status tayang dari kolom published dan publish_at
+func publishState(published bool, publishAt *time.Time) string {
+	switch {
+	case published:
+		return PublishStatePublished
+	case publishAt != nil:
+		return PublishStateScheduled
+	default:
+		return PublishStateDraft
+	}
+}
+
+// Status proyek yang valid (lihat kolom projects.status)
+const (
+	ProjectStatusActive   = "active"   // Proyek masih berjalan/dipelihara
+	ProjectStatusArchived = "archived" // Proyek sudah selesai/tidak dipelihara
+)
+
 // Project merepresentasikan proyek portofolio
 // Ditampilkan dengan konteks bisnis dan dampak, bukan sekadar daftar fitur
 type Project struct {
-	ID          int       `json:"id"`
-	Title       string    `json:"title"`       // Judul proyek
-	Description string    `json:"description"` // Deskripsi dengan konteks bisnis & impact
-	TechUsed    string    `json:"tech_used"`   // Teknologi yang digunakan (comma-separated)
-	Link        string    `json:"link"`        // Link ke demo/repo
-	GithubURL   string    `json:"github_url"`  // Link ke repository GitHub
-	ImageURL    string    `json:"image_url"`   // URL gambar proyek
-	SortOrder   int       `json:"sort_order"`  // Urutan tampil
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          int        `json:"id"`
+	Title       string     `json:"title"`       // Judul proyek
+	Description string     `json:"description"` // Deskripsi dengan konteks bisnis & impact
+	Tags        []Tag      `json:"tags"`        // Teknologi yang digunakan, sesuai urutan input
+	Role        string     `json:"role"`        // Peran pemilik portofolio di proyek
+	Status      string     `json:"status"`      // Status proyek (active/archived)
+	StartDate   *time.Time `json:"start_date"`  // Tanggal mulai (opsional)
+	EndDate     *time.Time `json:"end_date"`    // Tanggal selesai (nil = masih berjalan)
+	Link        string     `json:"link"`        // Link ke demo/repo
+	GithubURL   string     `json:"github_url"`  // Link ke repository GitHub
+	ImageURL    string     `json:"image_url"`   // URL gambar proyek
+	SortOrder   int        `json:"sort_order"`  // Urutan tampil
+	Published   bool       `json:"published"`   // Tampil di halaman publik (false = draft/terjadwal)
+	PublishAt   *time.Time `json:"publish_at"`  // Jadwal terbit otomatis (nil = tidak dijadwalkan)
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// PublishState mengembalikan status tayang proyek (published/scheduled/draft)
+func (p Project) PublishState() string {
+	return publishState(p.Published, p.PublishAt)
+}
+
+// TagNames menggabungkan nama tag proyek menjadi string comma-separated
+// Digunakan untuk mengisi input tag di form edit dashboard
+func (p Project) TagNames() string {
+	names := make([]string, len(p.Tags))
+	for i, tag := range p.Tags {
+		names[i] = tag.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// Tag merepresentasikan satu teknologi yang dipakai di proyek
+// Relasi proyek <-> tag disimpan di tabel project_tags
+type Tag struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`          // Nama tag (unik, case-insensitive)
+	TechStackID *int   `json:"tech_stack_id"` // Entri tech stack terkait (nil = belum dihubungkan)
 }
 
 // TechStack merepresentasikan teknologi yang dikuasai
 // Dideskripsikan dalam konteks penggunaan, BUKAN level persentase
 type TechStack struct {
-	ID          int       `json:"id"`
-	Category    string    `json:"category"`    // Kategori (Backend, Frontend, DevOps, dll)
-	Name        string    `json:"name"`        // Nama teknologi
-	Description string    `json:"description"` // Konteks penggunaan
-	SortOrder   int       `json:"sort_order"`  // Urutan tampil
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          int        `json:"id"`
+	Category    string     `json:"category"`    // Kategori (Backend, Frontend, DevOps, dll)
+	Name        string     `json:"name"`        // Nama teknologi
+	Description string     `json:"description"` // Konteks penggunaan
+	SortOrder   int        `json:"sort_order"`  // Urutan tampil
+	Published   bool       `json:"published"`   // Tampil di halaman publik (false = draft/terjadwal)
+	PublishAt   *time.Time `json:"publish_at"`  // Jadwal terbit otomatis (nil = tidak dijadwalkan)
+	Tags        []Tag      `json:"tags"`        // Tag proyek yang terhubung ke entri ini
+	Projects    []Project  `json:"projects"`    // Proyek yang memakai entri ini (hanya ID & Title)
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// PublishState mengembalikan status tayang tech stack (published/scheduled/draft)
+func (ts TechStack) PublishState() string {
+	return publishState(ts.Published, ts.PublishAt)
+}
+
+// TagNames menggabungkan nama tag yang terhubung menjadi string comma-separated
+func (ts TechStack) TagNames() string {
+	names := make([]string, len(ts.Tags))
+	for i, tag := range ts.Tags {
+		names[i] = tag.Name
+	}
+	return strings.Join(names, ", ")
 }
 
 // ContactMessage merepresentasikan pesan dari pengunjung
 // melalui form kontak di website
 type ContactMessage struct {
+	ID          int            `json:"id"`
+	Name        string         `json:"name"`         // Nama pengirim
+	Email       string         `json:"email"`        // Email pengirim
+	Message     string         `json:"message"`      // Isi pesan
+	Status      string         `json:"status"`       // Status: new, read, replied, archived
+	Locale      string         `json:"locale"`       // Bahasa halaman saat pesan dikirim
+	IsSpam      bool           `json:"is_spam"`      // Masuk folder spam
+	SpamScore   int            `json:"spam_score"`   // Skor heuristik spam saat pesan diterima
+	SpamReasons string         `json:"spam_reasons"` // Alasan skor spam (untuk ditampilkan di dashboard)
+	Consent     string         `json:"consent"`      // Versi kebijakan privasi yang disetujui pengirim
+	CreatedAt   time.Time      `json:"created_at"`
+	Replies     []MessageReply `json:"replies,omitempty"` // Thread balasan admin (diisi service)
+}
+
+// Status pesan kontak di kotak masuk
+const (
+	MessageNew      = "new"      // Belum dibaca
+	MessageRead     = "read"     // Sudah dibaca
+	MessageReplied  = "replied"  // Sudah dibalas dari dashboard
+	MessageArchived = "archived" // Diarsipkan, disembunyikan dari kotak masuk
+)
+
+// MessageStatuses adalah semua status pesan, urut untuk filter di dashboard
+var MessageStatuses = []string{MessageNew, MessageRead, MessageReplied, MessageArchived}
+
+// MessageAll adalah nilai filter status untuk semua pesan, termasuk yang diarsipkan
+const MessageAll = "all"
+
+// MessageFilter adalah kriteria pencarian pesan di kotak masuk dashboard
+type MessageFilter struct {
+	Query   string     // Kata kunci full-text (nama, email, isi pesan)
+	IDs     []int      // Hanya pesan dengan ID ini (kosong = tanpa batasan ID)
+	Status  string     // Status pesan; kosong = semua kecuali arsip, MessageAll = semua
+	From    *time.Time // Tanggal awal (inklusif, nil = tanpa batas)
+	To      *time.Time // Tanggal akhir (inklusif, nil = tanpa batas)
+	Page    int        // Halaman (mulai dari 1)
+	PerPage int        // Jumlah pesan per halaman
+}
+
+// MessagePage adalah satu halaman hasil pencarian pesan kontak
+type MessagePage struct {
+	Messages   []ContactMessage
+	Total      int // Jumlah seluruh pesan yang cocok
+	Page       int // Halaman saat ini
+	TotalPages int // Jumlah halaman (minimal 1)
+}
+
+// HasPrev mengecek apakah ada halaman sebelumnya
+func (p MessagePage) HasPrev() bool { return p.Page > 1 }
+
+// HasNext mengecek apakah ada halaman berikutnya
+func (p MessagePage) HasNext() bool { return p.Page < p.TotalPages }
+
+// MessageReply merepresentasikan satu balasan admin untuk pesan kontak
+type MessageReply struct {
+	ID        int        `json:"id"`
+	MessageID int        `json:"message_id"`
+	Body      string     `json:"body"`       // Isi balasan
+	EmailID   *int       `json:"email_id"`   // Email di antrean kirim (nil jika sudah dihapus)
+	SentAt    *time.Time `json:"sent_at"`    // Waktu email terkirim (nil = belum)
+	FailedAt  *time.Time `json:"failed_at"`  // Waktu pengiriman menyerah (nil = belum)
+	LastError string     `json:"last_error"` // Error pengiriman terakhir
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// DeliveryStatus mengembalikan status email balasan: sent, failed, atau pending
+func (r MessageReply) DeliveryStatus() string {
+	switch {
+	case r.SentAt != nil:
+		return "sent"
+	case r.FailedAt != nil:
+		return "failed"
+	default:
+		return "pending"
+	}
+}
+
+// QueuedEmail merepresentasikan email di antrean kirim (tabel email_queue)
+// Email yang gagal dikirim dicoba ulang sampai batas percobaan tercapai
+type QueuedEmail struct {
+	ID            int        `json:"id"`
+	To            string     `json:"to"`              // Alamat penerima
+	ReplyTo       string     `json:"reply_to"`        // Alamat Reply-To (opsional)
+	Subject       string     `json:"subject"`         // Subjek email
+	Text          string     `json:"text"`            // Isi versi teks biasa
+	HTML          string     `json:"html"`            // Isi versi HTML
+	Attempts      int        `json:"attempts"`        // Jumlah percobaan kirim
+	LastError     string     `json:"last_error"`      // Error percobaan terakhir
+	NextAttemptAt time.Time  `json:"next_attempt_at"` // Jadwal percobaan berikutnya
+	SentAt        *time.Time `json:"sent_at"`         // Waktu terkirim (nil = belum)
+	FailedAt      *time.Time `json:"failed_at"`       // Waktu menyerah (nil = masih dicoba)
+	MessageID     *int       `json:"message_id"`      // Pesan kontak asal email (nil = bukan email pesan)
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// Event yang bisa dilanggan webhook (format "<entity>.<aksi>")
+const (
+	EventMessageCreated    = "message.created"
+	EventExperienceCreated = "experience.created"
+	EventExperienceUpdated = "experience.updated"
+	EventExperienceDeleted = "experience.deleted"
+	EventProjectCreated    = "project.created"
+	EventProjectUpdated    = "project.updated"
+	EventProjectDeleted    = "project.deleted"
+	EventTechStackCreated  = "techstack.created"
+	EventTechStackUpdated  = "techstack.updated"
+	EventTechStackDeleted  = "techstack.deleted"
+	EventConfigUpdated     = "config.updated"
+)
+
+// WebhookEvents adalah semua event webhook, urut untuk pilihan di dashboard
+var WebhookEvents = []string{
+	EventMessageCreated,
+	EventExperienceCreated, EventExperienceUpdated, EventExperienceDeleted,
+	EventProjectCreated, EventProjectUpdated, EventProjectDeleted,
+	EventTechStackCreated, EventTechStackUpdated, EventTechStackDeleted,
+	EventConfigUpdated,
+}
+
+// Webhook merepresentasikan satu endpoint webhook keluar yang diatur dari dashboard
+type Webhook struct {
 	ID        int       `json:"id"`
-	Name      string    `json:"name"`    // Nama pengirim
-	Email     string    `json:"email"`   // Email pengirim
-	Message   string    `json:"message"` // Isi pesan
-	IsRead    bool      `json:"is_read"` // Status sudah dibaca atau belum
+	Name      string    `json:"name"`    // Nama untuk ditampilkan di dashboard
+	URL       string    `json:"url"`     // Endpoint tujuan
+	Secret    string    `json:"-"`       // Kunci HMAC-SHA256 untuk header signature
+	Events    []string  `json:"events"`  // Event yang dilanggan
+	Format    string    `json:"format"`  // Format payload: json, slack, discord, telegram
+	ChatID    string    `json:"chat_id"` // Chat ID tujuan (khusus telegram)
+	Active    bool      `json:"active"`  // false = dijeda
 	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Subscribed mengecek apakah webhook melanggan event tertentu
+func (w Webhook) Subscribed(event string) bool {
+	return slices.Contains(w.Events, event)
+}
+
+// WebhookDelivery merepresentasikan satu pengiriman event ke webhook (log delivery)
+type WebhookDelivery struct {
+	ID            int        `json:"id"`
+	WebhookID     int        `json:"webhook_id"`
+	WebhookName   string     `json:"webhook_name"`    // Nama webhook (untuk log di dashboard)
+	Event         string     `json:"event"`           // Nama event
+	Payload       string     `json:"payload"`         // Body JSON yang dikirim
+	Attempts      int        `json:"attempts"`        // Jumlah percobaan kirim
+	StatusCode    int        `json:"status_code"`     // Kode respons HTTP terakhir (0 = tidak ada respons)
+	Response      string     `json:"response"`        // Potongan body respons terakhir
+	LastError     string     `json:"last_error"`      // Error percobaan terakhir
+	NextAttemptAt time.Time  `json:"next_attempt_at"` // Jadwal percobaan berikutnya
+	DeliveredAt   *time.Time `json:"delivered_at"`    // Waktu berhasil terkirim (nil = belum)
+	FailedAt      *time.Time `json:"failed_at"`       // Waktu menyerah (nil = masih dicoba)
+	MessageID     *int       `json:"message_id"`      // Pesan kontak yang memicu event (nil = event lain)
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// Status mengembalikan status delivery untuk ditampilkan: delivered, failed, atau pending
+func (d WebhookDelivery) Status() string {
+	switch {
+	case d.DeliveredAt != nil:
+		return "delivered"
+	case d.FailedAt != nil:
+		return "failed"
+	default:
+		return "pending"
+	}
+}
+
+// Jenis permintaan privasi di log privacy_requests
+const (
+	PrivacyErase     = "erase"     // Semua data pengunjung dihapus permanen
+	PrivacyAnonymize = "anonymize" // Data pribadi diganti placeholder, statistik pesan tetap ada
+	PrivacyRetention = "retention" // Purge otomatis pesan yang melewati masa retensi
+)
+
+// Anonymized adalah pengganti nama, isi pesan, dan balasan yang dianonimkan
+const Anonymized = "[dihapus]"
+
+// PrivacyRequest merepresentasikan satu entri log permintaan privasi beserta
+// jumlah data yang terdampak. Email pemohon hanya disimpan sebagai hash dan versi tersamar
+type PrivacyRequest struct {
+	ID          int       `json:"id"`
+	Kind        string    `json:"kind"`         // erase, anonymize, atau retention
+	SubjectHash string    `json:"subject_hash"` // HMAC-SHA256 email pemohon (kosong untuk retention)
+	SubjectHint string    `json:"subject_hint"` // Email tersamar (misal: b***@gmail.com)
+	Note        string    `json:"note"`         // Catatan admin
+	Messages    int       `json:"messages"`     // Pesan yang dihapus/dianonimkan
+	Replies     int       `json:"replies"`      // Balasan yang dihapus/dianonimkan
+	Emails      int       `json:"emails"`       // Email antrean yang dihapus
+	Deliveries  int       `json:"deliveries"`   // Log delivery webhook yang dihapus
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Affected mengecek apakah permintaan mengenai data apa pun
+func (p PrivacyRequest) Affected() bool {
+	return p.Messages+p.Replies+p.Emails+p.Deliveries > 0
+}
+
+// CSPReport merepresentasikan laporan pelanggaran Content Security Policy dari browser.
+// Laporan dengan halaman, directive, resource, dan lokasi sumber yang sama digabung
+type CSPReport struct {
+	ID          int       `json:"id"`
+	DocumentURI string    `json:"document_uri"` // Halaman tempat pelanggaran (tanpa query string)
+	Directive   string    `json:"directive"`    // Directive yang dilanggar
+	BlockedURI  string    `json:"blocked_uri"`  // Resource yang diblokir (atau inline/eval)
+	SourceFile  string    `json:"source_file"`  // File sumber pemicu pelanggaran
+	LineNumber  int       `json:"line_number"`  // Baris di SourceFile
+	Sample      string    `json:"sample"`       // Potongan kode yang diblokir
+	Disposition string    `json:"disposition"`  // enforce atau report
+	Count       int       `json:"count"`        // Jumlah laporan yang sama
+	FirstSeen   time.Time `json:"first_seen"`
+	LastSeen    time.Time `json:"last_seen"`
 }
 
 // SiteConfig merepresentasikan konfigurasi situs (key-value)
@@ -64,17 +356,25 @@ type SiteConfig struct {
 // PortfolioData adalah kumpulan semua data yang dibutuhkan
 // untuk merender halaman utama portofolio
 type PortfolioData struct {
-	Config      map[string]string // Konfigurasi situs (key-value)
-	Experiences []Experience      // Daftar pengalaman kerja
-	Projects    []Project         // Daftar proyek
-	TechStacks  []TechStack       // Daftar tech stack per kategori
+	Config           map[string]string // Konfigurasi situs (key-value)
+	Experiences      []Experience      // Daftar pengalaman kerja
+	ExperienceMonths int               // Total masa kerja dalam bulan (periode tumpang tindih dihitung sekali)
+	Projects         []Project         // Daftar proyek
+	TechStacks       []TechStack       // Daftar tech stack per kategori
 }
 
 // ContactForm adalah struct untuk validasi input form kontak
 type ContactForm struct {
-	Name    string `json:"name" form:"name" binding:"required,min=2,max=100"`
-	Email   string `json:"email" form:"email" binding:"required,email"`
-	Message string `json:"message" form:"message" binding:"required,min=10,max=2000"`
+	Name      string `json:"name" form:"name" binding:"required,min=2,max=100"`
+	Email     string `json:"email" form:"email" binding:"required,email"`
+	Message   string `json:"message" form:"message" binding:"required,min=10,max=2000"`
+	Website   string `json:"website" form:"website"`       // Honeypot: field tersembunyi, hanya bot yang mengisinya
+	FormToken string `json:"form_token" form:"form_token"` // Token bertanda tangan berisi waktu form dirender
+	Captcha   string `json:"captcha" form:"captcha"`       // Jawaban CAPTCHA (jika CAPTCHA_PROVIDER aktif)
+	Locale    string `json:"-" form:"-"`                   // Bahasa halaman pengirim (diisi handler, untuk balasan otomatis)
+
+	// Versi kebijakan privasi yang dicentang pengunjung (nilai checkbox persetujuan)
+	ConsentVersion string `json:"consent_version" form:"consent_version" binding:"required,max=32"`
 }
 
 // AdminLoginForm adalah struct untuk validasi input login admin
@@ -82,3 +382,109 @@ type AdminLoginForm struct {
 	Username string `json:"username" form:"username" binding:"required"`
 	Password string `json:"password" form:"password" binding:"required"`
 }
+
+// ReorderForm adalah struct untuk request urutan baru dari drag-and-drop dashboard
+// IDs berisi ID item dalam urutan tampil yang diinginkan
+type ReorderForm struct {
+	IDs []int `json:"ids" form:"ids" binding:"required,min=1"`
+}
+
+// Jenis konten yang bisa masuk sampah (dipakai juga di URL dashboard)
+const (
+	TrashExperience = "experience"
+	TrashProject    = "project"
+	TrashTechStack  = "techstack"
+	TrashMessage    = "message"
+)
+
+// TrashEntities adalah daftar semua jenis konten yang mendukung soft delete
+var TrashEntities = []string{TrashExperience, TrashProject, TrashTechStack, TrashMessage}
+
+// TrashItem merepresentasikan satu konten yang sudah dipindahkan ke sampah
+// Ditampilkan di tab Sampah dashboard untuk dipulihkan atau dihapus permanen
+type TrashItem struct {
+	Entity    string    `json:"entity"`     // Jenis konten (experience/project/techstack/message)
+	ID        int       `json:"id"`         // ID di tabel asal
+	Title     string    `json:"title"`      // Judul ringkas untuk ditampilkan
+	DeletedAt time.Time `json:"deleted_at"` // Waktu dipindahkan ke sampah
+}
+
+// Jenis konten yang menyimpan riwayat revisi (dipakai juga di URL dashboard)
+const (
+	RevisionExperience = "experience"
+	RevisionProject    = "project"
+	RevisionTechStack  = "techstack"
+	RevisionConfig     = "config" // entity_key berisi key site_config
+)
+
+// Revision merepresentasikan snapshot satu konten sebelum diupdate
+type Revision struct {
+	ID        int           `json:"id"`
+	Entity    string        `json:"entity"`     // Jenis konten (experience/project/techstack/config)
+	EntityKey string        `json:"entity_key"` // ID konten, atau key untuk site_config
+	Snapshot  string        `json:"snapshot"`   // Isi konten sebelum diupdate (JSON)
+	CreatedAt time.Time     `json:"created_at"`
+	Changes   []FieldChange `json:"changes"` // Perbedaan dengan versi sesudahnya (diisi service)
+}
+
+// FieldChange merepresentasikan perubahan satu field antara dua versi konten
+type FieldChange struct {
+	Field string `json:"field"` // Label field yang ditampilkan
+	Old   string `json:"old"`   // Nilai di revisi ini
+	New   string `json:"new"`   // Nilai di versi sesudahnya
+}
+
+// RevisionHistory adalah data panel riwayat satu konten di dashboard
+type RevisionHistory struct {
+	Entity    string     // Jenis konten
+	Key       string     // ID konten atau key site_config
+	Title     string     // Judul ringkas konten saat ini
+	Revisions []Revision // Daftar revisi, terbaru duluan
+}
+
+// TranslatableFields memetakan jenis konten (sama dengan jenis revisi) ke field
+// yang bisa diterjemahkan, sesuai urutan tampil di editor terjemahan.
+// Untuk site_config, field selalu "value" dan hanya key di TranslatableConfigKeys
+var TranslatableFields = map[string][]string{
+	RevisionExperience: {"company", "role", "description"},
+	RevisionProject:    {"title", "role", "description"},
+	RevisionTechStack:  {"category", "name", "description"},
+	RevisionConfig:     {"value"},
+}
+
+// TranslatableConfigKeys adalah key site_config yang berisi teks (bukan URL/email)
+var TranslatableConfigKeys = []string{"name", "tagline", "about"}
+
+// Translations menyimpan teks terjemahan satu locale
+// Key map dibentuk dengan TranslationKey
+type Translations map[string]string
+
+// TranslationKey membentuk key map Translations untuk satu field konten
+func TranslationKey(entity, key, field string) string {
+	return entity + "/" + key + "/" + field
+}
+
+// Get mengambil terjemahan satu field, atau fallback jika belum diterjemahkan
+func (t Translations) Get(entity, key, field, fallback string) string {
+	if value, ok := t[TranslationKey(entity, key, field)]; ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+// TranslationField adalah satu baris di editor terjemahan dashboard
+type TranslationField struct {
+	Field  string // Nama field (misal: description)
+	Label  string // Label field yang ditampilkan di editor
+	Source string // Teks asli dalam bahasa Indonesia
+	Value  string // Teks terjemahan (kosong = belum diterjemahkan)
+}
+
+// TranslationEntry adalah satu konten beserta field-field terjemahannya
+type TranslationEntry struct {
+	Entity  string             // Jenis konten
+	Key     string             // ID konten atau key site_config
+	Title   string             // Judul ringkas konten
+	Fields  []TranslationField // Field yang bisa diterjemahkan
+	Missing int                // Jumlah field yang belum diterjemahkan
+}
@@ -0,0 +1,66 @@
+// Package notify mengirim notifikasi email (pesan kontak baru, balasan otomatis)
+// Email dirender dari template di web/templates/email dan dikirim lewat Sender
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	htmltemplate "html/template"
+	"path/filepath"
+	"portofolio-go/internal/i18n"
+	texttemplate "text/template"
+)
+
+// Message adalah satu email yang siap dikirim
+type Message struct {
+	To      string // Alamat penerima
+	ReplyTo string // Alamat untuk membalas (opsional)
+	Subject string // Subjek email
+	Text    string // Isi versi teks biasa
+	HTML    string // Isi versi HTML
+}
+
+// Sender mengirim email ke server mail
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Mailer menyusun email dari template lalu mengirimnya lewat Sender
+type Mailer struct {
+	sender Sender
+	html   *htmltemplate.Template
+	text   *texttemplate.Template
+}
+
+// NewMailer memuat template email dari dir (file *.html dan *.txt dengan nama sama)
+// Template bisa memakai fungsi t untuk teks dari katalog i18n
+func NewMailer(sender Sender, dir string) (*Mailer, error) {
+	funcs := map[string]any{"t": i18n.T}
+	html, err := htmltemplate.New("").Funcs(funcs).ParseGlob(filepath.Join(dir, "*.html"))
+	if err != nil {
+		return nil, fmt.Errorf("gagal memuat template email HTML: %w", err)
+	}
+	text, err := texttemplate.New("").Funcs(funcs).ParseGlob(filepath.Join(dir, "*.txt"))
+	if err != nil {
+		return nil, fmt.Errorf("gagal memuat template email teks: %w", err)
+	}
+	return &Mailer{sender: sender, html: html, text: text}, nil
+}
+
+// Compose merender template name (name.html & name.txt) menjadi isi email
+func (m *Mailer) Compose(name string, data any) (text, html string, err error) {
+	var textBuf, htmlBuf bytes.Buffer
+	if err := m.text.ExecuteTemplate(&textBuf, name+".txt", data); err != nil {
+		return "", "", fmt.Errorf("gagal render email %s.txt: %w", name, err)
+	}
+	if err := m.html.ExecuteTemplate(&htmlBuf, name+".html", data); err != nil {
+		return "", "", fmt.Errorf("gagal render email %s.html: %w", name, err)
+	}
+	return textBuf.String(), htmlBuf.String(), nil
+}
+
+// Send mengirim email lewat Sender
+func (m *Mailer) Send(ctx context.Context, msg Message) error {
+	return m.sender.Send(ctx, msg)
+}
@@ -0,0 +1,161 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Mode enkripsi koneksi SMTP (nilai SMTP_TLS)
+const (
+	TLSStartTLS = "starttls" // Koneksi biasa lalu upgrade dengan STARTTLS (umumnya port 587)
+	TLSImplicit = "tls"      // TLS sejak awal koneksi (umumnya port 465)
+	TLSNone     = "none"     // Tanpa enkripsi (hanya untuk server lokal/pengujian)
+)
+
+// SMTPSender mengirim email lewat server SMTP
+type SMTPSender struct {
+	Host     string        // Host server SMTP
+	Port     int           // Port server SMTP
+	Username string        // Username login (kosong = tanpa AUTH)
+	Password string        // Password login
+	From     string        // Alamat pengirim (misal: "Portofolio <noreply@example.com>")
+	TLS      string        // Mode enkripsi: starttls, tls, atau none
+	Timeout  time.Duration // Batas waktu satu pengiriman (default 30 detik)
+}
+
+// Send mengirim satu email. Error dikembalikan apa adanya agar antrean bisa mencoba ulang
+func (s *SMTPSender) Send(ctx context.Context, msg Message) error {
+	from, err := mail.ParseAddress(s.From)
+	if err != nil {
+		return fmt.Errorf("alamat pengirim tidak valid: %w", err)
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("alamat penerima tidak valid: %w", err)
+	}
+	body, err := buildMIME(from, to, msg)
+	if err != nil {
+		return err
+	}
+
+	timeout := s.Timeout
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
+	tlsConfig := &tls.Config{ServerName: s.Host}
+
+	// Buka koneksi (langsung TLS untuk mode implicit)
+	var conn net.Conn
+	dialer := &net.Dialer{}
+	if s.TLS == TLSImplicit {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("gagal terhubung ke SMTP %s: %w", addr, err)
+	}
+	deadline, _ := ctx.Deadline()
+	conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, s.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("gagal memulai sesi SMTP: %w", err)
+	}
+	defer client.Close()
+
+	if s.TLS == TLSStartTLS {
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("gagal STARTTLS: %w", err)
+		}
+	}
+	if s.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.Username, s.Password, s.Host)); err != nil {
+			return fmt.Errorf("gagal login SMTP: %w", err)
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return fmt.Errorf("SMTP MAIL FROM ditolak: %w", err)
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		return fmt.Errorf("SMTP RCPT TO ditolak: %w", err)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("SMTP DATA ditolak: %w", err)
+	}
+	if _, err := w.Write(body); err != nil {
+		return fmt.Errorf("gagal mengirim isi email: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("isi email ditolak server: %w", err)
+	}
+	return client.Quit()
+}
+
+// buildMIME menyusun email multipart/alternative (teks + HTML) dalam UTF-8
+func buildMIME(from, to *mail.Address, msg Message) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=UTF-8", msg.Text},
+		{"text/html; charset=UTF-8", msg.HTML},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("gagal menyusun email: %w", err)
+		}
+		qp := quotedprintable.NewWriter(w)
+		qp.Write([]byte(part.content))
+		qp.Close()
+	}
+	parts.Close()
+
+	id := make([]byte, 12)
+	rand.Read(id)
+	domain := from.Address[strings.LastIndex(from.Address, "@")+1:]
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from.String())
+	fmt.Fprintf(&buf, "To: %s\r\n", to.String())
+	if msg.ReplyTo != "" {
+		// Alamat dari form kontak disusun ulang agar nama berkarakter non-ASCII ter-encode
+		// dan CR/LF tidak bisa menyisipkan header lain
+		replyTo, err := mail.ParseAddress(msg.ReplyTo)
+		if err != nil {
+			return nil, fmt.Errorf("alamat Reply-To tidak valid: %w", err)
+		}
+		fmt.Fprintf(&buf, "Reply-To: %s\r\n", replyTo.String())
+	}
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "Message-ID: <%s@%s>\r\n", hex.EncodeToString(id), domain)
+	fmt.Fprintf(&buf, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", parts.Boundary())
+	buf.Write(body.Bytes())
+	return buf.Bytes(), nil
+}
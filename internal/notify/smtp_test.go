@@ -0,0 +1,220 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeSMTP adalah server SMTP minimal untuk pengujian (tanpa TLS dan AUTH).
+// Setiap sesi yang selesai dikirim ke channel sessions
+type fakeSMTP struct {
+	ln       net.Listener
+	sessions chan smtpSession
+}
+
+// smtpSession adalah rekaman satu sesi SMTP
+type smtpSession struct {
+	from, to string
+	data     string
+}
+
+func newFakeSMTP(t *testing.T) *fakeSMTP {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeSMTP{ln: ln, sessions: make(chan smtpSession, 1)}
+	t.Cleanup(func() { ln.Close() })
+	go s.serve()
+	return s
+}
+
+func (s *fakeSMTP) port() int {
+	return s.ln.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTP) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeSMTP) handle(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	var sess smtpSession
+	tp.PrintfLine("220 fake.test ESMTP")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(cmd) {
+		case "EHLO", "HELO":
+			tp.PrintfLine("250 fake.test")
+		case "MAIL":
+			sess.from = arg
+			tp.PrintfLine("250 OK")
+		case "RCPT":
+			sess.to = arg
+			tp.PrintfLine("250 OK")
+		case "DATA":
+			tp.PrintfLine("354 lanjut")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			sess.data = string(data)
+			tp.PrintfLine("250 OK")
+		case "QUIT":
+			tp.PrintfLine("221 bye")
+			s.sessions <- sess
+			return
+		default:
+			tp.PrintfLine("502 tidak didukung")
+		}
+	}
+}
+
+func TestSMTPSenderSend(t *testing.T) {
+	srv := newFakeSMTP(t)
+	sender := &SMTPSender{
+		Host:    "127.0.0.1",
+		Port:    srv.port(),
+		From:    "Portofolio <noreply@example.com>",
+		TLS:     TLSNone,
+		Timeout: 5 * time.Second,
+	}
+	msg := Message{
+		To:      "owner@example.com",
+		ReplyTo: "Siti Nurhaliza Ümit <siti@example.org>",
+		Subject: "Pesan baru dari Siti — portofolio",
+		Text:    "Halo,\nada pesan baru: café ☕",
+		HTML:    "<p>Halo, ada pesan baru: <b>café ☕</b></p>",
+	}
+	if err := sender.Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	var sess smtpSession
+	select {
+	case sess = <-srv.sessions:
+	case <-time.After(5 * time.Second):
+		t.Fatal("server SMTP tidak menerima sesi lengkap")
+	}
+	if sess.from != "FROM:<noreply@example.com>" {
+		t.Errorf("MAIL %s, want FROM:<noreply@example.com>", sess.from)
+	}
+	if sess.to != "TO:<owner@example.com>" {
+		t.Errorf("RCPT %s, want TO:<owner@example.com>", sess.to)
+	}
+
+	m, err := mail.ReadMessage(strings.NewReader(sess.data))
+	if err != nil {
+		t.Fatalf("email tidak bisa diparse: %v", err)
+	}
+	dec := new(mime.WordDecoder)
+	subject, err := dec.DecodeHeader(m.Header.Get("Subject"))
+	if err != nil || subject != msg.Subject {
+		t.Errorf("Subject = %q (%v), want %q", subject, err, msg.Subject)
+	}
+	replyTo, err := m.Header.AddressList("Reply-To")
+	if err != nil || len(replyTo) != 1 || replyTo[0].Name != "Siti Nurhaliza Ümit" || replyTo[0].Address != "siti@example.org" {
+		t.Errorf("Reply-To = %v (%v)", replyTo, err)
+	}
+	for _, h := range []string{"Date", "Message-ID", "MIME-Version"} {
+		if m.Header.Get(h) == "" {
+			t.Errorf("header %s kosong", h)
+		}
+	}
+	if id := m.Header.Get("Message-ID"); !strings.HasSuffix(id, "@example.com>") {
+		t.Errorf("Message-ID = %q, want domain pengirim", id)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(m.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q (%v)", m.Header.Get("Content-Type"), err)
+	}
+	mr := multipart.NewReader(m.Body, params["boundary"])
+	for _, want := range []struct{ contentType, body string }{
+		{"text/plain; charset=UTF-8", msg.Text},
+		{"text/html; charset=UTF-8", msg.HTML},
+	} {
+		part, err := mr.NextRawPart()
+		if err != nil {
+			t.Fatalf("bagian %s: %v", want.contentType, err)
+		}
+		if got := part.Header.Get("Content-Type"); got != want.contentType {
+			t.Errorf("Content-Type bagian = %q, want %q", got, want.contentType)
+		}
+		if got := part.Header.Get("Content-Transfer-Encoding"); got != "quoted-printable" {
+			t.Errorf("Content-Transfer-Encoding = %q", got)
+		}
+		body, err := io.ReadAll(quotedprintable.NewReader(part))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(body) != want.body {
+			t.Errorf("isi %s = %q, want %q", want.contentType, body, want.body)
+		}
+	}
+	if _, err := mr.NextPart(); err != io.EOF {
+		t.Errorf("bagian tambahan tidak diharapkan: %v", err)
+	}
+}
+
+func TestSMTPSenderConnectionError(t *testing.T) {
+	// Port yang baru ditutup: pengiriman harus gagal dengan error, bukan menggantung
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+
+	sender := &SMTPSender{Host: "127.0.0.1", Port: port, From: "noreply@example.com", TLS: TLSNone, Timeout: time.Second}
+	err = sender.Send(context.Background(), Message{To: "owner@example.com", Subject: "x"})
+	if err == nil || !strings.Contains(err.Error(), "127.0.0.1:"+strconv.Itoa(port)) {
+		t.Fatalf("Send = %v, want error koneksi", err)
+	}
+}
+
+func TestBuildMIMEReplyTo(t *testing.T) {
+	from := &mail.Address{Address: "noreply@example.com"}
+	to := &mail.Address{Address: "owner@example.com"}
+
+	// CR/LF di alamat dari form tidak boleh menyisipkan header baru
+	_, err := buildMIME(from, to, Message{ReplyTo: "siti@example.org\r\nBcc: korban@example.net"})
+	if err == nil {
+		t.Fatal("buildMIME menerima Reply-To berisi CRLF")
+	}
+
+	body, err := buildMIME(from, to, Message{ReplyTo: "siti@example.org"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	headers, err := textproto.NewReader(bufio.NewReader(strings.NewReader(string(body)))).ReadMIMEHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := headers.Get("Reply-To"); got != "<siti@example.org>" {
+		t.Errorf("Reply-To = %q, want <siti@example.org>", got)
+	}
+}
@@ -0,0 +1,105 @@
+// Package pagecache menyimpan halaman HTML yang sudah dirender di memori.
+// Setiap halaman dicatat bersama versi konten saat dirender; begitu versi konten
+// berubah (ada perubahan dari admin), halaman lama dianggap basi dan dirender ulang
+package pagecache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Page adalah satu halaman yang sudah dirender
+type Page struct {
+	Body       []byte    // HTML hasil render
+	Gzip       []byte    // Body terkompres gzip, dikompres sekali saat halaman disimpan
+	ETag       string    // Strong ETag dari hash isi halaman (termasuk tanda kutip)
+	Version    uint64    // Versi konten saat halaman dirender
+	RenderedAt time.Time // Waktu render (untuk batas umur cache)
+}
+
+// NewPage membuat Page dari hasil render, lengkap dengan varian gzip dan ETag-nya.
+// ETag hanya bergantung pada isi, jadi render ulang dengan isi sama menghasilkan ETag sama
+func NewPage(body []byte, version uint64, renderedAt time.Time) *Page {
+	sum := sha256.Sum256(body)
+	page := &Page{
+		Body:       body,
+		ETag:       `"` + base64.RawURLEncoding.EncodeToString(sum[:16]) + `"`,
+		Version:    version,
+		RenderedAt: renderedAt,
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(body); err == nil && gz.Close() == nil {
+		page.Gzip = buf.Bytes()
+	}
+	return page
+}
+
+// GzipETag adalah ETag varian gzip; strong ETag harus berbeda untuk setiap Content-Encoding
+func (p *Page) GzipETag() string {
+	return strings.TrimSuffix(p.ETag, `"`) + `-gz"`
+}
+
+// Matches mengecek apakah header If-None-Match berisi etag
+// (perbandingan lemah sesuai RFC 9110: awalan W/ diabaikan)
+func Matches(ifNoneMatch, etag string) bool {
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// Cache menyimpan halaman per key (misal: path + locale), aman dipakai bersamaan
+type Cache struct {
+	mu         sync.RWMutex
+	pages      map[string]*Page
+	maxAge     time.Duration // Umur maksimal halaman meski versi konten tidak berubah
+	maxEntries int           // Batas jumlah halaman tersimpan agar memori tidak tumbuh tanpa batas
+}
+
+// New membuat Cache baru. maxAge membatasi umur halaman untuk isi yang bergantung
+// pada waktu (misal: total masa kerja), maxEntries membatasi jumlah halaman tersimpan
+func New(maxAge time.Duration, maxEntries int) *Cache {
+	return &Cache{pages: make(map[string]*Page), maxAge: maxAge, maxEntries: maxEntries}
+}
+
+// Get mengembalikan halaman untuk key jika masih sesuai versi konten dan belum terlalu tua
+// Mengembalikan nil jika halaman harus dirender ulang
+func (c *Cache) Get(key string, version uint64, now time.Time) *Page {
+	c.mu.RLock()
+	page := c.pages[key]
+	c.mu.RUnlock()
+
+	if page == nil || page.Version != version || now.Sub(page.RenderedAt) > c.maxAge {
+		return nil
+	}
+	return page
+}
+
+// Put menyimpan halaman untuk key. Jika cache penuh, halaman dari versi lama dibuang dulu;
+// jika masih penuh, seluruh cache dikosongkan (render ulang lebih murah daripada pelacakan LRU)
+func (c *Cache) Put(key string, page *Page) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.pages[key]; !ok && len(c.pages) >= c.maxEntries {
+		for k, p := range c.pages {
+			if p.Version != page.Version {
+				delete(c.pages, k)
+			}
+		}
+		if len(c.pages) >= c.maxEntries {
+			clear(c.pages)
+		}
+	}
+	c.pages[key] = page
+}
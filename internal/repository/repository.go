@@ -1,21 +1,74 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"portofolio-go/internal/metrics"
 	"portofolio-go/internal/model"
+	"portofolio-go/internal/tracing"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Repository menyediakan akses ke database untuk semua operasi CRUD
 // Ini adalah lapisan paling bawah yang berinteraksi langsung dengan SQL
 type Repository struct {
-	db *sql.DB
+	db  *sql.DB
+	fts bool // Indeks FTS5 contact_messages_fts tersedia untuk pencarian pesan
 }
 
 // NewRepository membuat instance Repository baru dengan koneksi database
+// Pencarian pesan memakai FTS5 jika indeksnya bisa dibaca, selain itu LIKE
 func NewRepository(db *sql.DB) *Repository {
-	return &Repository{db: db}
+	_, err := db.Exec("SELECT 1 FROM contact_messages_fts LIMIT 0")
+	return &Repository{db: db, fts: err == nil}
+}
+
+// queryDuration mencatat durasi setiap method repository (termasuk semua query dan
+// transaksi di dalamnya), dengan label nama method
+var queryDuration = metrics.NewHistogramVec("db_query_duration_seconds",
+	"Durasi method repository (query database) per method.",
+	[]float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5}, "method")
+
+// startQuery membuka span "repository.<method>" untuk semua query di dalam method repository.
+// Fungsi yang dikembalikan menutup span dan mencatat durasinya; dipanggil lewat defer
+func startQuery(ctx context.Context, method string) (context.Context, func()) {
+	start := time.Now()
+	ctx, span := tracing.Start(ctx, "repository."+method, attribute.String("db.system.name", "sqlite"))
+	return ctx, func() {
+		span.End()
+		queryDuration.ObserveSince(start, method)
+	}
+}
+
+// rowScanner diimplementasikan oleh *sql.Row dan *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// publishedOnly adalah filter tambahan untuk konten yang tampil di halaman publik
+// Dipakai query yang menerima parameter includeDrafts
+const publishedOnly = " AND published = 1"
+
+// queryer diimplementasikan oleh *sql.DB dan *sql.Tx
+// Dipakai helper yang perlu membaca data di dalam maupun di luar transaksi
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// execer diimplementasikan oleh *sql.DB dan *sql.Tx
+// Dipakai helper penulisan data yang bisa ikut transaksi pemanggil
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
 }
 
 // ============================================
@@ -24,8 +77,10 @@ func NewRepository(db *sql.DB) *Repository {
 
 // GetAllConfig mengambil semua konfigurasi situs dari tabel site_config
 // Mengembalikan map key-value untuk kemudahan akses
-func (r *Repository) GetAllConfig() (map[string]string, error) {
-	rows, err := r.db.Query("SELECT key, value FROM site_config")
+func (r *Repository) GetAllConfig(ctx context.Context) (map[string]string, error) {
+	ctx, end := startQuery(ctx, "GetAllConfig")
+	defer end()
+	rows, err := r.db.QueryContext(ctx, "SELECT key, value FROM site_config")
 	if err != nil {
 		return nil, fmt.Errorf("gagal mengambil konfigurasi: %w", err)
 	}
@@ -43,26 +98,73 @@ func (r *Repository) GetAllConfig() (map[string]string, error) {
 }
 
 // UpdateConfig memperbarui nilai konfigurasi situs berdasarkan key
-func (r *Repository) UpdateConfig(key, value string) error {
-	_, err := r.db.Exec(
+// Nilai lama disimpan sebagai revisi jika memang berubah
+func (r *Repository) UpdateConfig(ctx context.Context, key, value string) error {
+	ctx, end := startQuery(ctx, "UpdateConfig")
+	defer end()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi konfigurasi: %w", err)
+	}
+	defer tx.Rollback()
+
+	var old string
+	err = tx.QueryRowContext(ctx, "SELECT value FROM site_config WHERE key = ?", key).Scan(&old)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		// Key baru — belum ada versi lama untuk disimpan
+	case err != nil:
+		return fmt.Errorf("gagal mengambil konfigurasi %s: %w", key, err)
+	case old != value:
+		if err := saveRevision(ctx, tx, model.RevisionConfig, key, old); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx,
 		"INSERT OR REPLACE INTO site_config (key, value, updated_at) VALUES (?, ?, ?)",
 		key, value, time.Now(),
 	)
 	if err != nil {
 		return fmt.Errorf("gagal update konfigurasi %s: %w", key, err)
 	}
-	return nil
+	return tx.Commit()
 }
 
 // ============================================
 // EXPERIENCES — Pengalaman Kerja
 // ============================================
 
+// experienceColumns adalah daftar kolom yang dipilih untuk setiap query experience
+// Urutannya harus sama dengan scanExperience
+const experienceColumns = "id, company, role, start_date, end_date, is_current, COALESCE(legacy_period, ''), description, sort_order, published, publish_at, created_at, updated_at"
+
+// scanExperience membaca satu baris experience sesuai urutan experienceColumns
+func scanExperience(row rowScanner) (model.Experience, error) {
+	var exp model.Experience
+	var startDate, endDate, publishAt sql.NullTime
+	err := row.Scan(&exp.ID, &exp.Company, &exp.Role, &startDate, &endDate, &exp.IsCurrent, &exp.LegacyPeriod,
+		&exp.Description, &exp.SortOrder, &exp.Published, &publishAt, &exp.CreatedAt, &exp.UpdatedAt)
+	if err != nil {
+		return exp, err
+	}
+	exp.StartDate = nullTimePtr(startDate)
+	exp.EndDate = nullTimePtr(endDate)
+	exp.PublishAt = nullTimePtr(publishAt)
+	return exp, nil
+}
+
 // GetAllExperiences mengambil semua pengalaman kerja, diurutkan berdasarkan sort_order
-func (r *Repository) GetAllExperiences() ([]model.Experience, error) {
-	rows, err := r.db.Query(
-		"SELECT id, company, role, period, description, sort_order, created_at, updated_at FROM experiences ORDER BY sort_order ASC",
-	)
+// lalu tanggal mulai terbaru untuk urutan yang sama.
+// Draft dan konten terjadwal hanya ikut jika includeDrafts bernilai true
+func (r *Repository) GetAllExperiences(ctx context.Context, includeDrafts bool) ([]model.Experience, error) {
+	ctx, end := startQuery(ctx, "GetAllExperiences")
+	defer end()
+	query := "SELECT " + experienceColumns + " FROM experiences WHERE deleted_at IS NULL"
+	if !includeDrafts {
+		query += publishedOnly
+	}
+	rows, err := r.db.QueryContext(ctx, query+" ORDER BY sort_order ASC, start_date DESC")
 	if err != nil {
 		return nil, fmt.Errorf("gagal mengambil experiences: %w", err)
 	}
@@ -70,8 +172,8 @@ func (r *Repository) GetAllExperiences() ([]model.Experience, error) {
 
 	var experiences []model.Experience
 	for rows.Next() {
-		var exp model.Experience
-		if err := rows.Scan(&exp.ID, &exp.Company, &exp.Role, &exp.Period, &exp.Description, &exp.SortOrder, &exp.CreatedAt, &exp.UpdatedAt); err != nil {
+		exp, err := scanExperience(rows)
+		if err != nil {
 			return nil, fmt.Errorf("gagal scan experience: %w", err)
 		}
 		experiences = append(experiences, exp)
@@ -80,11 +182,10 @@ func (r *Repository) GetAllExperiences() ([]model.Experience, error) {
 }
 
 // GetExperienceByID mengambil satu pengalaman kerja berdasarkan ID
-func (r *Repository) GetExperienceByID(id int) (*model.Experience, error) {
-	var exp model.Experience
-	err := r.db.QueryRow(
-		"SELECT id, company, role, period, description, sort_order, created_at, updated_at FROM experiences WHERE id = ?", id,
-	).Scan(&exp.ID, &exp.Company, &exp.Role, &exp.Period, &exp.Description, &exp.SortOrder, &exp.CreatedAt, &exp.UpdatedAt)
+func (r *Repository) GetExperienceByID(ctx context.Context, id int) (*model.Experience, error) {
+	ctx, end := startQuery(ctx, "GetExperienceByID")
+	defer end()
+	exp, err := scanExperience(r.db.QueryRowContext(ctx, "SELECT "+experienceColumns+" FROM experiences WHERE id = ? AND deleted_at IS NULL", id))
 	if err != nil {
 		return nil, fmt.Errorf("gagal mengambil experience ID %d: %w", id, err)
 	}
@@ -92,10 +193,13 @@ func (r *Repository) GetExperienceByID(id int) (*model.Experience, error) {
 }
 
 // CreateExperience menambahkan pengalaman kerja baru ke database
-func (r *Repository) CreateExperience(exp *model.Experience) error {
-	result, err := r.db.Exec(
-		"INSERT INTO experiences (company, role, period, description, sort_order) VALUES (?, ?, ?, ?, ?)",
-		exp.Company, exp.Role, exp.Period, exp.Description, exp.SortOrder,
+// Item baru selalu ditempatkan di urutan paling akhir
+func (r *Repository) CreateExperience(ctx context.Context, exp *model.Experience) error {
+	ctx, end := startQuery(ctx, "CreateExperience")
+	defer end()
+	result, err := r.db.ExecContext(ctx,
+		"INSERT INTO experiences (company, role, start_date, end_date, is_current, description, published, publish_at, sort_order) VALUES (?, ?, ?, ?, ?, ?, ?, ?, "+nextSortOrder("experiences")+")",
+		exp.Company, exp.Role, exp.StartDate, exp.EndDate, exp.IsCurrent, exp.Description, exp.Published, exp.PublishAt,
 	)
 	if err != nil {
 		return fmt.Errorf("gagal membuat experience: %w", err)
@@ -108,20 +212,40 @@ func (r *Repository) CreateExperience(exp *model.Experience) error {
 }
 
 // UpdateExperience memperbarui data pengalaman kerja yang sudah ada
-func (r *Repository) UpdateExperience(exp *model.Experience) error {
-	_, err := r.db.Exec(
-		"UPDATE experiences SET company=?, role=?, period=?, description=?, sort_order=?, updated_at=? WHERE id=?",
-		exp.Company, exp.Role, exp.Period, exp.Description, exp.SortOrder, time.Now(), exp.ID,
+// Versi sebelumnya disimpan sebagai revisi dalam transaksi yang sama.
+// sort_order tidak diubah di sini — gunakan ReorderExperiences
+func (r *Repository) UpdateExperience(ctx context.Context, exp *model.Experience) error {
+	ctx, end := startQuery(ctx, "UpdateExperience")
+	defer end()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi experience: %w", err)
+	}
+	defer tx.Rollback()
+
+	old, err := scanExperience(tx.QueryRowContext(ctx, "SELECT "+experienceColumns+" FROM experiences WHERE id = ? AND deleted_at IS NULL", exp.ID))
+	if err != nil {
+		return fmt.Errorf("gagal mengambil experience ID %d: %w", exp.ID, err)
+	}
+	if err := saveRevision(ctx, tx, model.RevisionExperience, strconv.Itoa(exp.ID), old); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		"UPDATE experiences SET company=?, role=?, start_date=?, end_date=?, is_current=?, legacy_period=CASE WHEN ? IS NULL THEN legacy_period END, description=?, published=?, publish_at=?, updated_at=? WHERE id=?",
+		exp.Company, exp.Role, exp.StartDate, exp.EndDate, exp.IsCurrent, exp.StartDate, exp.Description, exp.Published, exp.PublishAt, time.Now(), exp.ID,
 	)
 	if err != nil {
 		return fmt.Errorf("gagal update experience ID %d: %w", exp.ID, err)
 	}
-	return nil
+	return tx.Commit()
 }
 
-// DeleteExperience menghapus pengalaman kerja berdasarkan ID
-func (r *Repository) DeleteExperience(id int) error {
-	_, err := r.db.Exec("DELETE FROM experiences WHERE id = ?", id)
+// DeleteExperience memindahkan pengalaman kerja ke sampah (soft delete) berdasarkan ID
+func (r *Repository) DeleteExperience(ctx context.Context, id int) error {
+	ctx, end := startQuery(ctx, "DeleteExperience")
+	defer end()
+	_, err := r.db.ExecContext(ctx, "UPDATE experiences SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL", time.Now(), id)
 	if err != nil {
 		return fmt.Errorf("gagal hapus experience ID %d: %w", id, err)
 	}
@@ -132,11 +256,35 @@ func (r *Repository) DeleteExperience(id int) error {
 // PROJECTS — Proyek Portofolio
 // ============================================
 
-// GetAllProjects mengambil semua proyek, diurutkan berdasarkan sort_order
-func (r *Repository) GetAllProjects() ([]model.Project, error) {
-	rows, err := r.db.Query(
-		"SELECT id, title, description, tech_used, link, github_url, image_url, sort_order, created_at, updated_at FROM projects ORDER BY sort_order ASC",
-	)
+// projectColumns adalah daftar kolom yang dipilih untuk setiap query proyek
+// Urutannya harus sama dengan scanProject
+const projectColumns = "id, title, description, role, status, start_date, end_date, link, github_url, image_url, sort_order, published, publish_at, created_at, updated_at"
+
+// scanProject membaca satu baris proyek sesuai urutan projectColumns
+func scanProject(row rowScanner) (model.Project, error) {
+	var proj model.Project
+	var startDate, endDate, publishAt sql.NullTime
+	err := row.Scan(&proj.ID, &proj.Title, &proj.Description, &proj.Role, &proj.Status, &startDate, &endDate,
+		&proj.Link, &proj.GithubURL, &proj.ImageURL, &proj.SortOrder, &proj.Published, &publishAt, &proj.CreatedAt, &proj.UpdatedAt)
+	if err != nil {
+		return proj, err
+	}
+	proj.StartDate = nullTimePtr(startDate)
+	proj.EndDate = nullTimePtr(endDate)
+	proj.PublishAt = nullTimePtr(publishAt)
+	return proj, nil
+}
+
+// GetAllProjects mengambil semua proyek beserta tag-nya, diurutkan berdasarkan sort_order
+// Draft dan konten terjadwal hanya ikut jika includeDrafts bernilai true
+func (r *Repository) GetAllProjects(ctx context.Context, includeDrafts bool) ([]model.Project, error) {
+	ctx, end := startQuery(ctx, "GetAllProjects")
+	defer end()
+	query := "SELECT " + projectColumns + " FROM projects WHERE deleted_at IS NULL"
+	if !includeDrafts {
+		query += publishedOnly
+	}
+	rows, err := r.db.QueryContext(ctx, query+" ORDER BY sort_order ASC")
 	if err != nil {
 		return nil, fmt.Errorf("gagal mengambil projects: %w", err)
 	}
@@ -144,71 +292,217 @@ func (r *Repository) GetAllProjects() ([]model.Project, error) {
 
 	var projects []model.Project
 	for rows.Next() {
-		var proj model.Project
-		if err := rows.Scan(&proj.ID, &proj.Title, &proj.Description, &proj.TechUsed, &proj.Link, &proj.GithubURL, &proj.ImageURL, &proj.SortOrder, &proj.CreatedAt, &proj.UpdatedAt); err != nil {
+		proj, err := scanProject(rows)
+		if err != nil {
 			return nil, fmt.Errorf("gagal scan project: %w", err)
 		}
 		projects = append(projects, proj)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("gagal iterasi projects: %w", err)
+	}
+
+	// Ambil tag untuk semua proyek sekaligus (hindari N+1 query)
+	tagsByProject, err := getProjectTags(ctx, r.db, includeDrafts, "")
+	if err != nil {
+		return nil, err
+	}
+	for i := range projects {
+		projects[i].Tags = tagsByProject[projects[i].ID]
+	}
 	return projects, nil
 }
 
-// GetProjectByID mengambil satu proyek berdasarkan ID
-func (r *Repository) GetProjectByID(id int) (*model.Project, error) {
-	var proj model.Project
-	err := r.db.QueryRow(
-		"SELECT id, title, description, tech_used, link, github_url, image_url, sort_order, created_at, updated_at FROM projects WHERE id = ?", id,
-	).Scan(&proj.ID, &proj.Title, &proj.Description, &proj.TechUsed, &proj.Link, &proj.GithubURL, &proj.ImageURL, &proj.SortOrder, &proj.CreatedAt, &proj.UpdatedAt)
+// GetProjectByID mengambil satu proyek beserta tag-nya berdasarkan ID
+func (r *Repository) GetProjectByID(ctx context.Context, id int) (*model.Project, error) {
+	ctx, end := startQuery(ctx, "GetProjectByID")
+	defer end()
+	return getProjectByID(ctx, r.db, id)
+}
+
+// getProjectByID mengambil satu proyek beserta tag-nya lewat koneksi atau transaksi q
+func getProjectByID(ctx context.Context, q queryer, id int) (*model.Project, error) {
+	proj, err := scanProject(q.QueryRowContext(ctx, "SELECT "+projectColumns+" FROM projects WHERE id = ? AND deleted_at IS NULL", id))
 	if err != nil {
 		return nil, fmt.Errorf("gagal mengambil project ID %d: %w", id, err)
 	}
+
+	tagsByProject, err := getProjectTags(ctx, q, true, "WHERE pt.project_id = ?", proj.ID)
+	if err != nil {
+		return nil, err
+	}
+	proj.Tags = tagsByProject[proj.ID]
 	return &proj, nil
 }
 
-// CreateProject menambahkan proyek baru ke database
-func (r *Repository) CreateProject(proj *model.Project) error {
-	result, err := r.db.Exec(
-		"INSERT INTO projects (title, description, tech_used, link, github_url, image_url, sort_order) VALUES (?, ?, ?, ?, ?, ?, ?)",
-		proj.Title, proj.Description, proj.TechUsed, proj.Link, proj.GithubURL, proj.ImageURL, proj.SortOrder,
+// CreateProject menambahkan proyek baru beserta tag-nya dalam satu transaksi
+// Proyek baru selalu ditempatkan di urutan paling akhir
+func (r *Repository) CreateProject(ctx context.Context, proj *model.Project) error {
+	ctx, end := startQuery(ctx, "CreateProject")
+	defer end()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi project: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		"INSERT INTO projects (title, description, role, status, start_date, end_date, link, github_url, image_url, published, publish_at, sort_order) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, "+nextSortOrder("projects")+")",
+		proj.Title, proj.Description, proj.Role, proj.Status, proj.StartDate, proj.EndDate, proj.Link, proj.GithubURL, proj.ImageURL, proj.Published, proj.PublishAt,
 	)
 	if err != nil {
 		return fmt.Errorf("gagal membuat project: %w", err)
 	}
 	id, _ := result.LastInsertId()
 	proj.ID = int(id)
-	return nil
+
+	if err := setProjectTags(ctx, tx, proj); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
-// UpdateProject memperbarui data proyek yang sudah ada
-func (r *Repository) UpdateProject(proj *model.Project) error {
-	_, err := r.db.Exec(
-		"UPDATE projects SET title=?, description=?, tech_used=?, link=?, github_url=?, image_url=?, sort_order=?, updated_at=? WHERE id=?",
-		proj.Title, proj.Description, proj.TechUsed, proj.Link, proj.GithubURL, proj.ImageURL, proj.SortOrder, time.Now(), proj.ID,
+// UpdateProject memperbarui data proyek dan mengganti seluruh tag-nya dalam satu transaksi
+// Versi sebelumnya (termasuk tag) disimpan sebagai revisi.
+// sort_order tidak diubah di sini — gunakan ReorderProjects
+func (r *Repository) UpdateProject(ctx context.Context, proj *model.Project) error {
+	ctx, end := startQuery(ctx, "UpdateProject")
+	defer end()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi project: %w", err)
+	}
+	defer tx.Rollback()
+
+	old, err := getProjectByID(ctx, tx, proj.ID)
+	if err != nil {
+		return err
+	}
+	if err := saveRevision(ctx, tx, model.RevisionProject, strconv.Itoa(proj.ID), old); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		"UPDATE projects SET title=?, description=?, role=?, status=?, start_date=?, end_date=?, link=?, github_url=?, image_url=?, published=?, publish_at=?, updated_at=? WHERE id=?",
+		proj.Title, proj.Description, proj.Role, proj.Status, proj.StartDate, proj.EndDate, proj.Link, proj.GithubURL, proj.ImageURL, proj.Published, proj.PublishAt, time.Now(), proj.ID,
 	)
 	if err != nil {
 		return fmt.Errorf("gagal update project ID %d: %w", proj.ID, err)
 	}
-	return nil
+
+	if err := setProjectTags(ctx, tx, proj); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
-// DeleteProject menghapus proyek berdasarkan ID
-func (r *Repository) DeleteProject(id int) error {
-	_, err := r.db.Exec("DELETE FROM projects WHERE id = ?", id)
+// DeleteProject memindahkan proyek ke sampah (soft delete) berdasarkan ID
+// Relasi di project_tags tetap disimpan agar proyek bisa dipulihkan utuh
+func (r *Repository) DeleteProject(ctx context.Context, id int) error {
+	ctx, end := startQuery(ctx, "DeleteProject")
+	defer end()
+	_, err := r.db.ExecContext(ctx, "UPDATE projects SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL", time.Now(), id)
 	if err != nil {
 		return fmt.Errorf("gagal hapus project ID %d: %w", id, err)
 	}
 	return nil
 }
 
+// ============================================
+// TAGS — Tag Teknologi Proyek
+// ============================================
+
+// getProjectTags mengambil relasi proyek-tag, dikelompokkan per project ID
+// where bersifat opsional untuk membatasi proyek yang diambil (misal: "WHERE pt.project_id = ?").
+// Tag hanya dihubungkan ke tech stack draft jika includeDrafts bernilai true
+func getProjectTags(ctx context.Context, q queryer, includeDrafts bool, where string, args ...any) (map[int][]model.Tag, error) {
+	join := "LEFT JOIN tech_stacks ts ON ts.id = t.tech_stack_id AND ts.deleted_at IS NULL"
+	if !includeDrafts {
+		join += " AND ts.published = 1"
+	}
+	rows, err := q.QueryContext(ctx,
+		"SELECT pt.project_id, t.id, t.name, ts.id FROM project_tags pt JOIN tags t ON t.id = pt.tag_id "+
+			join+" "+where+" ORDER BY pt.project_id, pt.position ASC",
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil tag proyek: %w", err)
+	}
+	defer rows.Close()
+
+	tags := make(map[int][]model.Tag)
+	for rows.Next() {
+		var projectID int
+		var tag model.Tag
+		var techStackID sql.NullInt64
+		if err := rows.Scan(&projectID, &tag.ID, &tag.Name, &techStackID); err != nil {
+			return nil, fmt.Errorf("gagal scan tag proyek: %w", err)
+		}
+		tag.TechStackID = nullIntPtr(techStackID)
+		tags[projectID] = append(tags[projectID], tag)
+	}
+	return tags, rows.Err()
+}
+
+// setProjectTags mengganti seluruh tag proyek dengan proj.Tags (berdasarkan nama)
+// Tag yang belum ada di tabel tags akan dibuat otomatis
+func setProjectTags(ctx context.Context, tx *sql.Tx, proj *model.Project) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM project_tags WHERE project_id = ?", proj.ID); err != nil {
+		return fmt.Errorf("gagal menghapus tag project ID %d: %w", proj.ID, err)
+	}
+
+	for i := range proj.Tags {
+		tag := &proj.Tags[i]
+		if _, err := tx.ExecContext(ctx, "INSERT OR IGNORE INTO tags (name) VALUES (?)", tag.Name); err != nil {
+			return fmt.Errorf("gagal membuat tag %q: %w", tag.Name, err)
+		}
+		// Ambil ID dan nama kanonik (tag lama bisa beda huruf besar/kecil)
+		var techStackID sql.NullInt64
+		if err := tx.QueryRowContext(ctx, "SELECT id, name, tech_stack_id FROM tags WHERE name = ?", tag.Name).Scan(&tag.ID, &tag.Name, &techStackID); err != nil {
+			return fmt.Errorf("gagal mengambil tag %q: %w", tag.Name, err)
+		}
+		tag.TechStackID = nullIntPtr(techStackID)
+		if _, err := tx.ExecContext(ctx,
+			"INSERT OR IGNORE INTO project_tags (project_id, tag_id, position) VALUES (?, ?, ?)",
+			proj.ID, tag.ID, i,
+		); err != nil {
+			return fmt.Errorf("gagal menghubungkan tag %q ke project ID %d: %w", tag.Name, proj.ID, err)
+		}
+	}
+	return nil
+}
+
 // ============================================
 // TECH STACKS — Teknologi yang Dikuasai
 // ============================================
 
+// techStackColumns adalah daftar kolom yang dipilih untuk setiap query tech stack
+// Urutannya harus sama dengan scanTechStack
+const techStackColumns = "id, category, name, description, sort_order, published, publish_at, created_at, updated_at"
+
+// scanTechStack membaca satu baris tech stack sesuai urutan techStackColumns
+func scanTechStack(row rowScanner) (model.TechStack, error) {
+	var ts model.TechStack
+	var publishAt sql.NullTime
+	err := row.Scan(&ts.ID, &ts.Category, &ts.Name, &ts.Description, &ts.SortOrder,
+		&ts.Published, &publishAt, &ts.CreatedAt, &ts.UpdatedAt)
+	if err != nil {
+		return ts, err
+	}
+	ts.PublishAt = nullTimePtr(publishAt)
+	return ts, nil
+}
+
 // GetAllTechStacks mengambil semua tech stack, diurutkan berdasarkan sort_order
-func (r *Repository) GetAllTechStacks() ([]model.TechStack, error) {
-	rows, err := r.db.Query(
-		"SELECT id, category, name, description, sort_order, created_at, updated_at FROM tech_stacks ORDER BY sort_order ASC",
-	)
+// Draft dan konten terjadwal hanya ikut jika includeDrafts bernilai true
+func (r *Repository) GetAllTechStacks(ctx context.Context, includeDrafts bool) ([]model.TechStack, error) {
+	ctx, end := startQuery(ctx, "GetAllTechStacks")
+	defer end()
+	query := "SELECT " + techStackColumns + " FROM tech_stacks WHERE deleted_at IS NULL"
+	if !includeDrafts {
+		query += publishedOnly
+	}
+	rows, err := r.db.QueryContext(ctx, query+" ORDER BY sort_order ASC")
 	if err != nil {
 		return nil, fmt.Errorf("gagal mengambil tech stacks: %w", err)
 	}
@@ -216,8 +510,8 @@ func (r *Repository) GetAllTechStacks() ([]model.TechStack, error) {
 
 	var stacks []model.TechStack
 	for rows.Next() {
-		var ts model.TechStack
-		if err := rows.Scan(&ts.ID, &ts.Category, &ts.Name, &ts.Description, &ts.SortOrder, &ts.CreatedAt, &ts.UpdatedAt); err != nil {
+		ts, err := scanTechStack(rows)
+		if err != nil {
 			return nil, fmt.Errorf("gagal scan tech stack: %w", err)
 		}
 		stacks = append(stacks, ts)
@@ -226,61 +520,296 @@ func (r *Repository) GetAllTechStacks() ([]model.TechStack, error) {
 }
 
 // GetTechStackByID mengambil satu tech stack berdasarkan ID
-func (r *Repository) GetTechStackByID(id int) (*model.TechStack, error) {
-	var ts model.TechStack
-	err := r.db.QueryRow(
-		"SELECT id, category, name, description, sort_order, created_at, updated_at FROM tech_stacks WHERE id = ?", id,
-	).Scan(&ts.ID, &ts.Category, &ts.Name, &ts.Description, &ts.SortOrder, &ts.CreatedAt, &ts.UpdatedAt)
+func (r *Repository) GetTechStackByID(ctx context.Context, id int) (*model.TechStack, error) {
+	ctx, end := startQuery(ctx, "GetTechStackByID")
+	defer end()
+	ts, err := scanTechStack(r.db.QueryRowContext(ctx, "SELECT "+techStackColumns+" FROM tech_stacks WHERE id = ? AND deleted_at IS NULL", id))
 	if err != nil {
 		return nil, fmt.Errorf("gagal mengambil tech stack ID %d: %w", id, err)
 	}
 	return &ts, nil
 }
 
-// CreateTechStack menambahkan tech stack baru ke database
-func (r *Repository) CreateTechStack(ts *model.TechStack) error {
-	result, err := r.db.Exec(
-		"INSERT INTO tech_stacks (category, name, description, sort_order) VALUES (?, ?, ?, ?)",
-		ts.Category, ts.Name, ts.Description, ts.SortOrder,
+// CreateTechStack menambahkan tech stack baru beserta tag yang terhubung dalam satu transaksi
+// Item baru selalu ditempatkan di urutan paling akhir
+func (r *Repository) CreateTechStack(ctx context.Context, ts *model.TechStack) error {
+	ctx, end := startQuery(ctx, "CreateTechStack")
+	defer end()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi tech stack: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		"INSERT INTO tech_stacks (category, name, description, published, publish_at, sort_order) VALUES (?, ?, ?, ?, ?, "+nextSortOrder("tech_stacks")+")",
+		ts.Category, ts.Name, ts.Description, ts.Published, ts.PublishAt,
 	)
 	if err != nil {
 		return fmt.Errorf("gagal membuat tech stack: %w", err)
 	}
 	id, _ := result.LastInsertId()
 	ts.ID = int(id)
-	return nil
+
+	if err := setTechStackTags(ctx, tx, ts); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
-// UpdateTechStack memperbarui data tech stack yang sudah ada
-func (r *Repository) UpdateTechStack(ts *model.TechStack) error {
-	_, err := r.db.Exec(
-		"UPDATE tech_stacks SET category=?, name=?, description=?, sort_order=?, updated_at=? WHERE id=?",
-		ts.Category, ts.Name, ts.Description, ts.SortOrder, time.Now(), ts.ID,
+// UpdateTechStack memperbarui data tech stack dan mengganti tag yang terhubung dalam satu transaksi
+// Versi sebelumnya (termasuk tag yang terhubung) disimpan sebagai revisi.
+// sort_order tidak diubah di sini — gunakan ReorderTechStacks
+func (r *Repository) UpdateTechStack(ctx context.Context, ts *model.TechStack) error {
+	ctx, end := startQuery(ctx, "UpdateTechStack")
+	defer end()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi tech stack: %w", err)
+	}
+	defer tx.Rollback()
+
+	old, err := scanTechStack(tx.QueryRowContext(ctx, "SELECT "+techStackColumns+" FROM tech_stacks WHERE id = ? AND deleted_at IS NULL", ts.ID))
+	if err != nil {
+		return fmt.Errorf("gagal mengambil tech stack ID %d: %w", ts.ID, err)
+	}
+	rows, err := tx.QueryContext(ctx, "SELECT id, name FROM tags WHERE tech_stack_id = ? ORDER BY name COLLATE NOCASE ASC", ts.ID)
+	if err != nil {
+		return fmt.Errorf("gagal mengambil tag tech stack ID %d: %w", ts.ID, err)
+	}
+	for rows.Next() {
+		tag := model.Tag{TechStackID: &old.ID}
+		if err := rows.Scan(&tag.ID, &tag.Name); err != nil {
+			rows.Close()
+			return fmt.Errorf("gagal scan tag tech stack: %w", err)
+		}
+		old.Tags = append(old.Tags, tag)
+	}
+	rows.Close()
+	if err := saveRevision(ctx, tx, model.RevisionTechStack, strconv.Itoa(ts.ID), old); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		"UPDATE tech_stacks SET category=?, name=?, description=?, published=?, publish_at=?, updated_at=? WHERE id=?",
+		ts.Category, ts.Name, ts.Description, ts.Published, ts.PublishAt, time.Now(), ts.ID,
 	)
 	if err != nil {
 		return fmt.Errorf("gagal update tech stack ID %d: %w", ts.ID, err)
 	}
-	return nil
+	if err := setTechStackTags(ctx, tx, ts); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
-// DeleteTechStack menghapus tech stack berdasarkan ID
-func (r *Repository) DeleteTechStack(id int) error {
-	_, err := r.db.Exec("DELETE FROM tech_stacks WHERE id = ?", id)
+// DeleteTechStack memindahkan tech stack ke sampah (soft delete) berdasarkan ID
+func (r *Repository) DeleteTechStack(ctx context.Context, id int) error {
+	ctx, end := startQuery(ctx, "DeleteTechStack")
+	defer end()
+	_, err := r.db.ExecContext(ctx, "UPDATE tech_stacks SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL", time.Now(), id)
 	if err != nil {
 		return fmt.Errorf("gagal hapus tech stack ID %d: %w", id, err)
 	}
 	return nil
 }
 
+// GetTagsByTechStack mengambil semua tag yang terhubung ke tech stack,
+// dikelompokkan per tech stack ID
+func (r *Repository) GetTagsByTechStack(ctx context.Context) (map[int][]model.Tag, error) {
+	ctx, end := startQuery(ctx, "GetTagsByTechStack")
+	defer end()
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT id, name, tech_stack_id FROM tags WHERE tech_stack_id IS NOT NULL ORDER BY name COLLATE NOCASE ASC",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil tag tech stack: %w", err)
+	}
+	defer rows.Close()
+
+	tags := make(map[int][]model.Tag)
+	for rows.Next() {
+		var tag model.Tag
+		var techStackID int
+		if err := rows.Scan(&tag.ID, &tag.Name, &techStackID); err != nil {
+			return nil, fmt.Errorf("gagal scan tag tech stack: %w", err)
+		}
+		tag.TechStackID = &techStackID
+		tags[techStackID] = append(tags[techStackID], tag)
+	}
+	return tags, rows.Err()
+}
+
+// GetProjectsByTechStack mengambil proyek yang memakai setiap tech stack
+// lewat join tags -> project_tags -> projects, dikelompokkan per tech stack ID.
+// Hanya ID dan Title proyek yang diisi; proyek draft hanya ikut jika includeDrafts bernilai true.
+func (r *Repository) GetProjectsByTechStack(ctx context.Context, includeDrafts bool) (map[int][]model.Project, error) {
+	ctx, end := startQuery(ctx, "GetProjectsByTechStack")
+	defer end()
+	join := "JOIN projects p ON p.id = pt.project_id AND p.deleted_at IS NULL"
+	if !includeDrafts {
+		join += " AND p.published = 1"
+	}
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT DISTINCT t.tech_stack_id, p.id, p.title, p.sort_order
+		FROM tags t
+		JOIN project_tags pt ON pt.tag_id = t.id
+		`+join+`
+		WHERE t.tech_stack_id IS NOT NULL
+		ORDER BY t.tech_stack_id, p.sort_order ASC`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil proyek per tech stack: %w", err)
+	}
+	defer rows.Close()
+
+	projects := make(map[int][]model.Project)
+	for rows.Next() {
+		var techStackID int
+		var proj model.Project
+		if err := rows.Scan(&techStackID, &proj.ID, &proj.Title, &proj.SortOrder); err != nil {
+			return nil, fmt.Errorf("gagal scan proyek per tech stack: %w", err)
+		}
+		projects[techStackID] = append(projects[techStackID], proj)
+	}
+	return projects, rows.Err()
+}
+
+// setTechStackTags mengganti daftar tag yang terhubung ke tech stack di dalam transaksi tx
+// Tag yang sebelumnya terhubung tapi tidak ada di ts.Tags akan dilepas,
+// tag yang belum ada di tabel tags akan dibuat
+func setTechStackTags(ctx context.Context, tx *sql.Tx, ts *model.TechStack) error {
+	if _, err := tx.ExecContext(ctx, "UPDATE tags SET tech_stack_id = NULL WHERE tech_stack_id = ?", ts.ID); err != nil {
+		return fmt.Errorf("gagal melepas tag tech stack ID %d: %w", ts.ID, err)
+	}
+
+	for _, tag := range ts.Tags {
+		if _, err := tx.ExecContext(ctx, "INSERT OR IGNORE INTO tags (name) VALUES (?)", tag.Name); err != nil {
+			return fmt.Errorf("gagal membuat tag %q: %w", tag.Name, err)
+		}
+		if _, err := tx.ExecContext(ctx, "UPDATE tags SET tech_stack_id = ? WHERE name = ?", ts.ID, tag.Name); err != nil {
+			return fmt.Errorf("gagal menghubungkan tag %q ke tech stack ID %d: %w", tag.Name, ts.ID, err)
+		}
+	}
+	return nil
+}
+
+// GetAllTags mengambil semua tag, diurutkan berdasarkan nama
+// Digunakan untuk autocomplete di dashboard
+func (r *Repository) GetAllTags(ctx context.Context) ([]model.Tag, error) {
+	ctx, end := startQuery(ctx, "GetAllTags")
+	defer end()
+	rows, err := r.db.QueryContext(ctx, "SELECT id, name, tech_stack_id FROM tags ORDER BY name COLLATE NOCASE ASC")
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil tags: %w", err)
+	}
+	defer rows.Close()
+
+	var tags []model.Tag
+	for rows.Next() {
+		var tag model.Tag
+		var techStackID sql.NullInt64
+		if err := rows.Scan(&tag.ID, &tag.Name, &techStackID); err != nil {
+			return nil, fmt.Errorf("gagal scan tag: %w", err)
+		}
+		tag.TechStackID = nullIntPtr(techStackID)
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+// ============================================
+// REORDER — Urutan Tampil
+// ============================================
+
+// ReorderExperiences menulis ulang sort_order experience sesuai urutan ids
+func (r *Repository) ReorderExperiences(ctx context.Context, ids []int) error {
+	ctx, end := startQuery(ctx, "ReorderExperiences")
+	defer end()
+	return r.reorder(ctx, "experiences", ids)
+}
+
+// ReorderProjects menulis ulang sort_order proyek sesuai urutan ids
+func (r *Repository) ReorderProjects(ctx context.Context, ids []int) error {
+	ctx, end := startQuery(ctx, "ReorderProjects")
+	defer end()
+	return r.reorder(ctx, "projects", ids)
+}
+
+// ReorderTechStacks menulis ulang sort_order tech stack sesuai urutan ids
+func (r *Repository) ReorderTechStacks(ctx context.Context, ids []int) error {
+	ctx, end := startQuery(ctx, "ReorderTechStacks")
+	defer end()
+	return r.reorder(ctx, "tech_stacks", ids)
+}
+
+// reorder mengisi sort_order = 1, 2, 3, ... untuk setiap ID sesuai urutan
+// di dalam satu transaksi. Jika ada ID yang tidak ditemukan, seluruh perubahan dibatalkan.
+// Nama tabel hanya berasal dari method Reorder* di atas, bukan dari input pengguna.
+func (r *Repository) reorder(ctx context.Context, table string, ids []int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi reorder %s: %w", table, err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, "UPDATE "+table+" SET sort_order = ? WHERE id = ?")
+	if err != nil {
+		return fmt.Errorf("gagal menyiapkan reorder %s: %w", table, err)
+	}
+	defer stmt.Close()
+
+	for i, id := range ids {
+		result, err := stmt.ExecContext(ctx, i+1, id)
+		if err != nil {
+			return fmt.Errorf("gagal reorder %s ID %d: %w", table, id, err)
+		}
+		if n, _ := result.RowsAffected(); n == 0 {
+			return fmt.Errorf("gagal reorder %s: ID %d tidak ditemukan", table, id)
+		}
+	}
+	return tx.Commit()
+}
+
+// nextSortOrder mengembalikan subquery sort_order berikutnya (paling akhir) untuk tabel
+func nextSortOrder(table string) string {
+	return "(SELECT COALESCE(MAX(sort_order), 0) + 1 FROM " + table + ")"
+}
+
 // ============================================
 // CONTACT MESSAGES — Pesan Kontak
 // ============================================
 
-// GetAllContactMessages mengambil semua pesan kontak, terbaru duluan
-func (r *Repository) GetAllContactMessages() ([]model.ContactMessage, error) {
-	rows, err := r.db.Query(
-		"SELECT id, name, email, message, is_read, created_at FROM contact_messages ORDER BY created_at DESC",
+// messageColumns adalah kolom pesan kontak dengan urutan sesuai scanContactMessage
+const messageColumns = "id, name, email, message, status, locale, is_spam, spam_score, spam_reasons, consent_version, created_at"
+
+// scanContactMessage membaca satu baris pesan kontak (kolom sesuai messageColumns)
+func scanContactMessage(row rowScanner) (model.ContactMessage, error) {
+	var msg model.ContactMessage
+	err := row.Scan(
+		&msg.ID, &msg.Name, &msg.Email, &msg.Message, &msg.Status, &msg.Locale,
+		&msg.IsSpam, &msg.SpamScore, &msg.SpamReasons, &msg.Consent, &msg.CreatedAt,
 	)
+	return msg, err
+}
+
+// GetContactMessages mengambil pesan kontak di kotak masuk (spam = false)
+// atau folder spam (spam = true), terbaru duluan. status membatasi pesan
+// dengan status tertentu; kosong berarti semua kecuali yang diarsipkan
+func (r *Repository) GetContactMessages(ctx context.Context, spam bool, status string) ([]model.ContactMessage, error) {
+	ctx, end := startQuery(ctx, "GetContactMessages")
+	defer end()
+	query := "SELECT " + messageColumns + " FROM contact_messages WHERE deleted_at IS NULL AND is_spam = ?"
+	args := []any{spam}
+	if status == "" {
+		query += " AND status != ?"
+		args = append(args, model.MessageArchived)
+	} else {
+		query += " AND status = ?"
+		args = append(args, status)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query+" ORDER BY created_at DESC", args...)
 	if err != nil {
 		return nil, fmt.Errorf("gagal mengambil contact messages: %w", err)
 	}
@@ -288,8 +817,8 @@ func (r *Repository) GetAllContactMessages() ([]model.ContactMessage, error) {
 
 	var messages []model.ContactMessage
 	for rows.Next() {
-		var msg model.ContactMessage
-		if err := rows.Scan(&msg.ID, &msg.Name, &msg.Email, &msg.Message, &msg.IsRead, &msg.CreatedAt); err != nil {
+		msg, err := scanContactMessage(rows)
+		if err != nil {
 			return nil, fmt.Errorf("gagal scan contact message: %w", err)
 		}
 		messages = append(messages, msg)
@@ -297,11 +826,158 @@ func (r *Repository) GetAllContactMessages() ([]model.ContactMessage, error) {
 	return messages, nil
 }
 
+// SearchContactMessages mencari pesan kotak masuk (bukan spam) sesuai filter dan
+// mengembalikan satu halaman hasil beserta jumlah seluruh pesan yang cocok.
+// Kata kunci dicocokkan lewat FTS5 (awalan kata) atau LIKE jika FTS5 tidak tersedia
+func (r *Repository) SearchContactMessages(ctx context.Context, f model.MessageFilter) ([]model.ContactMessage, int, error) {
+	ctx, end := startQuery(ctx, "SearchContactMessages")
+	defer end()
+	where, args := r.messageFilterWhere(ctx, f)
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM contact_messages"+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("gagal menghitung hasil pencarian pesan: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT "+messageColumns+" FROM contact_messages"+where+" ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?",
+		append(args, f.PerPage, (f.Page-1)*f.PerPage)...,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("gagal mencari pesan kontak: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []model.ContactMessage
+	for rows.Next() {
+		msg, err := scanContactMessage(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("gagal scan contact message: %w", err)
+		}
+		messages = append(messages, msg)
+	}
+	return messages, total, rows.Err()
+}
+
+// EachContactMessage memanggil fn untuk setiap pesan kotak masuk yang cocok dengan filter
+// (tanpa halaman), terlama duluan. Baris dibaca satu per satu dari cursor sehingga
+// ekspor besar tidak perlu ditampung di memori. Error dari fn menghentikan iterasi
+func (r *Repository) EachContactMessage(ctx context.Context, f model.MessageFilter, fn func(model.ContactMessage) error) error {
+	ctx, end := startQuery(ctx, "EachContactMessage")
+	defer end()
+	where, args := r.messageFilterWhere(ctx, f)
+	rows, err := r.db.QueryContext(ctx, "SELECT "+messageColumns+" FROM contact_messages"+where+" ORDER BY created_at, id", args...)
+	if err != nil {
+		return fmt.Errorf("gagal mengambil pesan kontak: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		msg, err := scanContactMessage(rows)
+		if err != nil {
+			return fmt.Errorf("gagal scan contact message: %w", err)
+		}
+		if err := fn(msg); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// messageFilterWhere menyusun klausa WHERE (beserta argumennya) untuk filter pesan
+// kotak masuk. Pesan terhapus dan spam selalu dikecualikan
+func (r *Repository) messageFilterWhere(ctx context.Context, f model.MessageFilter) (string, []any) {
+	where := " WHERE deleted_at IS NULL AND is_spam = 0"
+	var args []any
+
+	if len(f.IDs) > 0 {
+		placeholders, idArgs := inClause(f.IDs)
+		where += " AND id IN (" + placeholders + ")"
+		args = append(args, idArgs...)
+	}
+
+	switch f.Status {
+	case "":
+		where += " AND status != ?"
+		args = append(args, model.MessageArchived)
+	case model.MessageAll:
+	default:
+		where += " AND status = ?"
+		args = append(args, f.Status)
+	}
+	// created_at disimpan CURRENT_TIMESTAMP ("YYYY-MM-DD HH:MM:SS" UTC), jadi cukup dibandingkan sebagai teks
+	if f.From != nil {
+		where += " AND created_at >= ?"
+		args = append(args, f.From.Format("2006-01-02"))
+	}
+	if f.To != nil {
+		where += " AND created_at < ?"
+		args = append(args, f.To.AddDate(0, 0, 1).Format("2006-01-02"))
+	}
+
+	if terms := searchTerms(f.Query); len(terms) > 0 {
+		if r.fts {
+			// Setiap kata dicari sebagai awalan: "budi"* "gmail"*
+			quoted := make([]string, len(terms))
+			for i, term := range terms {
+				quoted[i] = `"` + term + `"*`
+			}
+			where += " AND id IN (SELECT rowid FROM contact_messages_fts WHERE contact_messages_fts MATCH ?)"
+			args = append(args, strings.Join(quoted, " "))
+		} else {
+			for _, term := range terms {
+				where += " AND (name || ' ' || email || ' ' || message) LIKE ?"
+				args = append(args, "%"+term+"%")
+			}
+		}
+	}
+	return where, args
+}
+
+// GetContactMessageByID mengambil satu pesan kontak yang belum dihapus
+func (r *Repository) GetContactMessageByID(ctx context.Context, id int) (*model.ContactMessage, error) {
+	ctx, end := startQuery(ctx, "GetContactMessageByID")
+	defer end()
+	msg, err := scanContactMessage(r.db.QueryRowContext(ctx,
+		"SELECT "+messageColumns+" FROM contact_messages WHERE id = ? AND deleted_at IS NULL", id,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil pesan kontak ID %d: %w", id, err)
+	}
+	return &msg, nil
+}
+
+// CountMessagesByStatus menghitung pesan kotak masuk (bukan spam) per status
+func (r *Repository) CountMessagesByStatus(ctx context.Context) (map[string]int, error) {
+	ctx, end := startQuery(ctx, "CountMessagesByStatus")
+	defer end()
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT status, COUNT(*) FROM contact_messages WHERE deleted_at IS NULL AND is_spam = 0 GROUP BY status",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("gagal menghitung pesan per status: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var status string
+		var n int
+		if err := rows.Scan(&status, &n); err != nil {
+			return nil, fmt.Errorf("gagal scan jumlah pesan: %w", err)
+		}
+		counts[status] = n
+	}
+	return counts, rows.Err()
+}
+
 // CreateContactMessage menyimpan pesan kontak baru dari pengunjung
-func (r *Repository) CreateContactMessage(msg *model.ContactMessage) error {
-	result, err := r.db.Exec(
-		"INSERT INTO contact_messages (name, email, message) VALUES (?, ?, ?)",
-		msg.Name, msg.Email, msg.Message,
+func (r *Repository) CreateContactMessage(ctx context.Context, msg *model.ContactMessage) error {
+	ctx, end := startQuery(ctx, "CreateContactMessage")
+	defer end()
+	result, err := r.db.ExecContext(ctx,
+		"INSERT INTO contact_messages (name, email, message, locale, is_spam, spam_score, spam_reasons, consent_version) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		msg.Name, msg.Email, msg.Message, msg.Locale, msg.IsSpam, msg.SpamScore, msg.SpamReasons, msg.Consent,
 	)
 	if err != nil {
 		return fmt.Errorf("gagal menyimpan pesan kontak: %w", err)
@@ -311,20 +987,1055 @@ func (r *Repository) CreateContactMessage(msg *model.ContactMessage) error {
 	return nil
 }
 
-// MarkMessageAsRead menandai pesan kontak sebagai sudah dibaca
-func (r *Repository) MarkMessageAsRead(id int) error {
-	_, err := r.db.Exec("UPDATE contact_messages SET is_read = 1 WHERE id = ?", id)
+// MarkMessageAsRead menandai pesan kontak baru sebagai sudah dibaca
+// Pesan yang sudah dibalas atau diarsipkan tidak berubah statusnya
+func (r *Repository) MarkMessageAsRead(ctx context.Context, id int) error {
+	ctx, end := startQuery(ctx, "MarkMessageAsRead")
+	defer end()
+	_, err := r.db.ExecContext(ctx, "UPDATE contact_messages SET status = ? WHERE id = ? AND status = ?", model.MessageRead, id, model.MessageNew)
 	if err != nil {
 		return fmt.Errorf("gagal menandai pesan ID %d sebagai dibaca: %w", id, err)
 	}
 	return nil
 }
 
-// DeleteContactMessage menghapus pesan kontak berdasarkan ID
-func (r *Repository) DeleteContactMessage(id int) error {
-	_, err := r.db.Exec("DELETE FROM contact_messages WHERE id = ?", id)
+// MarkMessagesAsRead menandai beberapa pesan baru sekaligus sebagai sudah dibaca
+func (r *Repository) MarkMessagesAsRead(ctx context.Context, ids []int) error {
+	ctx, end := startQuery(ctx, "MarkMessagesAsRead")
+	defer end()
+	if len(ids) == 0 {
+		return nil
+	}
+	placeholders, args := inClause(ids)
+	_, err := r.db.ExecContext(ctx,
+		"UPDATE contact_messages SET status = ? WHERE status = ? AND id IN ("+placeholders+")",
+		append([]any{model.MessageRead, model.MessageNew}, args...)...,
+	)
 	if err != nil {
-		return fmt.Errorf("gagal hapus pesan kontak ID %d: %w", id, err)
+		return fmt.Errorf("gagal menandai pesan terpilih sebagai dibaca: %w", err)
+	}
+	return nil
+}
+
+// ArchiveMessages mengarsipkan beberapa pesan sekaligus
+func (r *Repository) ArchiveMessages(ctx context.Context, ids []int) error {
+	ctx, end := startQuery(ctx, "ArchiveMessages")
+	defer end()
+	if len(ids) == 0 {
+		return nil
+	}
+	placeholders, args := inClause(ids)
+	_, err := r.db.ExecContext(ctx,
+		"UPDATE contact_messages SET status = ? WHERE id IN ("+placeholders+")",
+		append([]any{model.MessageArchived}, args...)...,
+	)
+	if err != nil {
+		return fmt.Errorf("gagal mengarsipkan pesan terpilih: %w", err)
 	}
 	return nil
 }
+
+// DeleteContactMessages memindahkan beberapa pesan sekaligus ke sampah (soft delete)
+func (r *Repository) DeleteContactMessages(ctx context.Context, ids []int) error {
+	ctx, end := startQuery(ctx, "DeleteContactMessages")
+	defer end()
+	if len(ids) == 0 {
+		return nil
+	}
+	placeholders, args := inClause(ids)
+	_, err := r.db.ExecContext(ctx,
+		"UPDATE contact_messages SET deleted_at = ? WHERE deleted_at IS NULL AND id IN ("+placeholders+")",
+		append([]any{time.Now()}, args...)...,
+	)
+	if err != nil {
+		return fmt.Errorf("gagal hapus pesan terpilih: %w", err)
+	}
+	return nil
+}
+
+// SetMessageStatus mengubah status pesan kontak (new/read/replied/archived)
+func (r *Repository) SetMessageStatus(ctx context.Context, id int, status string) error {
+	ctx, end := startQuery(ctx, "SetMessageStatus")
+	defer end()
+	_, err := r.db.ExecContext(ctx, "UPDATE contact_messages SET status = ? WHERE id = ?", status, id)
+	if err != nil {
+		return fmt.Errorf("gagal mengubah status pesan ID %d: %w", id, err)
+	}
+	return nil
+}
+
+// HasMessageReplies mengecek apakah pesan kontak sudah pernah dibalas
+func (r *Repository) HasMessageReplies(ctx context.Context, id int) (bool, error) {
+	ctx, end := startQuery(ctx, "HasMessageReplies")
+	defer end()
+	var exists bool
+	err := r.db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM message_replies WHERE message_id = ?)", id).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("gagal mengecek balasan pesan ID %d: %w", id, err)
+	}
+	return exists, nil
+}
+
+// CreateMessageReply menyimpan balasan admin beserta email-nya di antrean kirim,
+// lalu menandai pesan sebagai sudah dibalas — semuanya dalam satu transaksi
+func (r *Repository) CreateMessageReply(ctx context.Context, reply *model.MessageReply, email *model.QueuedEmail) error {
+	ctx, end := startQuery(ctx, "CreateMessageReply")
+	defer end()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi balasan: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := enqueueEmail(ctx, tx, email); err != nil {
+		return err
+	}
+	reply.EmailID = &email.ID
+
+	result, err := tx.ExecContext(ctx,
+		"INSERT INTO message_replies (message_id, body, email_id) VALUES (?, ?, ?)",
+		reply.MessageID, reply.Body, email.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("gagal menyimpan balasan pesan ID %d: %w", reply.MessageID, err)
+	}
+	id, _ := result.LastInsertId()
+	reply.ID = int(id)
+
+	if _, err := tx.ExecContext(ctx, "UPDATE contact_messages SET status = ? WHERE id = ?", model.MessageReplied, reply.MessageID); err != nil {
+		return fmt.Errorf("gagal menandai pesan ID %d sudah dibalas: %w", reply.MessageID, err)
+	}
+	return tx.Commit()
+}
+
+// GetMessageReplies mengambil thread balasan beserta status email-nya untuk pesan-pesan tertentu,
+// dikelompokkan per message ID dan urut dari yang paling lama
+func (r *Repository) GetMessageReplies(ctx context.Context, messageIDs []int) (map[int][]model.MessageReply, error) {
+	ctx, end := startQuery(ctx, "GetMessageReplies")
+	defer end()
+	replies := make(map[int][]model.MessageReply)
+	if len(messageIDs) == 0 {
+		return replies, nil
+	}
+
+	placeholders, args := inClause(messageIDs)
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT mr.id, mr.message_id, mr.body, mr.email_id, e.sent_at, e.failed_at, COALESCE(e.last_error, ''), mr.created_at
+		 FROM message_replies mr LEFT JOIN email_queue e ON e.id = mr.email_id
+		 WHERE mr.message_id IN (`+placeholders+`) ORDER BY mr.created_at, mr.id`, args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil balasan pesan: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var reply model.MessageReply
+		if err := rows.Scan(
+			&reply.ID, &reply.MessageID, &reply.Body, &reply.EmailID,
+			&reply.SentAt, &reply.FailedAt, &reply.LastError, &reply.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("gagal scan balasan pesan: %w", err)
+		}
+		replies[reply.MessageID] = append(replies[reply.MessageID], reply)
+	}
+	return replies, rows.Err()
+}
+
+// SetMessageSpam memindahkan pesan kontak ke folder spam (spam = true) atau kembali ke kotak masuk
+func (r *Repository) SetMessageSpam(ctx context.Context, id int, spam bool) error {
+	ctx, end := startQuery(ctx, "SetMessageSpam")
+	defer end()
+	_, err := r.db.ExecContext(ctx, "UPDATE contact_messages SET is_spam = ? WHERE id = ?", spam, id)
+	if err != nil {
+		return fmt.Errorf("gagal mengubah status spam pesan ID %d: %w", id, err)
+	}
+	return nil
+}
+
+// DeleteContactMessage memindahkan pesan kontak ke sampah (soft delete) berdasarkan ID
+func (r *Repository) DeleteContactMessage(ctx context.Context, id int) error {
+	ctx, end := startQuery(ctx, "DeleteContactMessage")
+	defer end()
+	_, err := r.db.ExecContext(ctx, "UPDATE contact_messages SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL", time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("gagal hapus pesan kontak ID %d: %w", id, err)
+	}
+	return nil
+}
+
+// ============================================
+// EMAIL QUEUE — Antrean Email
+// ============================================
+
+// EnqueueEmail menambahkan email ke antrean, siap dikirim pada percobaan berikutnya
+func (r *Repository) EnqueueEmail(ctx context.Context, email *model.QueuedEmail) error {
+	ctx, end := startQuery(ctx, "EnqueueEmail")
+	defer end()
+	return enqueueEmail(ctx, r.db, email)
+}
+
+// enqueueEmail menambahkan email ke antrean di dalam maupun di luar transaksi
+func enqueueEmail(ctx context.Context, q execer, email *model.QueuedEmail) error {
+	result, err := q.ExecContext(ctx,
+		`INSERT INTO email_queue (to_addr, reply_to, subject, text_body, html_body, next_attempt_at, message_id)
+		 VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		email.To, email.ReplyTo, email.Subject, email.Text, email.HTML, email.NextAttemptAt.UTC(), email.MessageID,
+	)
+	if err != nil {
+		return fmt.Errorf("gagal menambahkan email ke antrean: %w", err)
+	}
+	id, _ := result.LastInsertId()
+	email.ID = int(id)
+	return nil
+}
+
+// GetDueEmails mengambil email yang belum terkirim dan jadwal percobaannya sudah tiba
+// Diurutkan dari yang paling lama menunggu, maksimal limit email
+func (r *Repository) GetDueEmails(ctx context.Context, now time.Time, limit int) ([]model.QueuedEmail, error) {
+	ctx, end := startQuery(ctx, "GetDueEmails")
+	defer end()
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, to_addr, reply_to, subject, text_body, html_body, attempts, last_error, next_attempt_at, created_at
+		 FROM email_queue WHERE sent_at IS NULL AND failed_at IS NULL AND next_attempt_at <= ?
+		 ORDER BY next_attempt_at, id LIMIT ?`, now.UTC(), limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil antrean email: %w", err)
+	}
+	defer rows.Close()
+
+	var emails []model.QueuedEmail
+	for rows.Next() {
+		var e model.QueuedEmail
+		if err := rows.Scan(
+			&e.ID, &e.To, &e.ReplyTo, &e.Subject, &e.Text, &e.HTML,
+			&e.Attempts, &e.LastError, &e.NextAttemptAt, &e.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("gagal scan antrean email: %w", err)
+		}
+		emails = append(emails, e)
+	}
+	return emails, rows.Err()
+}
+
+// MarkEmailSent menandai email di antrean sudah terkirim
+func (r *Repository) MarkEmailSent(ctx context.Context, id int, now time.Time) error {
+	ctx, end := startQuery(ctx, "MarkEmailSent")
+	defer end()
+	_, err := r.db.ExecContext(ctx,
+		"UPDATE email_queue SET attempts = attempts + 1, last_error = '', sent_at = ? WHERE id = ?", now, id,
+	)
+	if err != nil {
+		return fmt.Errorf("gagal menandai email ID %d terkirim: %w", id, err)
+	}
+	return nil
+}
+
+// MarkEmailRetry mencatat percobaan kirim yang gagal dan menjadwalkan percobaan berikutnya
+func (r *Repository) MarkEmailRetry(ctx context.Context, id int, lastErr string, next time.Time) error {
+	ctx, end := startQuery(ctx, "MarkEmailRetry")
+	defer end()
+	_, err := r.db.ExecContext(ctx,
+		"UPDATE email_queue SET attempts = attempts + 1, last_error = ?, next_attempt_at = ? WHERE id = ?",
+		lastErr, next.UTC(), id,
+	)
+	if err != nil {
+		return fmt.Errorf("gagal menjadwalkan ulang email ID %d: %w", id, err)
+	}
+	return nil
+}
+
+// MarkEmailFailed mencatat percobaan terakhir yang gagal dan berhenti mencoba mengirim email
+func (r *Repository) MarkEmailFailed(ctx context.Context, id int, lastErr string, now time.Time) error {
+	ctx, end := startQuery(ctx, "MarkEmailFailed")
+	defer end()
+	_, err := r.db.ExecContext(ctx,
+		"UPDATE email_queue SET attempts = attempts + 1, last_error = ?, failed_at = ? WHERE id = ?",
+		lastErr, now, id,
+	)
+	if err != nil {
+		return fmt.Errorf("gagal menandai email ID %d gagal: %w", id, err)
+	}
+	return nil
+}
+
+// ============================================
+// WEBHOOKS — Webhook Keluar & Log Delivery
+// ============================================
+
+// webhookColumns adalah kolom tabel webhooks dengan urutan sesuai scanWebhook
+const webhookColumns = "id, name, url, secret, events, format, chat_id, active, created_at, updated_at"
+
+// scanWebhook membaca satu baris webhooks (kolom sesuai webhookColumns)
+func scanWebhook(row rowScanner) (model.Webhook, error) {
+	var w model.Webhook
+	var events string
+	err := row.Scan(&w.ID, &w.Name, &w.URL, &w.Secret, &events, &w.Format, &w.ChatID, &w.Active, &w.CreatedAt, &w.UpdatedAt)
+	if events != "" {
+		w.Events = strings.Split(events, ",")
+	}
+	return w, err
+}
+
+// GetAllWebhooks mengambil semua webhook, urut sesuai waktu dibuat
+func (r *Repository) GetAllWebhooks(ctx context.Context) ([]model.Webhook, error) {
+	ctx, end := startQuery(ctx, "GetAllWebhooks")
+	defer end()
+	rows, err := r.db.QueryContext(ctx, "SELECT "+webhookColumns+" FROM webhooks ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var webhooks []model.Webhook
+	for rows.Next() {
+		w, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("gagal scan webhook: %w", err)
+		}
+		webhooks = append(webhooks, w)
+	}
+	return webhooks, rows.Err()
+}
+
+// GetWebhookByID mengambil satu webhook berdasarkan ID
+func (r *Repository) GetWebhookByID(ctx context.Context, id int) (*model.Webhook, error) {
+	ctx, end := startQuery(ctx, "GetWebhookByID")
+	defer end()
+	w, err := scanWebhook(r.db.QueryRowContext(ctx, "SELECT "+webhookColumns+" FROM webhooks WHERE id = ?", id))
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil webhook ID %d: %w", id, err)
+	}
+	return &w, nil
+}
+
+// CreateWebhook menyimpan webhook baru
+func (r *Repository) CreateWebhook(ctx context.Context, w *model.Webhook) error {
+	ctx, end := startQuery(ctx, "CreateWebhook")
+	defer end()
+	result, err := r.db.ExecContext(ctx,
+		"INSERT INTO webhooks (name, url, secret, events, format, chat_id, active) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		w.Name, w.URL, w.Secret, strings.Join(w.Events, ","), w.Format, w.ChatID, w.Active,
+	)
+	if err != nil {
+		return fmt.Errorf("gagal menyimpan webhook: %w", err)
+	}
+	id, _ := result.LastInsertId()
+	w.ID = int(id)
+	return nil
+}
+
+// UpdateWebhook memperbarui pengaturan webhook
+func (r *Repository) UpdateWebhook(ctx context.Context, w *model.Webhook) error {
+	ctx, end := startQuery(ctx, "UpdateWebhook")
+	defer end()
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE webhooks SET name = ?, url = ?, secret = ?, events = ?, format = ?, chat_id = ?, active = ?, updated_at = ?
+		 WHERE id = ?`,
+		w.Name, w.URL, w.Secret, strings.Join(w.Events, ","), w.Format, w.ChatID, w.Active, time.Now(), w.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("gagal update webhook ID %d: %w", w.ID, err)
+	}
+	return nil
+}
+
+// DeleteWebhook menghapus webhook permanen beserta log delivery-nya (ON DELETE CASCADE)
+func (r *Repository) DeleteWebhook(ctx context.Context, id int) error {
+	ctx, end := startQuery(ctx, "DeleteWebhook")
+	defer end()
+	_, err := r.db.ExecContext(ctx, "DELETE FROM webhooks WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("gagal hapus webhook ID %d: %w", id, err)
+	}
+	return nil
+}
+
+// deliveryColumns adalah kolom log delivery (dengan nama webhook) sesuai scanWebhookDelivery
+const deliveryColumns = `d.id, d.webhook_id, w.name, d.event, d.payload, d.attempts, d.status_code, d.response,
+	d.last_error, d.next_attempt_at, d.delivered_at, d.failed_at, d.message_id, d.created_at`
+
+// scanWebhookDelivery membaca satu baris log delivery (kolom sesuai deliveryColumns)
+func scanWebhookDelivery(row rowScanner) (model.WebhookDelivery, error) {
+	var d model.WebhookDelivery
+	err := row.Scan(
+		&d.ID, &d.WebhookID, &d.WebhookName, &d.Event, &d.Payload, &d.Attempts, &d.StatusCode, &d.Response,
+		&d.LastError, &d.NextAttemptAt, &d.DeliveredAt, &d.FailedAt, &d.MessageID, &d.CreatedAt,
+	)
+	return d, err
+}
+
+// queryWebhookDeliveries menjalankan query log delivery dan men-scan semua barisnya
+func (r *Repository) queryWebhookDeliveries(ctx context.Context, where string, args ...any) ([]model.WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT "+deliveryColumns+" FROM webhook_deliveries d JOIN webhooks w ON w.id = d.webhook_id "+where, args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil log webhook: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []model.WebhookDelivery
+	for rows.Next() {
+		d, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("gagal scan log webhook: %w", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+// GetRecentWebhookDeliveries mengambil log delivery terbaru, maksimal limit baris
+func (r *Repository) GetRecentWebhookDeliveries(ctx context.Context, limit int) ([]model.WebhookDelivery, error) {
+	ctx, end := startQuery(ctx, "GetRecentWebhookDeliveries")
+	defer end()
+	return r.queryWebhookDeliveries(ctx, "ORDER BY d.id DESC LIMIT ?", limit)
+}
+
+// GetDueWebhookDeliveries mengambil delivery yang belum terkirim dan jadwal percobaannya sudah tiba
+func (r *Repository) GetDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]model.WebhookDelivery, error) {
+	ctx, end := startQuery(ctx, "GetDueWebhookDeliveries")
+	defer end()
+	return r.queryWebhookDeliveries(ctx,
+		"WHERE d.delivered_at IS NULL AND d.failed_at IS NULL AND d.next_attempt_at <= ? ORDER BY d.next_attempt_at, d.id LIMIT ?",
+		now.UTC(), limit,
+	)
+}
+
+// GetWebhookDeliveryByID mengambil satu log delivery berdasarkan ID
+func (r *Repository) GetWebhookDeliveryByID(ctx context.Context, id int) (*model.WebhookDelivery, error) {
+	ctx, end := startQuery(ctx, "GetWebhookDeliveryByID")
+	defer end()
+	d, err := scanWebhookDelivery(r.db.QueryRowContext(ctx,
+		"SELECT "+deliveryColumns+" FROM webhook_deliveries d JOIN webhooks w ON w.id = d.webhook_id WHERE d.id = ?", id,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil log webhook ID %d: %w", id, err)
+	}
+	return &d, nil
+}
+
+// CreateWebhookDelivery mencatat delivery baru yang siap dikirim
+func (r *Repository) CreateWebhookDelivery(ctx context.Context, d *model.WebhookDelivery) error {
+	ctx, end := startQuery(ctx, "CreateWebhookDelivery")
+	defer end()
+	result, err := r.db.ExecContext(ctx,
+		"INSERT INTO webhook_deliveries (webhook_id, event, payload, next_attempt_at, message_id) VALUES (?, ?, ?, ?, ?)",
+		d.WebhookID, d.Event, d.Payload, d.NextAttemptAt.UTC(), d.MessageID,
+	)
+	if err != nil {
+		return fmt.Errorf("gagal mencatat delivery webhook: %w", err)
+	}
+	id, _ := result.LastInsertId()
+	d.ID = int(id)
+	return nil
+}
+
+// MarkWebhookDelivered mencatat percobaan yang berhasil beserta respons endpoint
+func (r *Repository) MarkWebhookDelivered(ctx context.Context, id, statusCode int, response string, now time.Time) error {
+	ctx, end := startQuery(ctx, "MarkWebhookDelivered")
+	defer end()
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE webhook_deliveries SET attempts = attempts + 1, status_code = ?, response = ?, last_error = '', delivered_at = ?
+		 WHERE id = ?`,
+		statusCode, response, now, id,
+	)
+	if err != nil {
+		return fmt.Errorf("gagal menandai delivery webhook ID %d terkirim: %w", id, err)
+	}
+	return nil
+}
+
+// MarkWebhookRetry mencatat percobaan yang gagal dan menjadwalkan percobaan berikutnya
+func (r *Repository) MarkWebhookRetry(ctx context.Context, id, statusCode int, response, lastErr string, next time.Time) error {
+	ctx, end := startQuery(ctx, "MarkWebhookRetry")
+	defer end()
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE webhook_deliveries SET attempts = attempts + 1, status_code = ?, response = ?, last_error = ?, next_attempt_at = ?
+		 WHERE id = ?`,
+		statusCode, response, lastErr, next.UTC(), id,
+	)
+	if err != nil {
+		return fmt.Errorf("gagal menjadwalkan ulang delivery webhook ID %d: %w", id, err)
+	}
+	return nil
+}
+
+// MarkWebhookFailed mencatat percobaan terakhir yang gagal dan berhenti mencoba mengirim
+func (r *Repository) MarkWebhookFailed(ctx context.Context, id, statusCode int, response, lastErr string, now time.Time) error {
+	ctx, end := startQuery(ctx, "MarkWebhookFailed")
+	defer end()
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE webhook_deliveries SET attempts = attempts + 1, status_code = ?, response = ?, last_error = ?, failed_at = ?
+		 WHERE id = ?`,
+		statusCode, response, lastErr, now, id,
+	)
+	if err != nil {
+		return fmt.Errorf("gagal menandai delivery webhook ID %d gagal: %w", id, err)
+	}
+	return nil
+}
+
+// ============================================
+// PUBLISHING — Jadwal Terbit
+// ============================================
+
+// publishTables adalah tabel konten yang mendukung draft & jadwal terbit
+var publishTables = []string{"experiences", "projects", "tech_stacks"}
+
+// PublishScheduled menerbitkan semua konten terjadwal yang publish_at-nya sudah lewat
+// Mengembalikan jumlah baris yang diterbitkan dari seluruh tabel
+func (r *Repository) PublishScheduled(ctx context.Context, now time.Time) (int, error) {
+	ctx, end := startQuery(ctx, "PublishScheduled")
+	defer end()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("gagal memulai transaksi publish: %w", err)
+	}
+	defer tx.Rollback()
+
+	total := 0
+	for _, table := range publishTables {
+		result, err := tx.ExecContext(ctx,
+			"UPDATE "+table+" SET published = 1, publish_at = NULL, updated_at = ? WHERE published = 0 AND publish_at IS NOT NULL AND publish_at <= ? AND deleted_at IS NULL",
+			now, now.UTC(),
+		)
+		if err != nil {
+			return 0, fmt.Errorf("gagal menerbitkan konten terjadwal di %s: %w", table, err)
+		}
+		n, _ := result.RowsAffected()
+		total += int(n)
+	}
+	return total, tx.Commit()
+}
+
+// ============================================
+// TRASH — Sampah (Soft Delete)
+// ============================================
+
+// trashTables memetakan jenis konten di URL dashboard ke tabel dan kolom judulnya
+var trashTables = map[string]struct{ table, title string }{
+	model.TrashExperience: {"experiences", "role || ' @ ' || company"},
+	model.TrashProject:    {"projects", "title"},
+	model.TrashTechStack:  {"tech_stacks", "name"},
+	model.TrashMessage:    {"contact_messages", "name || ' <' || email || '>'"},
+}
+
+// GetTrash mengambil semua konten di sampah dari seluruh tabel, terbaru duluan
+func (r *Repository) GetTrash(ctx context.Context) ([]model.TrashItem, error) {
+	ctx, end := startQuery(ctx, "GetTrash")
+	defer end()
+	var parts []string
+	for _, entity := range model.TrashEntities {
+		t := trashTables[entity]
+		parts = append(parts, fmt.Sprintf(
+			"SELECT '%s', id, %s, deleted_at FROM %s WHERE deleted_at IS NOT NULL", entity, t.title, t.table,
+		))
+	}
+
+	rows, err := r.db.QueryContext(ctx, strings.Join(parts, " UNION ALL ")+" ORDER BY 4 DESC")
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil isi sampah: %w", err)
+	}
+	defer rows.Close()
+
+	var items []model.TrashItem
+	for rows.Next() {
+		var item model.TrashItem
+		if err := rows.Scan(&item.Entity, &item.ID, &item.Title, &item.DeletedAt); err != nil {
+			return nil, fmt.Errorf("gagal scan isi sampah: %w", err)
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// RestoreFromTrash memulihkan konten dari sampah
+func (r *Repository) RestoreFromTrash(ctx context.Context, entity string, id int) error {
+	ctx, end := startQuery(ctx, "RestoreFromTrash")
+	defer end()
+	t, ok := trashTables[entity]
+	if !ok {
+		return fmt.Errorf("jenis konten sampah tidak dikenal: %s", entity)
+	}
+	result, err := r.db.ExecContext(ctx, "UPDATE "+t.table+" SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL", id)
+	if err != nil {
+		return fmt.Errorf("gagal memulihkan %s ID %d: %w", entity, id, err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("%s ID %d tidak ada di sampah", entity, id)
+	}
+	return nil
+}
+
+// PurgeFromTrash menghapus permanen satu konten yang sudah ada di sampah
+// Relasi (misal: project_tags) ikut terhapus lewat ON DELETE CASCADE
+func (r *Repository) PurgeFromTrash(ctx context.Context, entity string, id int) error {
+	ctx, end := startQuery(ctx, "PurgeFromTrash")
+	defer end()
+	t, ok := trashTables[entity]
+	if !ok {
+		return fmt.Errorf("jenis konten sampah tidak dikenal: %s", entity)
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi hapus permanen: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM "+t.table+" WHERE id = ? AND deleted_at IS NOT NULL", id)
+	if err != nil {
+		return fmt.Errorf("gagal hapus permanen %s ID %d: %w", entity, id, err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("%s ID %d tidak ada di sampah", entity, id)
+	}
+	// Riwayat revisi dan terjemahan tidak berguna lagi setelah kontennya hilang
+	for _, table := range []string{"revisions", "translations"} {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM "+table+" WHERE entity = ? AND entity_key = ?", entity, strconv.Itoa(id)); err != nil {
+			return fmt.Errorf("gagal hapus %s %s ID %d: %w", table, entity, id, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// PurgeTrashBefore menghapus permanen semua konten yang masuk sampah sebelum cutoff
+// Mengembalikan jumlah baris yang dihapus dari seluruh tabel
+func (r *Repository) PurgeTrashBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	ctx, end := startQuery(ctx, "PurgeTrashBefore")
+	defer end()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("gagal memulai transaksi purge sampah: %w", err)
+	}
+	defer tx.Rollback()
+
+	total := 0
+	for _, entity := range model.TrashEntities {
+		t := trashTables[entity]
+		for _, related := range []string{"revisions", "translations"} {
+			if _, err := tx.ExecContext(ctx,
+				"DELETE FROM "+related+" WHERE entity = ? AND entity_key IN (SELECT CAST(id AS TEXT) FROM "+t.table+" WHERE deleted_at IS NOT NULL AND deleted_at < ?)",
+				entity, cutoff,
+			); err != nil {
+				return 0, fmt.Errorf("gagal purge %s %s: %w", related, t.table, err)
+			}
+		}
+		result, err := tx.ExecContext(ctx, "DELETE FROM "+t.table+" WHERE deleted_at IS NOT NULL AND deleted_at < ?", cutoff)
+		if err != nil {
+			return 0, fmt.Errorf("gagal purge sampah %s: %w", t.table, err)
+		}
+		n, _ := result.RowsAffected()
+		total += int(n)
+	}
+	return total, tx.Commit()
+}
+
+// ============================================
+// PRIVACY — Retensi & Permintaan Privasi Pengunjung
+// ============================================
+
+// ErasePersonalData menghapus (atau menganonimkan, jika anonymize) semua data milik
+// pemilik alamat email: pesan kontak (termasuk spam & sampah), balasan, email di antrean,
+// dan log delivery webhook. emails berisi variasi penulisan alamat yang sama (asli dan
+// ter-escape). Jumlah data terdampak diisi ke req yang dicatat di log privasi dalam
+// transaksi yang sama
+func (r *Repository) ErasePersonalData(ctx context.Context, req *model.PrivacyRequest, emails []string, anonymize bool) error {
+	ctx, end := startQuery(ctx, "ErasePersonalData")
+	defer end()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi penghapusan data: %w", err)
+	}
+	defer tx.Rollback()
+
+	addrs, addrArgs := inClause(emails)
+
+	var ids []int
+	rows, err := tx.QueryContext(ctx, "SELECT id FROM contact_messages WHERE email COLLATE NOCASE IN ("+addrs+")", addrArgs...)
+	if err != nil {
+		return fmt.Errorf("gagal mencari pesan pemohon: %w", err)
+	}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return fmt.Errorf("gagal scan ID pesan pemohon: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("gagal mencari pesan pemohon: %w", err)
+	}
+	placeholders, idArgs := inClause(ids)
+
+	// Email antrean: yang tertaut ke pesan, atau dikirim ke/atas nama alamat ini (data lama)
+	result, err := tx.ExecContext(ctx,
+		"DELETE FROM email_queue WHERE message_id IN ("+placeholders+") OR to_addr COLLATE NOCASE IN ("+addrs+") OR reply_to COLLATE NOCASE IN ("+addrs+")",
+		slices.Concat(idArgs, addrArgs, addrArgs)...,
+	)
+	if err != nil {
+		return fmt.Errorf("gagal menghapus email pemohon: %w", err)
+	}
+	req.Emails = rowsAffected(result)
+
+	// Log delivery: yang tertaut ke pesan, atau payload-nya memuat alamat ini (data lama)
+	contains := strings.Repeat(" OR instr(lower(payload), lower(?)) > 0", len(emails))
+	result, err = tx.ExecContext(ctx, "DELETE FROM webhook_deliveries WHERE message_id IN ("+placeholders+")"+contains, slices.Concat(idArgs, addrArgs)...)
+	if err != nil {
+		return fmt.Errorf("gagal menghapus log webhook pemohon: %w", err)
+	}
+	req.Deliveries = rowsAffected(result)
+
+	if anonymize {
+		result, err = tx.ExecContext(ctx, "UPDATE message_replies SET body = ? WHERE message_id IN ("+placeholders+")", append([]any{model.Anonymized}, idArgs...)...)
+		if err != nil {
+			return fmt.Errorf("gagal menganonimkan balasan pemohon: %w", err)
+		}
+		req.Replies = rowsAffected(result)
+		result, err = tx.ExecContext(ctx,
+			"UPDATE contact_messages SET name = ?, email = '', message = ?, spam_reasons = '' WHERE id IN ("+placeholders+")",
+			append([]any{model.Anonymized, model.Anonymized}, idArgs...)...,
+		)
+		if err != nil {
+			return fmt.Errorf("gagal menganonimkan pesan pemohon: %w", err)
+		}
+		req.Messages = rowsAffected(result)
+	} else {
+		if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM message_replies WHERE message_id IN ("+placeholders+")", idArgs...).Scan(&req.Replies); err != nil {
+			return fmt.Errorf("gagal menghitung balasan pemohon: %w", err)
+		}
+		// Balasan ikut terhapus lewat ON DELETE CASCADE
+		result, err = tx.ExecContext(ctx, "DELETE FROM contact_messages WHERE id IN ("+placeholders+")", idArgs...)
+		if err != nil {
+			return fmt.Errorf("gagal menghapus pesan pemohon: %w", err)
+		}
+		req.Messages = rowsAffected(result)
+	}
+
+	if err := createPrivacyRequest(ctx, tx, req); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// PurgeMessagesBefore menghapus permanen pesan kontak yang diterima sebelum cutoff
+// beserta balasan, email antrean, dan log delivery webhook yang dibuat sebelum cutoff
+// atau tertaut ke pesan tersebut. Jika ada data terhapus, req (kind retention) diisi
+// jumlahnya dan dicatat di log privasi
+func (r *Repository) PurgeMessagesBefore(ctx context.Context, req *model.PrivacyRequest, cutoff time.Time) error {
+	ctx, end := startQuery(ctx, "PurgeMessagesBefore")
+	defer end()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi retensi pesan: %w", err)
+	}
+	defer tx.Rollback()
+
+	// created_at disimpan CURRENT_TIMESTAMP ("YYYY-MM-DD HH:MM:SS" UTC)
+	before := cutoff.UTC().Format("2006-01-02 15:04:05")
+	expired := "SELECT id FROM contact_messages WHERE created_at < ?"
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM email_queue WHERE created_at < ? OR message_id IN ("+expired+")", before, before)
+	if err != nil {
+		return fmt.Errorf("gagal purge email lama: %w", err)
+	}
+	req.Emails = rowsAffected(result)
+
+	result, err = tx.ExecContext(ctx, "DELETE FROM webhook_deliveries WHERE created_at < ? OR message_id IN ("+expired+")", before, before)
+	if err != nil {
+		return fmt.Errorf("gagal purge log webhook lama: %w", err)
+	}
+	req.Deliveries = rowsAffected(result)
+
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM message_replies WHERE message_id IN ("+expired+")", before).Scan(&req.Replies); err != nil {
+		return fmt.Errorf("gagal menghitung balasan lama: %w", err)
+	}
+	result, err = tx.ExecContext(ctx, "DELETE FROM contact_messages WHERE created_at < ?", before)
+	if err != nil {
+		return fmt.Errorf("gagal purge pesan lama: %w", err)
+	}
+	req.Messages = rowsAffected(result)
+
+	if req.Affected() {
+		if err := createPrivacyRequest(ctx, tx, req); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// GetPrivacyRequests mengambil log permintaan privasi terbaru, maksimal limit baris
+func (r *Repository) GetPrivacyRequests(ctx context.Context, limit int) ([]model.PrivacyRequest, error) {
+	ctx, end := startQuery(ctx, "GetPrivacyRequests")
+	defer end()
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, kind, subject_hash, subject_hint, note, messages, replies, emails, deliveries, created_at
+		 FROM privacy_requests ORDER BY id DESC LIMIT ?`, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil log privasi: %w", err)
+	}
+	defer rows.Close()
+
+	var requests []model.PrivacyRequest
+	for rows.Next() {
+		var p model.PrivacyRequest
+		if err := rows.Scan(
+			&p.ID, &p.Kind, &p.SubjectHash, &p.SubjectHint, &p.Note,
+			&p.Messages, &p.Replies, &p.Emails, &p.Deliveries, &p.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("gagal scan log privasi: %w", err)
+		}
+		requests = append(requests, p)
+	}
+	return requests, rows.Err()
+}
+
+// createPrivacyRequest mencatat satu entri log privasi
+func createPrivacyRequest(ctx context.Context, q execer, req *model.PrivacyRequest) error {
+	result, err := q.ExecContext(ctx,
+		`INSERT INTO privacy_requests (kind, subject_hash, subject_hint, note, messages, replies, emails, deliveries)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		req.Kind, req.SubjectHash, req.SubjectHint, req.Note, req.Messages, req.Replies, req.Emails, req.Deliveries,
+	)
+	if err != nil {
+		return fmt.Errorf("gagal mencatat log privasi: %w", err)
+	}
+	id, _ := result.LastInsertId()
+	req.ID = int(id)
+	return nil
+}
+
+// rowsAffected mengembalikan jumlah baris yang diubah query (0 jika driver tidak mendukung)
+func rowsAffected(result sql.Result) int {
+	n, _ := result.RowsAffected()
+	return int(n)
+}
+
+// ============================================
+// CSP REPORTS — Laporan Pelanggaran Content Security Policy
+// ============================================
+
+// SaveCSPReport menyimpan laporan pelanggaran CSP. Laporan yang sama persis hanya
+// menaikkan penghitung, lalu tabel dipangkas menjadi keep laporan terbaru agar tidak
+// membengkak oleh laporan dari browser/ekstensi yang berisik
+func (r *Repository) SaveCSPReport(ctx context.Context, report *model.CSPReport, keep int) error {
+	ctx, end := startQuery(ctx, "SaveCSPReport")
+	defer end()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi laporan CSP: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO csp_reports (document_uri, directive, blocked_uri, source_file, line_number, sample, disposition)
+		 VALUES (?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT (document_uri, directive, blocked_uri, source_file, line_number, disposition)
+		 DO UPDATE SET count = count + 1, sample = excluded.sample, last_seen = CURRENT_TIMESTAMP`,
+		report.DocumentURI, report.Directive, report.BlockedURI, report.SourceFile,
+		report.LineNumber, report.Sample, report.Disposition,
+	); err != nil {
+		return fmt.Errorf("gagal menyimpan laporan CSP: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		"DELETE FROM csp_reports WHERE id NOT IN (SELECT id FROM csp_reports ORDER BY last_seen DESC, id DESC LIMIT ?)", keep,
+	); err != nil {
+		return fmt.Errorf("gagal memangkas laporan CSP: %w", err)
+	}
+	return tx.Commit()
+}
+
+// GetCSPReports mengambil laporan pelanggaran CSP, yang terakhir terjadi duluan
+func (r *Repository) GetCSPReports(ctx context.Context, limit int) ([]model.CSPReport, error) {
+	ctx, end := startQuery(ctx, "GetCSPReports")
+	defer end()
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, document_uri, directive, blocked_uri, source_file, line_number, sample, disposition, count, first_seen, last_seen
+		 FROM csp_reports ORDER BY last_seen DESC, id DESC LIMIT ?`, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil laporan CSP: %w", err)
+	}
+	defer rows.Close()
+
+	var reports []model.CSPReport
+	for rows.Next() {
+		var p model.CSPReport
+		if err := rows.Scan(
+			&p.ID, &p.DocumentURI, &p.Directive, &p.BlockedURI, &p.SourceFile, &p.LineNumber,
+			&p.Sample, &p.Disposition, &p.Count, &p.FirstSeen, &p.LastSeen,
+		); err != nil {
+			return nil, fmt.Errorf("gagal scan laporan CSP: %w", err)
+		}
+		reports = append(reports, p)
+	}
+	return reports, rows.Err()
+}
+
+// ClearCSPReports menghapus semua laporan pelanggaran CSP (setelah ditinjau admin)
+func (r *Repository) ClearCSPReports(ctx context.Context) error {
+	ctx, end := startQuery(ctx, "ClearCSPReports")
+	defer end()
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM csp_reports"); err != nil {
+		return fmt.Errorf("gagal menghapus laporan CSP: %w", err)
+	}
+	return nil
+}
+
+// ============================================
+// REVISIONS — Riwayat Perubahan Konten
+// ============================================
+
+// saveRevision menyimpan snapshot (JSON) konten sebelum diupdate
+// Dipanggil di dalam transaksi update agar snapshot dan perubahan selalu konsisten
+func saveRevision(ctx context.Context, tx *sql.Tx, entity, key string, snapshot any) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("gagal encode revisi %s %s: %w", entity, key, err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		"INSERT INTO revisions (entity, entity_key, snapshot) VALUES (?, ?, ?)", entity, key, string(data),
+	); err != nil {
+		return fmt.Errorf("gagal menyimpan revisi %s %s: %w", entity, key, err)
+	}
+	return nil
+}
+
+// GetRevisions mengambil semua revisi satu konten, terbaru duluan
+func (r *Repository) GetRevisions(ctx context.Context, entity, key string) ([]model.Revision, error) {
+	ctx, end := startQuery(ctx, "GetRevisions")
+	defer end()
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT id, entity, entity_key, snapshot, created_at FROM revisions WHERE entity = ? AND entity_key = ? ORDER BY id DESC",
+		entity, key,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil revisi %s %s: %w", entity, key, err)
+	}
+	defer rows.Close()
+
+	var revisions []model.Revision
+	for rows.Next() {
+		var rev model.Revision
+		if err := rows.Scan(&rev.ID, &rev.Entity, &rev.EntityKey, &rev.Snapshot, &rev.CreatedAt); err != nil {
+			return nil, fmt.Errorf("gagal scan revisi: %w", err)
+		}
+		revisions = append(revisions, rev)
+	}
+	return revisions, rows.Err()
+}
+
+// GetRevisionByID mengambil satu revisi berdasarkan ID
+func (r *Repository) GetRevisionByID(ctx context.Context, id int) (*model.Revision, error) {
+	ctx, end := startQuery(ctx, "GetRevisionByID")
+	defer end()
+	var rev model.Revision
+	err := r.db.QueryRowContext(ctx,
+		"SELECT id, entity, entity_key, snapshot, created_at FROM revisions WHERE id = ?", id,
+	).Scan(&rev.ID, &rev.Entity, &rev.EntityKey, &rev.Snapshot, &rev.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil revisi ID %d: %w", id, err)
+	}
+	return &rev, nil
+}
+
+// ============================================
+// TRANSLATIONS — Terjemahan Konten
+// ============================================
+
+// GetTranslations mengambil semua terjemahan konten untuk satu locale
+func (r *Repository) GetTranslations(ctx context.Context, locale string) (model.Translations, error) {
+	ctx, end := startQuery(ctx, "GetTranslations")
+	defer end()
+	rows, err := r.db.QueryContext(ctx, "SELECT entity, entity_key, field, value FROM translations WHERE locale = ?", locale)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil terjemahan %s: %w", locale, err)
+	}
+	defer rows.Close()
+
+	translations := make(model.Translations)
+	for rows.Next() {
+		var entity, key, field, value string
+		if err := rows.Scan(&entity, &key, &field, &value); err != nil {
+			return nil, fmt.Errorf("gagal scan terjemahan: %w", err)
+		}
+		translations[model.TranslationKey(entity, key, field)] = value
+	}
+	return translations, rows.Err()
+}
+
+// SaveTranslations menyimpan terjemahan beberapa field satu konten sekaligus
+// Field dengan nilai kosong dihapus agar kembali memakai teks bahasa Indonesia
+func (r *Repository) SaveTranslations(ctx context.Context, entity, key, locale string, values map[string]string) error {
+	ctx, end := startQuery(ctx, "SaveTranslations")
+	defer end()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("gagal memulai transaksi terjemahan: %w", err)
+	}
+	defer tx.Rollback()
+
+	for field, value := range values {
+		if value == "" {
+			_, err = tx.ExecContext(ctx,
+				"DELETE FROM translations WHERE entity = ? AND entity_key = ? AND field = ? AND locale = ?",
+				entity, key, field, locale,
+			)
+		} else {
+			_, err = tx.ExecContext(ctx,
+				`INSERT INTO translations (entity, entity_key, field, locale, value, updated_at)
+				 VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+				 ON CONFLICT(entity, entity_key, field, locale) DO UPDATE SET value = excluded.value, updated_at = CURRENT_TIMESTAMP`,
+				entity, key, field, locale, value,
+			)
+		}
+		if err != nil {
+			return fmt.Errorf("gagal menyimpan terjemahan %s %s.%s (%s): %w", entity, key, field, locale, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// ============================================
+// HELPER FUNCTIONS
+// ============================================
+
+// nullIntPtr mengubah sql.NullInt64 menjadi *int (nil jika NULL)
+func nullIntPtr(ni sql.NullInt64) *int {
+	if !ni.Valid {
+		return nil
+	}
+	v := int(ni.Int64)
+	return &v
+}
+
+// nullTimePtr mengubah sql.NullTime menjadi *time.Time (nil jika NULL)
+func nullTimePtr(nt sql.NullTime) *time.Time {
+	if !nt.Valid {
+		return nil
+	}
+	return &nt.Time
+}
+
+// inClause membuat placeholder "?,?,?" dan argumen query untuk klausa IN
+func inClause[T any](values []T) (string, []any) {
+	args := make([]any, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+	return strings.TrimSuffix(strings.Repeat("?,", len(values)), ","), args
+}
+
+// searchWord mencocokkan satu kata (huruf/angka) di kata kunci pencarian
+var searchWord = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// searchTerms memecah kata kunci pencarian menjadi kata-kata huruf kecil.
+// Tanda baca dibuang sehingga aman dipakai di query FTS5 maupun LIKE
+func searchTerms(query string) []string {
+	return searchWord.FindAllString(strings.ToLower(query), 10)
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"portofolio-go/internal/database"
+	"portofolio-go/internal/model"
+	"slices"
+	"testing"
+)
+
+// newSearchTestDB membuat database sementara berisi beberapa pesan kontak.
+// Test pemanggil harus sudah pindah ke root repo (migrations/ dibaca relatif dari sana)
+func newSearchTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := database.InitDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	repo := NewRepository(db)
+	for _, msg := range []model.ContactMessage{
+		{Name: "Budi Santoso", Email: "budi@gmail.com", Message: "Halo, saya tertarik dengan proyek dashboard Anda"},
+		{Name: "Siti Aminah", Email: "siti@kantor.co.id", Message: "Apakah tersedia untuk kontrak backend Golang?"},
+		{Name: "John Smith", Email: "john@example.com", Message: "Interested in your Golang experience"},
+		{Name: "Bot", Email: "bot@spam.test", Message: "Golang murah", IsSpam: true},
+	} {
+		if err := repo.CreateContactMessage(context.Background(), &msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return db
+}
+
+// searchPaths mengembalikan Repository untuk setiap jalur pencarian: LIKE selalu,
+// FTS5 hanya jika binary test di-build dengan -tags sqlite_fts5
+func searchPaths(t *testing.T, db *sql.DB) map[string]*Repository {
+	t.Helper()
+	paths := map[string]*Repository{"like": {db: db}}
+	if repo := NewRepository(db); repo.fts {
+		paths["fts5"] = repo
+	} else {
+		t.Log("SQLite tanpa FTS5, jalur MATCH tidak diuji (jalankan go test -tags sqlite_fts5)")
+	}
+	return paths
+}
+
+// searchNames mencari pesan dengan kata kunci query dan mengembalikan nama pengirimnya (terurut)
+func searchNames(t *testing.T, repo *Repository, query string) []string {
+	t.Helper()
+	messages, total, err := repo.SearchContactMessages(context.Background(), model.MessageFilter{Query: query, Page: 1, PerPage: 50})
+	if err != nil {
+		t.Fatalf("SearchContactMessages(%q): %v", query, err)
+	}
+	if total != len(messages) {
+		t.Errorf("SearchContactMessages(%q): total = %d, len = %d", query, total, len(messages))
+	}
+	names := make([]string, len(messages))
+	for i, msg := range messages {
+		names[i] = msg.Name
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestSearchContactMessages(t *testing.T) {
+	t.Chdir("../..")
+	db := newSearchTestDB(t)
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"Budi Santoso", "John Smith", "Siti Aminah"}},
+		{"golang", []string{"John Smith", "Siti Aminah"}}, // Spam tidak ikut
+		{"GOLANG", []string{"John Smith", "Siti Aminah"}},
+		{"bud", []string{"Budi Santoso"}},                   // Awalan kata
+		{"gmail", []string{"Budi Santoso"}},                 // Bagian email
+		{"golang kontrak", []string{"Siti Aminah"}},         // Semua kata harus cocok
+		{"siti@kantor.co.id", []string{"Siti Aminah"}},      // Tanda baca dibuang
+		{`"golang" OR budi`, nil},                           // Sintaks FTS5 tidak ditafsirkan
+		{"(golang*", []string{"John Smith", "Siti Aminah"}}, // Operator FTS5 dibuang
+		{"kubernetes", nil},
+	}
+	for name, repo := range searchPaths(t, db) {
+		for _, tt := range tests {
+			if got := searchNames(t, repo, tt.query); !slices.Equal(got, tt.want) {
+				t.Errorf("%s: cari %q = %v, want %v", name, tt.query, got, tt.want)
+			}
+		}
+	}
+}
+
+// TestMessageSearchIndexSync memastikan hasil pencarian (dan indeks FTS5 yang dijaga
+// trigger di internal/database/search.go) mengikuti perubahan, anonimisasi, dan
+// penghapusan pesan
+func TestMessageSearchIndexSync(t *testing.T) {
+	t.Chdir("../..")
+	for name := range searchPaths(t, newSearchTestDB(t)) {
+		t.Run(name, func(t *testing.T) {
+			db := newSearchTestDB(t)
+			repo := &Repository{db: db, fts: name == "fts5"}
+			ctx := context.Background()
+
+			// checkIndex memverifikasi isi indeks FTS5 sama persis dengan contact_messages
+			checkIndex := func(step string) {
+				t.Helper()
+				if name != "fts5" {
+					return
+				}
+				if _, err := db.Exec("INSERT INTO contact_messages_fts (contact_messages_fts, rank) VALUES ('integrity-check', 1)"); err != nil {
+					t.Fatalf("%s: indeks FTS5 tidak sinkron: %v", step, err)
+				}
+			}
+			expect := func(step, query string, want ...string) {
+				t.Helper()
+				if got := searchNames(t, repo, query); !slices.Equal(got, want) {
+					t.Errorf("%s: cari %q = %v, want %v", step, query, got, want)
+				}
+			}
+			checkIndex("awal")
+
+			// Ubah isi pesan: kata lama hilang dari hasil, kata baru ikut
+			if _, err := db.Exec("UPDATE contact_messages SET message = 'Butuh bantuan migrasi PostgreSQL' WHERE name = 'John Smith'"); err != nil {
+				t.Fatal(err)
+			}
+			checkIndex("update")
+			expect("update", "golang", "Siti Aminah")
+			expect("update", "postgres", "John Smith")
+
+			// Perubahan kolom lain (status) tidak menyentuh indeks
+			if err := repo.MarkMessagesAsRead(ctx, []int{1, 2, 3}); err != nil {
+				t.Fatal(err)
+			}
+			checkIndex("status")
+			expect("status", "dashboard", "Budi Santoso")
+
+			// Anonimisasi: nama, email, dan isi pesan tidak bisa ditemukan lagi
+			if err := repo.ErasePersonalData(ctx, &model.PrivacyRequest{Kind: model.PrivacyAnonymize}, []string{"budi@gmail.com"}, true); err != nil {
+				t.Fatal(err)
+			}
+			checkIndex("anonymize")
+			for _, query := range []string{"budi", "gmail", "dashboard"} {
+				expect("anonymize", query)
+			}
+			expect("anonymize", "dihapus", model.Anonymized)
+
+			// Hapus permanen: pesan hilang dari hasil dan dari indeks
+			if err := repo.ErasePersonalData(ctx, &model.PrivacyRequest{Kind: model.PrivacyErase}, []string{"siti@kantor.co.id"}, false); err != nil {
+				t.Fatal(err)
+			}
+			checkIndex("erase")
+			expect("erase", "siti")
+			expect("erase", "golang")
+			expect("erase", "", "John Smith", model.Anonymized)
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"portofolio-go/internal/config"
+	"portofolio-go/internal/database"
+	"portofolio-go/internal/model"
+	"portofolio-go/internal/notify"
+	"portofolio-go/internal/repository"
+	"testing"
+	"time"
+)
+
+// fakeSender adalah notify.Sender yang gagal sebanyak failures kali sebelum berhasil
+type fakeSender struct {
+	failures int
+	sent     []notify.Message
+}
+
+func (f *fakeSender) Send(ctx context.Context, msg notify.Message) error {
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("421 server sibuk")
+	}
+	f.sent = append(f.sent, msg)
+	return nil
+}
+
+// newEmailTestService membuat Service dengan database sementara dan sender palsu
+func newEmailTestService(t *testing.T, sender notify.Sender) (*Service, *sql.DB) {
+	t.Helper()
+	return newTestService(t, &config.AppConfig{}, sender)
+}
+
+// newTestService membuat Service dengan konfigurasi cfg, database sementara, dan sender palsu
+func newTestService(t *testing.T, cfg *config.AppConfig, sender notify.Sender) (*Service, *sql.DB) {
+	t.Helper()
+	t.Chdir("../..") // migrations/ dan web/templates/ dibaca relatif dari root repo
+
+	db, err := database.InitDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	mailer, err := notify.NewMailer(sender, "web/templates/email")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewService(repository.NewRepository(db), cfg, mailer), db
+}
+
+// queuedEmail membaca status satu email di antrean
+func queuedEmail(t *testing.T, db *sql.DB, id int) (attempts int, lastErr string, next time.Time, sent, failed bool) {
+	t.Helper()
+	var sentAt, failedAt sql.NullTime
+	err := db.QueryRow(
+		"SELECT attempts, last_error, next_attempt_at, sent_at, failed_at FROM email_queue WHERE id = ?", id,
+	).Scan(&attempts, &lastErr, &next, &sentAt, &failedAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return attempts, lastErr, next, sentAt.Valid, failedAt.Valid
+}
+
+// makeDue memajukan jadwal email agar ikut diproses pada putaran berikutnya
+func makeDue(t *testing.T, db *sql.DB, id int) {
+	t.Helper()
+	if _, err := db.Exec("UPDATE email_queue SET next_attempt_at = ? WHERE id = ?", time.Now().Add(-time.Second).UTC(), id); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProcessEmailQueueRetry(t *testing.T) {
+	ctx := context.Background()
+	sender := &fakeSender{failures: 2}
+	svc, db := newEmailTestService(t, sender)
+
+	email := &model.QueuedEmail{To: "owner@example.com", Subject: "Pesan baru", Text: "halo", HTML: "<p>halo</p>", NextAttemptAt: time.Now()}
+	if err := svc.repo.EnqueueEmail(ctx, email); err != nil {
+		t.Fatal(err)
+	}
+
+	// Dua percobaan pertama gagal: dijadwalkan ulang dengan jeda 1 menit lalu 2 menit
+	for i, wantDelay := range []time.Duration{emailRetryBase, 2 * emailRetryBase} {
+		before := time.Now()
+		sent, failed, err := svc.ProcessEmailQueue(ctx)
+		if err != nil || sent != 0 || failed != 1 {
+			t.Fatalf("putaran %d: sent=%d failed=%d err=%v, want 0 1 nil", i+1, sent, failed, err)
+		}
+		attempts, lastErr, next, isSent, isFailed := queuedEmail(t, db, email.ID)
+		if attempts != i+1 || lastErr != "421 server sibuk" || isSent || isFailed {
+			t.Fatalf("putaran %d: attempts=%d last_error=%q sent=%v failed=%v", i+1, attempts, lastErr, isSent, isFailed)
+		}
+		if delay := next.Sub(before); delay < wantDelay-time.Second || delay > wantDelay+5*time.Second {
+			t.Errorf("putaran %d: jeda percobaan ulang %s, want ±%s", i+1, delay, wantDelay)
+		}
+
+		// Belum jatuh tempo: putaran berikutnya tidak mengirim apa pun
+		if sent, failed, _ := svc.ProcessEmailQueue(ctx); sent+failed != 0 {
+			t.Fatalf("putaran %d: email dikirim sebelum jadwalnya", i+1)
+		}
+		makeDue(t, db, email.ID)
+	}
+
+	// Percobaan ketiga berhasil
+	sent, failed, err := svc.ProcessEmailQueue(ctx)
+	if err != nil || sent != 1 || failed != 0 {
+		t.Fatalf("sent=%d failed=%d err=%v, want 1 0 nil", sent, failed, err)
+	}
+	attempts, lastErr, _, isSent, _ := queuedEmail(t, db, email.ID)
+	if attempts != 3 || lastErr != "" || !isSent {
+		t.Fatalf("attempts=%d last_error=%q sent=%v, want 3 \"\" true", attempts, lastErr, isSent)
+	}
+	if len(sender.sent) != 1 || sender.sent[0].To != "owner@example.com" {
+		t.Fatalf("email terkirim = %+v", sender.sent)
+	}
+}
+
+func TestProcessEmailQueueGivesUp(t *testing.T) {
+	ctx := context.Background()
+	svc, db := newEmailTestService(t, &fakeSender{failures: emailMaxAttempts})
+
+	email := &model.QueuedEmail{To: "owner@example.com", Subject: "Pesan baru", NextAttemptAt: time.Now()}
+	if err := svc.repo.EnqueueEmail(ctx, email); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 1; i <= emailMaxAttempts; i++ {
+		if _, failed, err := svc.ProcessEmailQueue(ctx); err != nil || failed != 1 {
+			t.Fatalf("percobaan %d: failed=%d err=%v", i, failed, err)
+		}
+		makeDue(t, db, email.ID)
+	}
+	attempts, _, _, isSent, isFailed := queuedEmail(t, db, email.ID)
+	if attempts != emailMaxAttempts || isSent || !isFailed {
+		t.Fatalf("attempts=%d sent=%v failed=%v, want %d false true", attempts, isSent, isFailed, emailMaxAttempts)
+	}
+
+	// Email yang sudah menyerah tidak diproses lagi
+	if sent, failed, _ := svc.ProcessEmailQueue(ctx); sent+failed != 0 {
+		t.Fatal("email gagal permanen masih dicoba ulang")
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, time.Minute},
+		{1, 2 * time.Minute},
+		{3, 8 * time.Minute},
+		{8, 256 * time.Minute},
+		{9, 6 * time.Hour}, // 512 menit melewati batas
+		{100, 6 * time.Hour},
+	}
+	for _, tt := range tests {
+		if got := retryDelay(tt.attempts, emailRetryBase, emailRetryMax); got != tt.want {
+			t.Errorf("retryDelay(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
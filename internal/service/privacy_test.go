@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"portofolio-go/internal/config"
+	"portofolio-go/internal/model"
+	"testing"
+)
+
+func TestErasePersonalDataRequiresSecret(t *testing.T) {
+	ctx := context.Background()
+
+	// Secret acak per proses (development tanpa SESSION_SECRET) tidak boleh dipakai untuk log privasi
+	cfg := &config.AppConfig{AppMode: "development"}
+	if err := cfg.PrepareSessionSecret(); err != nil || !cfg.EphemeralSecret {
+		t.Fatalf("PrepareSessionSecret: err=%v ephemeral=%v", err, cfg.EphemeralSecret)
+	}
+	svc, _ := newTestService(t, cfg, &fakeSender{})
+	if _, err := svc.ErasePersonalData(ctx, model.PrivacyErase, "budi@example.com", ""); !errors.Is(err, ErrPrivacySecretRequired) {
+		t.Fatalf("ErasePersonalData = %v, want ErrPrivacySecretRequired", err)
+	}
+	if reqs, err := svc.GetPrivacyRequests(ctx); err != nil || len(reqs) != 0 {
+		t.Fatalf("log privasi = %v (%v), want kosong", reqs, err)
+	}
+}
+
+func TestErasePersonalDataSubjectHash(t *testing.T) {
+	ctx := context.Background()
+	const secret = "c2f1a9e07b4d4e3f8a6b5c4d3e2f1a0b"
+	svc, _ := newTestService(t, &config.AppConfig{AppMode: "production", SessionSecret: secret}, &fakeSender{})
+
+	req, err := svc.ErasePersonalData(ctx, model.PrivacyErase, "Budi@Example.com", "permintaan via email")
+	if err != nil {
+		t.Fatalf("ErasePersonalData: %v", err)
+	}
+
+	hash := func(key string) string {
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write([]byte("budi@example.com"))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+	if req.SubjectHash != hash(secret) {
+		t.Errorf("SubjectHash = %s, want HMAC email huruf kecil dengan SESSION_SECRET", req.SubjectHash)
+	}
+	if req.SubjectHash == hash("default-secret-ganti-ini") {
+		t.Error("SubjectHash bisa dicocokkan dengan default lama dari repo")
+	}
+	if req.SubjectHint != "B***@Example.com" {
+		t.Errorf("SubjectHint = %q", req.SubjectHint)
+	}
+}
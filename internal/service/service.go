@@ -1,226 +1,1908 @@
 package service
 
 import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"html"
+	"io"
+	"log/slog"
+	"net/mail"
+	"net/url"
+	"portofolio-go/internal/config"
+	"portofolio-go/internal/export"
+	"portofolio-go/internal/i18n"
+	"portofolio-go/internal/metrics"
 	"portofolio-go/internal/model"
+	"portofolio-go/internal/notify"
 	"portofolio-go/internal/repository"
+	"portofolio-go/internal/tracing"
+	"portofolio-go/internal/webhook"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
 )
 
 // Service menyediakan business logic untuk aplikasi
 // Layer ini berada di antara handler dan repository
 type Service struct {
-	repo *repository.Repository
+	repo     *repository.Repository
+	cfg      *config.AppConfig
+	mailer   *notify.Mailer  // nil = notifikasi email nonaktif
+	webhooks *webhook.Client // Pengirim webhook keluar
+	version  atomic.Uint64   // Versi konten halaman publik, naik setiap konten berubah
 }
 
-// NewService membuat instance Service baru dengan dependency repository
-func NewService(repo *repository.Repository) *Service {
-	return &Service{repo: repo}
+// NewService membuat instance Service baru dengan dependency repository, konfigurasi,
+// dan mailer untuk notifikasi email (boleh nil jika SMTP tidak dikonfigurasi)
+func NewService(repo *repository.Repository, cfg *config.AppConfig, mailer *notify.Mailer) *Service {
+	return &Service{repo: repo, cfg: cfg, mailer: mailer, webhooks: webhook.NewClient(webhookTimeout)}
 }
 
 // ============================================
 // PORTFOLIO DATA — Data Halaman Utama
 // ============================================
 
+// ContentVersion mengembalikan versi konten halaman publik saat ini.
+// Halaman yang dirender dengan versi lebih lama sudah basi (lihat internal/pagecache)
+func (s *Service) ContentVersion() uint64 {
+	return s.version.Load()
+}
+
+// contentChanged menaikkan versi konten; dipanggil setiap mutasi yang
+// mengubah isi halaman publik (konten, urutan, terjemahan, konfigurasi situs)
+func (s *Service) contentChanged() {
+	s.version.Add(1)
+}
+
 // GetPortfolioData mengumpulkan semua data yang dibutuhkan untuk halaman utama
-// Menggabungkan config, experiences, projects, dan tech stacks
-func (s *Service) GetPortfolioData() (*model.PortfolioData, error) {
+// Menggabungkan config, experiences, projects, dan tech stacks.
+// Hanya konten yang sudah terbit yang diambil, diterjemahkan ke locale yang diminta
+func (s *Service) GetPortfolioData(ctx context.Context, locale string) (*model.PortfolioData, error) {
+	ctx, span := tracing.Start(ctx, "service.GetPortfolioData")
+	defer span.End()
+
+	return s.portfolioData(ctx, false, locale)
+}
+
+// GetPreviewData sama seperti GetPortfolioData, tetapi ikut menyertakan draft
+// dan konten terjadwal (untuk halaman preview)
+func (s *Service) GetPreviewData(ctx context.Context, locale string) (*model.PortfolioData, error) {
+	ctx, span := tracing.Start(ctx, "service.GetPreviewData")
+	defer span.End()
+
+	return s.portfolioData(ctx, true, locale)
+}
+
+// portfolioData mengumpulkan data halaman utama, dengan atau tanpa draft
+func (s *Service) portfolioData(ctx context.Context, includeDrafts bool, locale string) (*model.PortfolioData, error) {
 	// Ambil konfigurasi situs
-	config, err := s.repo.GetAllConfig()
+	config, err := s.repo.GetAllConfig(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("gagal mengambil config: %w", err)
 	}
 
 	// Ambil daftar pengalaman kerja
-	experiences, err := s.repo.GetAllExperiences()
+	experiences, err := s.repo.GetAllExperiences(ctx, includeDrafts)
 	if err != nil {
 		return nil, fmt.Errorf("gagal mengambil experiences: %w", err)
 	}
 
+	// Hitung total masa kerja dari seluruh pengalaman
+	experienceMonths := totalExperienceMonths(experiences, time.Now())
+
 	// Ambil daftar proyek
-	projects, err := s.repo.GetAllProjects()
+	projects, err := s.repo.GetAllProjects(ctx, includeDrafts)
 	if err != nil {
 		return nil, fmt.Errorf("gagal mengambil projects: %w", err)
 	}
 
-	// Ambil daftar tech stack
-	techStacks, err := s.repo.GetAllTechStacks()
+	// Ambil daftar tech stack beserta proyek yang memakainya
+	techStacks, err := s.techStacksWithProjects(ctx, includeDrafts)
 	if err != nil {
-		return nil, fmt.Errorf("gagal mengambil tech stacks: %w", err)
+		return nil, err
 	}
 
-	return &model.PortfolioData{
-		Config:      config,
-		Experiences: experiences,
-		Projects:    projects,
-		TechStacks:  techStacks,
-	}, nil
+	data := &model.PortfolioData{
+		Config:           config,
+		Experiences:      experiences,
+		ExperienceMonths: experienceMonths,
+		Projects:         projects,
+		TechStacks:       techStacks,
+	}
+
+	// Konten asli ditulis dalam bahasa Indonesia, locale lain perlu diterjemahkan
+	if locale != i18n.Default {
+		translations, err := s.repo.GetTranslations(ctx, locale)
+		if err != nil {
+			return nil, err
+		}
+		translatePortfolio(data, translations)
+	}
+	return data, nil
 }
 
 // ============================================
 // CONTACT — Pesan Kontak
 // ============================================
 
+// contactMessagesSaved menghitung pesan kontak yang tersimpan per folder (inbox, spam)
+var contactMessagesSaved = metrics.NewCounterVec("contact_messages_saved_total",
+	"Jumlah pesan kontak yang tersimpan per folder (inbox, spam).", "folder")
+
 // SubmitContactMessage memvalidasi dan menyimpan pesan kontak dari pengunjung
-// Melakukan sanitasi input untuk mencegah XSS
-func (s *Service) SubmitContactMessage(form *model.ContactForm) error {
+// Melakukan sanitasi input untuk mencegah XSS. Pesan dengan skor spam
+// di atas ambang batas disimpan di folder spam, bukan kotak masuk
+func (s *Service) SubmitContactMessage(ctx context.Context, form *model.ContactForm) error {
+	ctx, span := tracing.Start(ctx, "service.SubmitContactMessage")
+	defer span.End()
+
+	// Hitung skor spam dari teks asli (sebelum di-escape)
+	score, reasons := spamScore(form, s.cfg.SpamBlockedWords)
+
 	// Sanitasi input — bersihkan HTML tags yang berbahaya
 	msg := &model.ContactMessage{
-		Name:    sanitizeInput(form.Name),
-		Email:   sanitizeInput(form.Email),
-		Message: sanitizeInput(form.Message),
+		Name:        sanitizeInput(form.Name),
+		Email:       sanitizeInput(form.Email),
+		Message:     sanitizeInput(form.Message),
+		Status:      model.MessageNew,
+		Locale:      i18n.Negotiate(form.Locale),
+		IsSpam:      score >= s.cfg.SpamThreshold,
+		SpamScore:   score,
+		SpamReasons: strings.Join(reasons, ", "),
+		Consent:     sanitizeInput(form.ConsentVersion),
 	}
 
 	// Simpan ke database
-	if err := s.repo.CreateContactMessage(msg); err != nil {
+	if err := s.repo.CreateContactMessage(ctx, msg); err != nil {
 		return fmt.Errorf("gagal menyimpan pesan kontak: %w", err)
 	}
+	if msg.IsSpam {
+		contactMessagesSaved.Inc("spam")
+	} else {
+		contactMessagesSaved.Inc("inbox")
+	}
+
+	// Notifikasi email hanya dimasukkan ke antrean; pengiriman dilakukan job latar belakang.
+	// Pesan sudah tersimpan, jadi kegagalan di sini cukup dicatat tanpa menggagalkan request
+	if s.mailer != nil && !msg.IsSpam {
+		if err := s.queueContactEmails(ctx, form, msg.ID); err != nil {
+			slog.ErrorContext(ctx, "Gagal menyiapkan notifikasi email", "message_id", msg.ID, "error", err)
+		}
+	}
+	if !msg.IsSpam {
+		summary := i18n.T(i18n.Default, "webhook.message_created", form.Name, excerpt(form.Message, 200))
+		s.emitFor(ctx, model.EventMessageCreated, summary, msg, &msg.ID)
+	}
+
+	return nil
+}
+
+// ErrMailerDisabled dikembalikan jika fitur yang butuh email dipakai tanpa SMTP_HOST
+var ErrMailerDisabled = errors.New("pengiriman email belum dikonfigurasi (SMTP_HOST kosong)")
+
+// ErrInvalidStatus dikembalikan jika status pesan yang diminta tidak dikenal
+var ErrInvalidStatus = errors.New("status pesan tidak dikenal")
+
+// messagesPerPage adalah jumlah pesan per halaman kotak masuk dashboard
+const messagesPerPage = 20
+
+// Aksi massal untuk pesan yang dipilih di kotak masuk
+const (
+	BulkMarkRead = "read"    // Tandai pesan baru sebagai dibaca
+	BulkArchive  = "archive" // Arsipkan pesan
+	BulkDelete   = "delete"  // Pindahkan pesan ke sampah
+)
+
+// SearchMessages mencari pesan kotak masuk sesuai filter (kata kunci, status, rentang tanggal)
+// dan mengembalikan satu halaman hasil beserta thread balasannya.
+// Halaman di luar jangkauan diganti halaman terdekat yang valid
+func (s *Service) SearchMessages(ctx context.Context, filter model.MessageFilter) (*model.MessagePage, error) {
+	ctx, span := tracing.Start(ctx, "service.SearchMessages")
+	defer span.End()
+
+	if !validMessageFilterStatus(filter.Status) {
+		return nil, ErrInvalidStatus
+	}
+	if filter.PerPage <= 0 {
+		filter.PerPage = messagesPerPage
+	}
+	filter.Page = max(filter.Page, 1)
+
+	messages, total, err := s.repo.SearchContactMessages(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	totalPages := max((total+filter.PerPage-1)/filter.PerPage, 1)
+	if filter.Page > totalPages {
+		filter.Page = totalPages
+		if messages, total, err = s.repo.SearchContactMessages(ctx, filter); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.attachReplies(ctx, messages); err != nil {
+		return nil, err
+	}
+	return &model.MessagePage{
+		Messages:   messages,
+		Total:      total,
+		Page:       filter.Page,
+		TotalPages: totalPages,
+	}, nil
+}
 
+// attachReplies mengisi thread balasan setiap pesan
+func (s *Service) attachReplies(ctx context.Context, messages []model.ContactMessage) error {
+	ids := make([]int, len(messages))
+	for i, msg := range messages {
+		ids[i] = msg.ID
+	}
+	replies, err := s.repo.GetMessageReplies(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for i := range messages {
+		messages[i].Replies = replies[messages[i].ID]
+	}
 	return nil
 }
 
-// GetAllContactMessages mengambil semua pesan kontak untuk admin
-func (s *Service) GetAllContactMessages() ([]model.ContactMessage, error) {
-	return s.repo.GetAllContactMessages()
+// BulkMessages menjalankan aksi massal (read/archive/delete) untuk pesan terpilih
+func (s *Service) BulkMessages(ctx context.Context, action string, ids []int) error {
+	ctx, span := tracing.Start(ctx, "service.BulkMessages")
+	defer span.End()
+
+	if len(ids) == 0 {
+		return fmt.Errorf("belum ada pesan yang dipilih")
+	}
+	switch action {
+	case BulkMarkRead:
+		return s.repo.MarkMessagesAsRead(ctx, ids)
+	case BulkArchive:
+		return s.repo.ArchiveMessages(ctx, ids)
+	case BulkDelete:
+		return s.repo.DeleteContactMessages(ctx, ids)
+	default:
+		return fmt.Errorf("aksi pesan tidak dikenal: %q", action)
+	}
+}
+
+// ExportMessages menulis pesan kotak masuk yang cocok dengan filter (tanpa halaman)
+// ke w dalam format csv atau mbox, terlama duluan. Pesan di-stream langsung dari
+// database ke w tanpa ditampung di memori. Mengembalikan jumlah pesan yang ditulis
+func (s *Service) ExportMessages(ctx context.Context, w io.Writer, format string, filter model.MessageFilter) (int, error) {
+	ctx, span := tracing.Start(ctx, "service.ExportMessages")
+	defer span.End()
+
+	if !validMessageFilterStatus(filter.Status) {
+		return 0, ErrInvalidStatus
+	}
+	ew, err := export.NewWriter(format, w)
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	err = s.repo.EachContactMessage(ctx, filter, func(msg model.ContactMessage) error {
+		n++
+		return ew.Write(msg)
+	})
+	if err != nil {
+		return n, err
+	}
+	return n, ew.Close()
+}
+
+// validMessageFilterStatus mengecek nilai filter status pesan (kosong, all, atau status dikenal)
+func validMessageFilterStatus(status string) bool {
+	return status == "" || status == model.MessageAll || slices.Contains(model.MessageStatuses, status)
+}
+
+// CountMessagesByStatus menghitung pesan kotak masuk per status (untuk filter dashboard)
+func (s *Service) CountMessagesByStatus(ctx context.Context) (map[string]int, error) {
+	ctx, span := tracing.Start(ctx, "service.CountMessagesByStatus")
+	defer span.End()
+
+	return s.repo.CountMessagesByStatus(ctx)
+}
+
+// GetSpamMessages mengambil semua pesan kontak di folder spam
+func (s *Service) GetSpamMessages(ctx context.Context) ([]model.ContactMessage, error) {
+	ctx, span := tracing.Start(ctx, "service.GetSpamMessages")
+	defer span.End()
+
+	return s.repo.GetContactMessages(ctx, true, "")
+}
+
+// ArchiveMessage mengarsipkan pesan (archived = true) atau mengembalikannya ke kotak masuk
+// Pesan yang dikembalikan berstatus replied jika sudah pernah dibalas, selain itu read
+func (s *Service) ArchiveMessage(ctx context.Context, id int, archived bool) error {
+	ctx, span := tracing.Start(ctx, "service.ArchiveMessage")
+	defer span.End()
+
+	if archived {
+		return s.repo.SetMessageStatus(ctx, id, model.MessageArchived)
+	}
+	replied, err := s.repo.HasMessageReplies(ctx, id)
+	if err != nil {
+		return err
+	}
+	if replied {
+		return s.repo.SetMessageStatus(ctx, id, model.MessageReplied)
+	}
+	return s.repo.SetMessageStatus(ctx, id, model.MessageRead)
+}
+
+// replyEmailData adalah data untuk template email balasan (web/templates/email/contact_reply)
+type replyEmailData struct {
+	Locale     string
+	SiteName   string
+	SiteURL    string
+	Name       string
+	Body       string
+	Original   string
+	ReceivedAt time.Time
+}
+
+// ReplyToMessage mengirim balasan admin ke pengirim pesan lewat antrean email
+// dan menyimpannya di thread percakapan. Pesan ditandai sudah dibalas
+func (s *Service) ReplyToMessage(ctx context.Context, id int, body string) error {
+	ctx, span := tracing.Start(ctx, "service.ReplyToMessage")
+	defer span.End()
+
+	if s.mailer == nil {
+		return ErrMailerDisabled
+	}
+	body = strings.TrimSpace(body)
+	if body == "" || len([]rune(body)) > 10000 {
+		return fmt.Errorf("isi balasan wajib diisi (maks 10000 karakter)")
+	}
+
+	msg, err := s.repo.GetContactMessageByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	configs, err := s.repo.GetAllConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Pesan tersimpan dalam bentuk ter-escape; template email meng-escape sendiri
+	data := replyEmailData{
+		Locale:     i18n.Negotiate(msg.Locale),
+		SiteName:   configs["name"],
+		SiteURL:    s.cfg.SiteURL,
+		Name:       html.UnescapeString(msg.Name),
+		Body:       body,
+		Original:   html.UnescapeString(msg.Message),
+		ReceivedAt: msg.CreatedAt,
+	}
+	text, htmlBody, err := s.mailer.Compose("contact_reply", data)
+	if err != nil {
+		return err
+	}
+
+	replyTo := s.cfg.MailOwner
+	if replyTo == "" {
+		replyTo = configs["email"]
+	}
+	email := &model.QueuedEmail{
+		To:            html.UnescapeString(msg.Email),
+		ReplyTo:       replyTo,
+		Subject:       i18n.T(data.Locale, "email.reply_subject", data.SiteName),
+		Text:          text,
+		HTML:          htmlBody,
+		NextAttemptAt: time.Now(),
+		MessageID:     &msg.ID,
+	}
+	reply := &model.MessageReply{MessageID: msg.ID, Body: sanitizeInput(body)}
+	return s.repo.CreateMessageReply(ctx, reply, email)
+}
+
+// SetMessageSpam memindahkan pesan ke folder spam atau kembali ke kotak masuk
+func (s *Service) SetMessageSpam(ctx context.Context, id int, spam bool) error {
+	ctx, span := tracing.Start(ctx, "service.SetMessageSpam")
+	defer span.End()
+
+	return s.repo.SetMessageSpam(ctx, id, spam)
 }
 
 // MarkMessageAsRead menandai pesan sebagai sudah dibaca
-func (s *Service) MarkMessageAsRead(id int) error {
-	return s.repo.MarkMessageAsRead(id)
+func (s *Service) MarkMessageAsRead(ctx context.Context, id int) error {
+	ctx, span := tracing.Start(ctx, "service.MarkMessageAsRead")
+	defer span.End()
+
+	return s.repo.MarkMessageAsRead(ctx, id)
 }
 
 // DeleteContactMessage menghapus pesan kontak
-func (s *Service) DeleteContactMessage(id int) error {
-	return s.repo.DeleteContactMessage(id)
+func (s *Service) DeleteContactMessage(ctx context.Context, id int) error {
+	ctx, span := tracing.Start(ctx, "service.DeleteContactMessage")
+	defer span.End()
+
+	return s.repo.DeleteContactMessage(ctx, id)
 }
 
 // ============================================
 // EXPERIENCES — Pengalaman Kerja (CRUD Admin)
 // ============================================
 
-// GetAllExperiences mengambil semua pengalaman kerja
-func (s *Service) GetAllExperiences() ([]model.Experience, error) {
-	return s.repo.GetAllExperiences()
+// GetAllExperiences mengambil semua pengalaman kerja, termasuk draft
+func (s *Service) GetAllExperiences(ctx context.Context) ([]model.Experience, error) {
+	ctx, span := tracing.Start(ctx, "service.GetAllExperiences")
+	defer span.End()
+
+	return s.repo.GetAllExperiences(ctx, true)
 }
 
 // GetExperienceByID mengambil pengalaman kerja berdasarkan ID
-func (s *Service) GetExperienceByID(id int) (*model.Experience, error) {
-	return s.repo.GetExperienceByID(id)
+func (s *Service) GetExperienceByID(ctx context.Context, id int) (*model.Experience, error) {
+	ctx, span := tracing.Start(ctx, "service.GetExperienceByID")
+	defer span.End()
+
+	return s.repo.GetExperienceByID(ctx, id)
 }
 
-// CreateExperience membuat pengalaman kerja baru setelah sanitasi
-func (s *Service) CreateExperience(exp *model.Experience) error {
-	exp.Company = sanitizeInput(exp.Company)
-	exp.Role = sanitizeInput(exp.Role)
-	exp.Period = sanitizeInput(exp.Period)
-	exp.Description = sanitizeInput(exp.Description)
-	return s.repo.CreateExperience(exp)
+// CreateExperience membuat pengalaman kerja baru setelah sanitasi dan validasi
+func (s *Service) CreateExperience(ctx context.Context, exp *model.Experience) error {
+	ctx, span := tracing.Start(ctx, "service.CreateExperience")
+	defer span.End()
+
+	if err := prepareExperience(exp); err != nil {
+		return err
+	}
+	if err := s.repo.CreateExperience(ctx, exp); err != nil {
+		return err
+	}
+	s.contentChanged()
+	s.emitContent(ctx, model.RevisionExperience, "created", exp.ID)
+	return nil
 }
 
-// UpdateExperience memperbarui pengalaman kerja setelah sanitasi
-func (s *Service) UpdateExperience(exp *model.Experience) error {
-	exp.Company = sanitizeInput(exp.Company)
-	exp.Role = sanitizeInput(exp.Role)
-	exp.Period = sanitizeInput(exp.Period)
-	exp.Description = sanitizeInput(exp.Description)
-	return s.repo.UpdateExperience(exp)
+// UpdateExperience memperbarui pengalaman kerja setelah sanitasi dan validasi
+func (s *Service) UpdateExperience(ctx context.Context, exp *model.Experience) error {
+	ctx, span := tracing.Start(ctx, "service.UpdateExperience")
+	defer span.End()
+
+	if err := prepareExperience(exp); err != nil {
+		return err
+	}
+	if err := s.repo.UpdateExperience(ctx, exp); err != nil {
+		return err
+	}
+	s.contentChanged()
+	s.emitContent(ctx, model.RevisionExperience, "updated", exp.ID)
+	return nil
 }
 
 // DeleteExperience menghapus pengalaman kerja
-func (s *Service) DeleteExperience(id int) error {
-	return s.repo.DeleteExperience(id)
+func (s *Service) DeleteExperience(ctx context.Context, id int) error {
+	ctx, span := tracing.Start(ctx, "service.DeleteExperience")
+	defer span.End()
+
+	// Isi konten diambil sebelum dihapus untuk payload event
+	data, title, err := s.currentVersion(ctx, model.RevisionExperience, strconv.Itoa(id))
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteExperience(ctx, id); err != nil {
+		return err
+	}
+	s.contentChanged()
+	s.emitContentData(ctx, model.RevisionExperience, "deleted", title, data)
+	return nil
 }
 
 // ============================================
 // PROJECTS — Proyek (CRUD Admin)
 // ============================================
 
-// GetAllProjects mengambil semua proyek
-func (s *Service) GetAllProjects() ([]model.Project, error) {
-	return s.repo.GetAllProjects()
+// GetAllProjects mengambil semua proyek, termasuk draft
+func (s *Service) GetAllProjects(ctx context.Context) ([]model.Project, error) {
+	ctx, span := tracing.Start(ctx, "service.GetAllProjects")
+	defer span.End()
+
+	return s.repo.GetAllProjects(ctx, true)
 }
 
 // GetProjectByID mengambil proyek berdasarkan ID
-func (s *Service) GetProjectByID(id int) (*model.Project, error) {
-	return s.repo.GetProjectByID(id)
+func (s *Service) GetProjectByID(ctx context.Context, id int) (*model.Project, error) {
+	ctx, span := tracing.Start(ctx, "service.GetProjectByID")
+	defer span.End()
+
+	return s.repo.GetProjectByID(ctx, id)
 }
 
-// CreateProject membuat proyek baru setelah sanitasi
-func (s *Service) CreateProject(proj *model.Project) error {
-	proj.Title = sanitizeInput(proj.Title)
-	proj.Description = sanitizeInput(proj.Description)
-	proj.TechUsed = sanitizeInput(proj.TechUsed)
-	proj.GithubURL = sanitizeInput(proj.GithubURL)
-	return s.repo.CreateProject(proj)
+// CreateProject membuat proyek baru setelah sanitasi dan validasi
+func (s *Service) CreateProject(ctx context.Context, proj *model.Project) error {
+	ctx, span := tracing.Start(ctx, "service.CreateProject")
+	defer span.End()
+
+	if err := prepareProject(proj); err != nil {
+		return err
+	}
+	if err := s.repo.CreateProject(ctx, proj); err != nil {
+		return err
+	}
+	s.contentChanged()
+	s.emitContent(ctx, model.RevisionProject, "created", proj.ID)
+	return nil
 }
 
-// UpdateProject memperbarui proyek setelah sanitasi
-func (s *Service) UpdateProject(proj *model.Project) error {
-	proj.Title = sanitizeInput(proj.Title)
-	proj.Description = sanitizeInput(proj.Description)
-	proj.TechUsed = sanitizeInput(proj.TechUsed)
-	proj.GithubURL = sanitizeInput(proj.GithubURL)
-	return s.repo.UpdateProject(proj)
+// UpdateProject memperbarui proyek setelah sanitasi dan validasi
+func (s *Service) UpdateProject(ctx context.Context, proj *model.Project) error {
+	ctx, span := tracing.Start(ctx, "service.UpdateProject")
+	defer span.End()
+
+	if err := prepareProject(proj); err != nil {
+		return err
+	}
+	if err := s.repo.UpdateProject(ctx, proj); err != nil {
+		return err
+	}
+	s.contentChanged()
+	s.emitContent(ctx, model.RevisionProject, "updated", proj.ID)
+	return nil
 }
 
 // DeleteProject menghapus proyek
-func (s *Service) DeleteProject(id int) error {
-	return s.repo.DeleteProject(id)
+func (s *Service) DeleteProject(ctx context.Context, id int) error {
+	ctx, span := tracing.Start(ctx, "service.DeleteProject")
+	defer span.End()
+
+	// Isi konten diambil sebelum dihapus untuk payload event
+	data, title, err := s.currentVersion(ctx, model.RevisionProject, strconv.Itoa(id))
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteProject(ctx, id); err != nil {
+		return err
+	}
+	s.contentChanged()
+	s.emitContentData(ctx, model.RevisionProject, "deleted", title, data)
+	return nil
 }
 
 // ============================================
 // TECH STACKS — Teknologi (CRUD Admin)
 // ============================================
 
-// GetAllTechStacks mengambil semua tech stack
-func (s *Service) GetAllTechStacks() ([]model.TechStack, error) {
-	return s.repo.GetAllTechStacks()
+// GetAllTechStacks mengambil semua tech stack, termasuk draft
+func (s *Service) GetAllTechStacks(ctx context.Context) ([]model.TechStack, error) {
+	ctx, span := tracing.Start(ctx, "service.GetAllTechStacks")
+	defer span.End()
+
+	return s.repo.GetAllTechStacks(ctx, true)
 }
 
 // GetTechStackByID mengambil tech stack berdasarkan ID
-func (s *Service) GetTechStackByID(id int) (*model.TechStack, error) {
-	return s.repo.GetTechStackByID(id)
+func (s *Service) GetTechStackByID(ctx context.Context, id int) (*model.TechStack, error) {
+	ctx, span := tracing.Start(ctx, "service.GetTechStackByID")
+	defer span.End()
+
+	return s.repo.GetTechStackByID(ctx, id)
+}
+
+// GetTechStacksWithProjects mengambil semua tech stack (termasuk draft) beserta tag
+// yang terhubung dan daftar proyek yang memakainya
+func (s *Service) GetTechStacksWithProjects(ctx context.Context) ([]model.TechStack, error) {
+	ctx, span := tracing.Start(ctx, "service.GetTechStacksWithProjects")
+	defer span.End()
+
+	return s.techStacksWithProjects(ctx, true)
+}
+
+// techStacksWithProjects mengambil tech stack beserta tag dan proyeknya, dengan atau tanpa draft
+func (s *Service) techStacksWithProjects(ctx context.Context, includeDrafts bool) ([]model.TechStack, error) {
+	techStacks, err := s.repo.GetAllTechStacks(ctx, includeDrafts)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil tech stacks: %w", err)
+	}
+
+	tags, err := s.repo.GetTagsByTechStack(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil tag tech stack: %w", err)
+	}
+
+	projects, err := s.repo.GetProjectsByTechStack(ctx, includeDrafts)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil proyek per tech stack: %w", err)
+	}
+
+	for i := range techStacks {
+		techStacks[i].Tags = tags[techStacks[i].ID]
+		techStacks[i].Projects = projects[techStacks[i].ID]
+	}
+	return techStacks, nil
 }
 
 // CreateTechStack membuat tech stack baru setelah sanitasi
-func (s *Service) CreateTechStack(ts *model.TechStack) error {
+// Jika tidak ada tag yang dipilih, tag dengan nama yang sama akan dihubungkan
+func (s *Service) CreateTechStack(ctx context.Context, ts *model.TechStack) error {
+	ctx, span := tracing.Start(ctx, "service.CreateTechStack")
+	defer span.End()
+
 	ts.Category = sanitizeInput(ts.Category)
 	ts.Name = sanitizeInput(ts.Name)
 	ts.Description = sanitizeInput(ts.Description)
-	return s.repo.CreateTechStack(ts)
+	ts.Tags = normalizeTags(ts.Tags)
+	ts.Published, ts.PublishAt = normalizePublication(ts.Published, ts.PublishAt, time.Now())
+	if len(ts.Tags) == 0 {
+		ts.Tags = []model.Tag{{Name: ts.Name}}
+	}
+
+	if err := s.repo.CreateTechStack(ctx, ts); err != nil {
+		return err
+	}
+	s.contentChanged()
+	s.emitContent(ctx, model.RevisionTechStack, "created", ts.ID)
+	return nil
 }
 
-// UpdateTechStack memperbarui tech stack setelah sanitasi
-func (s *Service) UpdateTechStack(ts *model.TechStack) error {
+// UpdateTechStack memperbarui tech stack dan tag yang terhubung setelah sanitasi
+func (s *Service) UpdateTechStack(ctx context.Context, ts *model.TechStack) error {
+	ctx, span := tracing.Start(ctx, "service.UpdateTechStack")
+	defer span.End()
+
 	ts.Category = sanitizeInput(ts.Category)
 	ts.Name = sanitizeInput(ts.Name)
 	ts.Description = sanitizeInput(ts.Description)
-	return s.repo.UpdateTechStack(ts)
+	ts.Tags = normalizeTags(ts.Tags)
+	ts.Published, ts.PublishAt = normalizePublication(ts.Published, ts.PublishAt, time.Now())
+
+	if err := s.repo.UpdateTechStack(ctx, ts); err != nil {
+		return err
+	}
+	s.contentChanged()
+	s.emitContent(ctx, model.RevisionTechStack, "updated", ts.ID)
+	return nil
+}
+
+// GetAllTags mengambil semua tag proyek (untuk autocomplete di dashboard)
+func (s *Service) GetAllTags(ctx context.Context) ([]model.Tag, error) {
+	ctx, span := tracing.Start(ctx, "service.GetAllTags")
+	defer span.End()
+
+	return s.repo.GetAllTags(ctx)
 }
 
 // DeleteTechStack menghapus tech stack
-func (s *Service) DeleteTechStack(id int) error {
-	return s.repo.DeleteTechStack(id)
+func (s *Service) DeleteTechStack(ctx context.Context, id int) error {
+	ctx, span := tracing.Start(ctx, "service.DeleteTechStack")
+	defer span.End()
+
+	// Isi konten diambil sebelum dihapus untuk payload event
+	data, title, err := s.currentVersion(ctx, model.RevisionTechStack, strconv.Itoa(id))
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteTechStack(ctx, id); err != nil {
+		return err
+	}
+	s.contentChanged()
+	s.emitContentData(ctx, model.RevisionTechStack, "deleted", title, data)
+	return nil
 }
 
 // ============================================
-// SITE CONFIG — Konfigurasi (CRUD Admin)
+// REORDER — Urutan Tampil (Drag & Drop Admin)
 // ============================================
 
-// GetAllConfig mengambil semua konfigurasi situs
-func (s *Service) GetAllConfig() (map[string]string, error) {
-	return s.repo.GetAllConfig()
+// ErrUnknownEntity dikembalikan jika jenis konten yang diminta tidak dikenal
+var ErrUnknownEntity = errors.New("jenis konten tidak dikenal")
+
+// Reorder menyimpan urutan baru untuk experience, project, atau techstack
+// ids adalah daftar ID dalam urutan tampil yang diinginkan (paling atas duluan)
+func (s *Service) Reorder(ctx context.Context, entity string, ids []int) error {
+	ctx, span := tracing.Start(ctx, "service.Reorder")
+	defer span.End()
+
+	if len(ids) == 0 {
+		return fmt.Errorf("daftar ID urutan kosong")
+	}
+	seen := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return fmt.Errorf("ID %d muncul lebih dari sekali", id)
+		}
+		seen[id] = true
+	}
+
+	var err error
+	switch entity {
+	case "experience":
+		err = s.repo.ReorderExperiences(ctx, ids)
+	case "project":
+		err = s.repo.ReorderProjects(ctx, ids)
+	case "techstack":
+		err = s.repo.ReorderTechStacks(ctx, ids)
+	default:
+		return ErrUnknownEntity
+	}
+	if err != nil {
+		return err
+	}
+	s.contentChanged()
+	return nil
 }
 
-// UpdateConfig memperbarui konfigurasi situs
-func (s *Service) UpdateConfig(key, value string) error {
-	return s.repo.UpdateConfig(sanitizeInput(key), sanitizeInput(value))
+// ============================================
+// PUBLISHING — Draft & Jadwal Terbit
+// ============================================
+
+// PublishScheduled menerbitkan konten terjadwal yang waktunya sudah tiba
+// Dipanggil berkala oleh background job
+func (s *Service) PublishScheduled(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "service.PublishScheduled")
+	defer span.End()
+
+	n, err := s.repo.PublishScheduled(ctx, time.Now())
+	if n > 0 {
+		s.contentChanged()
+	}
+	return n, err
 }
 
 // ============================================
-// HELPER FUNCTIONS
+// EMAIL — Notifikasi & Antrean Kirim
+// ============================================
+
+const (
+	emailBatchSize   = 20               // Maksimal email yang dikirim per putaran job
+	emailMaxAttempts = 8                // Batas percobaan sebelum email ditandai gagal
+	emailRetryBase   = time.Minute      // Jeda percobaan ulang pertama (berlipat dua setiap gagal)
+	emailRetryMax    = 6 * time.Hour    // Jeda percobaan ulang terpanjang
+	emailSendTimeout = 30 * time.Second // Batas waktu satu kali kirim ke server SMTP
+)
+
+// contactEmailData adalah data untuk template email pesan kontak (web/templates/email/contact_*)
+type contactEmailData struct {
+	Locale    string
+	SiteName  string
+	SiteURL   string
+	Name      string
+	Email     string
+	Message   string
+	CreatedAt time.Time
+}
+
+// queueContactEmails menyusun notifikasi untuk pemilik situs dan (jika diaktifkan)
+// balasan otomatis ke pengunjung, lalu memasukkannya ke antrean email.
+// Template menerima teks asli form karena html/template sudah meng-escape sendiri
+func (s *Service) queueContactEmails(ctx context.Context, form *model.ContactForm, messageID int) error {
+	configs, err := s.repo.GetAllConfig(ctx)
+	if err != nil {
+		return err
+	}
+	owner := s.cfg.MailOwner
+	if owner == "" {
+		owner = configs["email"]
+	}
+
+	data := contactEmailData{
+		Locale:    i18n.Default,
+		SiteName:  configs["name"],
+		SiteURL:   s.cfg.SiteURL,
+		Name:      form.Name,
+		Email:     form.Email,
+		Message:   form.Message,
+		CreatedAt: time.Now(),
+	}
+
+	if owner != "" {
+		subject := i18n.T(data.Locale, "email.owner_subject", form.Name)
+		if err := s.queueEmail(ctx, "contact_owner", owner, form.Email, subject, messageID, data); err != nil {
+			return err
+		}
+	}
+
+	if s.cfg.MailAutoReply {
+		data.Locale = i18n.Negotiate(form.Locale)
+		subject := i18n.T(data.Locale, "email.auto_reply_subject", data.SiteName)
+		if err := s.queueEmail(ctx, "contact_auto_reply", form.Email, owner, subject, messageID, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// queueEmail merender template email lalu memasukkannya ke antrean kirim
+// Email ditautkan ke pesan kontak asalnya agar ikut terhapus bersama pesan
+func (s *Service) queueEmail(ctx context.Context, template, to, replyTo, subject string, messageID int, data any) error {
+	text, htmlBody, err := s.mailer.Compose(template, data)
+	if err != nil {
+		return err
+	}
+	return s.repo.EnqueueEmail(ctx, &model.QueuedEmail{
+		To:            to,
+		ReplyTo:       replyTo,
+		Subject:       subject,
+		Text:          text,
+		HTML:          htmlBody,
+		NextAttemptAt: time.Now(),
+		MessageID:     &messageID,
+	})
+}
+
+// ProcessEmailQueue mengirim email di antrean yang jadwalnya sudah tiba.
+// Email yang gagal dijadwalkan ulang dengan jeda eksponensial, lalu ditandai gagal
+// setelah emailMaxAttempts percobaan. Mengembalikan jumlah email terkirim dan gagal
+func (s *Service) ProcessEmailQueue(ctx context.Context) (sent, failed int, err error) {
+	ctx, span := tracing.Start(ctx, "service.ProcessEmailQueue")
+	defer span.End()
+
+	if s.mailer == nil {
+		return 0, 0, nil
+	}
+
+	emails, err := s.repo.GetDueEmails(ctx, time.Now(), emailBatchSize)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, e := range emails {
+		if ctx.Err() != nil {
+			break
+		}
+
+		sendCtx, cancel := context.WithTimeout(ctx, emailSendTimeout)
+		sendErr := s.mailer.Send(sendCtx, notify.Message{
+			To: e.To, ReplyTo: e.ReplyTo, Subject: e.Subject, Text: e.Text, HTML: e.HTML,
+		})
+		cancel()
+
+		now := time.Now()
+		switch {
+		case sendErr == nil:
+			err = s.repo.MarkEmailSent(ctx, e.ID, now)
+			sent++
+		case e.Attempts+1 >= emailMaxAttempts:
+			err = s.repo.MarkEmailFailed(ctx, e.ID, sendErr.Error(), now)
+			failed++
+		default:
+			err = s.repo.MarkEmailRetry(ctx, e.ID, sendErr.Error(), now.Add(retryDelay(e.Attempts, emailRetryBase, emailRetryMax)))
+			failed++
+		}
+		if err != nil {
+			return sent, failed, err
+		}
+	}
+	return sent, failed, nil
+}
+
 // ============================================
+// WEBHOOKS — Webhook Keluar & Log Delivery
+// ============================================
+
+const (
+	webhookBatchSize   = 20               // Maksimal delivery yang dikirim per putaran job
+	webhookMaxAttempts = 8                // Batas percobaan sebelum delivery ditandai gagal
+	webhookRetryBase   = 30 * time.Second // Jeda percobaan ulang pertama (berlipat dua setiap gagal)
+	webhookRetryMax    = 6 * time.Hour    // Jeda percobaan ulang terpanjang
+	webhookTimeout     = 10 * time.Second // Batas waktu satu request ke endpoint webhook
+	webhookLogLimit    = 50               // Jumlah log delivery terbaru di dashboard
+)
+
+// GetWebhooks mengambil semua webhook untuk dashboard
+func (s *Service) GetWebhooks(ctx context.Context) ([]model.Webhook, error) {
+	ctx, span := tracing.Start(ctx, "service.GetWebhooks")
+	defer span.End()
+
+	return s.repo.GetAllWebhooks(ctx)
+}
+
+// GetWebhookDeliveries mengambil log delivery terbaru untuk dashboard
+func (s *Service) GetWebhookDeliveries(ctx context.Context) ([]model.WebhookDelivery, error) {
+	ctx, span := tracing.Start(ctx, "service.GetWebhookDeliveries")
+	defer span.End()
+
+	return s.repo.GetRecentWebhookDeliveries(ctx, webhookLogLimit)
+}
+
+// CreateWebhook menyimpan webhook baru setelah validasi
+// Jika secret dikosongkan, secret acak dibuat otomatis
+func (s *Service) CreateWebhook(ctx context.Context, w *model.Webhook) error {
+	ctx, span := tracing.Start(ctx, "service.CreateWebhook")
+	defer span.End()
+
+	if err := prepareWebhook(w); err != nil {
+		return err
+	}
+	if w.Secret == "" {
+		w.Secret = randomSecret()
+	}
+	return s.repo.CreateWebhook(ctx, w)
+}
+
+// UpdateWebhook memperbarui webhook setelah validasi
+// Secret yang dikosongkan berarti tetap memakai secret lama
+func (s *Service) UpdateWebhook(ctx context.Context, w *model.Webhook) error {
+	ctx, span := tracing.Start(ctx, "service.UpdateWebhook")
+	defer span.End()
+
+	if err := prepareWebhook(w); err != nil {
+		return err
+	}
+	if w.Secret == "" {
+		old, err := s.repo.GetWebhookByID(ctx, w.ID)
+		if err != nil {
+			return err
+		}
+		w.Secret = old.Secret
+	}
+	return s.repo.UpdateWebhook(ctx, w)
+}
+
+// DeleteWebhook menghapus webhook beserta log delivery-nya
+func (s *Service) DeleteWebhook(ctx context.Context, id int) error {
+	ctx, span := tracing.Start(ctx, "service.DeleteWebhook")
+	defer span.End()
+
+	return s.repo.DeleteWebhook(ctx, id)
+}
+
+// RedeliverWebhook menjadwalkan ulang payload dari log delivery sebagai delivery baru
+// Payload dikirim apa adanya; signature dihitung ulang dengan secret webhook saat ini
+func (s *Service) RedeliverWebhook(ctx context.Context, id int) error {
+	ctx, span := tracing.Start(ctx, "service.RedeliverWebhook")
+	defer span.End()
+
+	d, err := s.repo.GetWebhookDeliveryByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.repo.CreateWebhookDelivery(ctx, &model.WebhookDelivery{
+		WebhookID:     d.WebhookID,
+		Event:         d.Event,
+		Payload:       d.Payload,
+		NextAttemptAt: time.Now(),
+		MessageID:     d.MessageID,
+	})
+}
+
+// emit mencatat delivery untuk setiap webhook aktif yang melanggan event.
+// Pengiriman dilakukan job latar belakang, jadi kegagalan di sini cukup dicatat di log
+func (s *Service) emit(ctx context.Context, event, summary string, data any) {
+	s.emitFor(ctx, event, summary, data, nil)
+}
+
+// emitFor seperti emit, dengan delivery ditautkan ke pesan kontak (jika messageID tidak nil)
+// agar log delivery yang memuat data pengunjung ikut terhapus bersama pesannya
+func (s *Service) emitFor(ctx context.Context, event, summary string, data any, messageID *int) {
+	webhooks, err := s.repo.GetAllWebhooks(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Gagal mengambil webhook", "event", event, "error", err)
+		return
+	}
+
+	ev := webhook.Event{Name: event, Summary: summary, OccurredAt: time.Now(), Data: data}
+	for _, w := range webhooks {
+		if !w.Active || !w.Subscribed(event) {
+			continue
+		}
+		payload, err := webhook.Payload(w.Format, w.ChatID, ev)
+		if err == nil {
+			err = s.repo.CreateWebhookDelivery(ctx, &model.WebhookDelivery{
+				WebhookID:     w.ID,
+				Event:         event,
+				Payload:       string(payload),
+				NextAttemptAt: ev.OccurredAt,
+				MessageID:     messageID,
+			})
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "Gagal menyiapkan webhook", "webhook", w.Name, "event", event, "error", err)
+		}
+	}
+}
+
+// emitContent mengirim event experience/project/techstack (action: created, updated)
+// dengan isi konten terbaru dari database sebagai data payload
+func (s *Service) emitContent(ctx context.Context, entity, action string, id int) {
+	data, title, err := s.currentVersion(ctx, entity, strconv.Itoa(id))
+	if err != nil {
+		slog.ErrorContext(ctx, "Gagal mengambil konten untuk event webhook", "entity", entity, "id", id, "error", err)
+		return
+	}
+	s.emitContentData(ctx, entity, action, title, data)
+}
+
+// emitContentData mengirim event konten dengan judul dan data yang sudah diambil
+func (s *Service) emitContentData(ctx context.Context, entity, action, title string, data any) {
+	summary := i18n.T(i18n.Default, "webhook."+action, i18n.T(i18n.Default, "webhook.entity."+entity), html.UnescapeString(title))
+	s.emit(ctx, entity+"."+action, summary, data)
+}
+
+// emitConfig mengirim event config.updated untuk satu key konfigurasi situs
+func (s *Service) emitConfig(ctx context.Context, key, value string) {
+	s.emit(ctx, model.EventConfigUpdated, i18n.T(i18n.Default, "webhook.config_updated", key), map[string]string{"key": key, "value": value})
+}
+
+// ProcessWebhookQueue mengirim delivery yang jadwalnya sudah tiba.
+// Delivery yang gagal (error jaringan atau status selain 2xx) dijadwalkan ulang dengan
+// jeda eksponensial, lalu ditandai gagal setelah webhookMaxAttempts percobaan
+func (s *Service) ProcessWebhookQueue(ctx context.Context) (delivered, failed int, err error) {
+	ctx, span := tracing.Start(ctx, "service.ProcessWebhookQueue")
+	defer span.End()
+
+	deliveries, err := s.repo.GetDueWebhookDeliveries(ctx, time.Now(), webhookBatchSize)
+	if err != nil || len(deliveries) == 0 {
+		return 0, 0, err
+	}
+
+	webhooks, err := s.repo.GetAllWebhooks(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	byID := make(map[int]model.Webhook, len(webhooks))
+	for _, w := range webhooks {
+		byID[w.ID] = w
+	}
+
+	for _, d := range deliveries {
+		if ctx.Err() != nil {
+			break
+		}
+
+		w := byID[d.WebhookID]
+		result, sendErr := s.webhooks.Deliver(ctx, w.URL, w.Secret, d.Event, d.ID, []byte(d.Payload))
+
+		now := time.Now()
+		switch {
+		case sendErr == nil:
+			err = s.repo.MarkWebhookDelivered(ctx, d.ID, result.StatusCode, result.Body, now)
+			delivered++
+		case d.Attempts+1 >= webhookMaxAttempts:
+			err = s.repo.MarkWebhookFailed(ctx, d.ID, result.StatusCode, result.Body, sendErr.Error(), now)
+			failed++
+		default:
+			next := now.Add(retryDelay(d.Attempts, webhookRetryBase, webhookRetryMax))
+			err = s.repo.MarkWebhookRetry(ctx, d.ID, result.StatusCode, result.Body, sendErr.Error(), next)
+			failed++
+		}
+		if err != nil {
+			return delivered, failed, err
+		}
+	}
+	return delivered, failed, nil
+}
+
+// ============================================
+// TRASH — Sampah (Restore & Purge Admin)
+// ============================================
+
+// GetTrash mengambil semua konten yang ada di sampah
+func (s *Service) GetTrash(ctx context.Context) ([]model.TrashItem, error) {
+	ctx, span := tracing.Start(ctx, "service.GetTrash")
+	defer span.End()
+
+	return s.repo.GetTrash(ctx)
+}
+
+// RestoreFromTrash memulihkan konten dari sampah
+func (s *Service) RestoreFromTrash(ctx context.Context, entity string, id int) error {
+	ctx, span := tracing.Start(ctx, "service.RestoreFromTrash")
+	defer span.End()
+
+	if err := s.repo.RestoreFromTrash(ctx, entity, id); err != nil {
+		return err
+	}
+	s.contentChanged()
+	return nil
+}
+
+// PurgeFromTrash menghapus permanen satu konten dari sampah
+func (s *Service) PurgeFromTrash(ctx context.Context, entity string, id int) error {
+	ctx, span := tracing.Start(ctx, "service.PurgeFromTrash")
+	defer span.End()
+
+	return s.repo.PurgeFromTrash(ctx, entity, id)
+}
+
+// PurgeExpiredTrash menghapus permanen konten yang sudah di sampah lebih lama dari retention
+// Dipanggil berkala oleh background job
+func (s *Service) PurgeExpiredTrash(ctx context.Context, retention time.Duration) (int, error) {
+	ctx, span := tracing.Start(ctx, "service.PurgeExpiredTrash")
+	defer span.End()
+
+	return s.repo.PurgeTrashBefore(ctx, time.Now().Add(-retention))
+}
+
+// ============================================
+// PRIVACY — Retensi & Permintaan Privasi Pengunjung
+// ============================================
+
+// ErrInvalidPrivacyKind dikembalikan jika jenis permintaan privasi tidak dikenal
+var ErrInvalidPrivacyKind = errors.New("jenis permintaan privasi tidak dikenal")
+
+// ErrPrivacySecretRequired dikembalikan jika SESSION_SECRET tidak diisi: hash email di log
+// privasi harus memakai kunci rahasia yang tetap agar tidak bisa ditebak dan tetap cocok
+// untuk permintaan berikutnya
+var ErrPrivacySecretRequired = errors.New("SESSION_SECRET harus diisi untuk mencatat permintaan privasi")
+
+// privacyLogLimit adalah jumlah entri log privasi yang ditampilkan di dashboard
+const privacyLogLimit = 100
+
+// ErasePersonalData menjalankan permintaan penghapusan (kind erase) atau anonimisasi
+// (kind anonymize) untuk semua data milik alamat email: pesan, balasan, email antrean,
+// dan log delivery webhook. Permintaan dicatat di log privasi walaupun tidak ada data
+// yang ditemukan, dengan email disimpan sebagai hash dan versi tersamar saja
+func (s *Service) ErasePersonalData(ctx context.Context, kind, email, note string) (*model.PrivacyRequest, error) {
+	ctx, span := tracing.Start(ctx, "service.ErasePersonalData")
+	defer span.End()
+
+	if kind != model.PrivacyErase && kind != model.PrivacyAnonymize {
+		return nil, ErrInvalidPrivacyKind
+	}
+	if s.cfg.EphemeralSecret || s.cfg.SessionSecret == "" {
+		return nil, ErrPrivacySecretRequired
+	}
+	email = strings.TrimSpace(email)
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email || len(email) > 254 {
+		return nil, fmt.Errorf("alamat email tidak valid: %q", email)
+	}
+	note = strings.TrimSpace(note)
+	if len([]rune(note)) > 500 {
+		return nil, fmt.Errorf("catatan maksimal 500 karakter")
+	}
+
+	req := &model.PrivacyRequest{
+		Kind:        kind,
+		SubjectHash: s.privacySubject(email),
+		SubjectHint: maskEmail(email),
+		Note:        sanitizeInput(note),
+	}
+	// Pesan menyimpan email dalam bentuk ter-escape (sanitizeInput), antrean email dalam bentuk asli
+	emails := []string{email}
+	if escaped := sanitizeInput(email); escaped != email {
+		emails = append(emails, escaped)
+	}
+	if err := s.repo.ErasePersonalData(ctx, req, emails, kind == model.PrivacyAnonymize); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// PurgeExpiredMessages menghapus permanen pesan kontak yang lebih tua dari retention
+// beserta balasan, email antrean, dan log delivery webhook terkait. Purge yang
+// menghapus data dicatat di log privasi. Dipanggil berkala oleh background job
+func (s *Service) PurgeExpiredMessages(ctx context.Context, retention time.Duration) (*model.PrivacyRequest, error) {
+	ctx, span := tracing.Start(ctx, "service.PurgeExpiredMessages")
+	defer span.End()
+
+	req := &model.PrivacyRequest{Kind: model.PrivacyRetention}
+	if err := s.repo.PurgeMessagesBefore(ctx, req, time.Now().Add(-retention)); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// GetPrivacyRequests mengambil log permintaan privasi terbaru
+func (s *Service) GetPrivacyRequests(ctx context.Context) ([]model.PrivacyRequest, error) {
+	ctx, span := tracing.Start(ctx, "service.GetPrivacyRequests")
+	defer span.End()
+
+	return s.repo.GetPrivacyRequests(ctx, privacyLogLimit)
+}
+
+// privacySubject menghitung identitas pemohon di log privasi: HMAC-SHA256 email
+// (huruf kecil) dengan SESSION_SECRET. Permintaan ulang dari email yang sama bisa
+// dicocokkan tanpa menyimpan alamatnya; tanpa secret yang asli (lihat ErrPrivacySecretRequired)
+// hash bisa dicocokkan dengan tebakan email sehingga tidak lagi pseudonim
+func (s *Service) privacySubject(email string) string {
+	mac := hmac.New(sha256.New, []byte(s.cfg.SessionSecret))
+	mac.Write([]byte(strings.ToLower(email)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// maskEmail menyamarkan alamat email untuk ditampilkan: budi@gmail.com → b***@gmail.com
+func maskEmail(email string) string {
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok || local == "" {
+		return "***"
+	}
+	first, _ := utf8.DecodeRuneInString(local)
+	return string(first) + "***@" + domain
+}
+
+// ============================================
+// CSP REPORTS — Laporan Pelanggaran Content Security Policy
+// ============================================
+
+const (
+	cspReportKeep      = 500 // Jumlah laporan CSP berbeda yang disimpan
+	cspReportLimit     = 100 // Jumlah laporan CSP yang ditampilkan di dashboard
+	cspReportFieldMax  = 512 // Panjang maksimal URL/directive di laporan CSP
+	cspReportSampleMax = 200 // Panjang maksimal potongan kode di laporan CSP
+)
+
+// ErrInvalidCSPReport dikembalikan jika laporan CSP tidak menyebut directive yang dilanggar
+var ErrInvalidCSPReport = errors.New("laporan CSP tidak valid")
+
+// RecordCSPReport menyimpan laporan pelanggaran CSP dari browser.
+// Query string dan fragment URL dibuang (bisa berisi token, misal link preview)
+// dan field dipotong agar laporan palsu berukuran besar tidak memenuhi database
+func (s *Service) RecordCSPReport(ctx context.Context, report *model.CSPReport) error {
+	ctx, span := tracing.Start(ctx, "service.RecordCSPReport")
+	defer span.End()
+
+	report.Directive = excerpt(report.Directive, cspReportFieldMax)
+	if report.Directive == "" {
+		return ErrInvalidCSPReport
+	}
+	report.DocumentURI = excerpt(stripQuery(report.DocumentURI), cspReportFieldMax)
+	report.BlockedURI = excerpt(stripQuery(report.BlockedURI), cspReportFieldMax)
+	report.SourceFile = excerpt(stripQuery(report.SourceFile), cspReportFieldMax)
+	report.Sample = excerpt(report.Sample, cspReportSampleMax)
+	report.Disposition = excerpt(report.Disposition, 16)
+	report.LineNumber = max(report.LineNumber, 0)
+	return s.repo.SaveCSPReport(ctx, report, cspReportKeep)
+}
+
+// GetCSPReports mengambil laporan pelanggaran CSP terbaru untuk dashboard
+func (s *Service) GetCSPReports(ctx context.Context) ([]model.CSPReport, error) {
+	ctx, span := tracing.Start(ctx, "service.GetCSPReports")
+	defer span.End()
+
+	return s.repo.GetCSPReports(ctx, cspReportLimit)
+}
+
+// ClearCSPReports menghapus semua laporan pelanggaran CSP
+func (s *Service) ClearCSPReports(ctx context.Context) error {
+	ctx, span := tracing.Start(ctx, "service.ClearCSPReports")
+	defer span.End()
+
+	return s.repo.ClearCSPReports(ctx)
+}
+
+// stripQuery membuang query string dan fragment dari URL di laporan CSP
+// Nilai non-URL seperti "inline" atau "eval" dikembalikan apa adanya
+func stripQuery(raw string) string {
+	raw, _, _ = strings.Cut(raw, "#")
+	raw, _, _ = strings.Cut(raw, "?")
+	return raw
+}
+
+// ============================================
+// REVISIONS — Riwayat Perubahan & Rollback
+// ============================================
+
+// GetRevisionHistory mengambil riwayat revisi satu konten, terbaru duluan
+// Setiap revisi dilengkapi daftar field yang berubah dibanding versi sesudahnya
+// (revisi yang lebih baru, atau isi saat ini untuk revisi terbaru)
+func (s *Service) GetRevisionHistory(ctx context.Context, entity, key string) (*model.RevisionHistory, error) {
+	ctx, span := tracing.Start(ctx, "service.GetRevisionHistory")
+	defer span.End()
+
+	current, title, err := s.currentVersion(ctx, entity, key)
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := s.repo.GetRevisions(ctx, entity, key)
+	if err != nil {
+		return nil, err
+	}
+
+	newer := revisionFields(current)
+	for i := range revisions {
+		snapshot, err := decodeSnapshot(entity, revisions[i].Snapshot)
+		if err != nil {
+			return nil, err
+		}
+		fields := revisionFields(snapshot)
+		revisions[i].Changes = diffFields(fields, newer)
+		newer = fields
+	}
+
+	return &model.RevisionHistory{Entity: entity, Key: key, Title: title, Revisions: revisions}, nil
+}
+
+// RestoreRevision mengembalikan konten ke isi revisi tertentu
+// Isi saat ini otomatis tersimpan sebagai revisi baru, sehingga rollback bisa dibatalkan.
+// Snapshot sudah tersanitasi saat disimpan, jadi tidak disanitasi ulang di sini.
+func (s *Service) RestoreRevision(ctx context.Context, id int) (*model.Revision, error) {
+	ctx, span := tracing.Start(ctx, "service.RestoreRevision")
+	defer span.End()
+
+	rev, err := s.repo.GetRevisionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := decodeSnapshot(rev.Entity, rev.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := snapshot.(type) {
+	case *model.Experience:
+		err = s.repo.UpdateExperience(ctx, v)
+	case *model.Project:
+		err = s.repo.UpdateProject(ctx, v)
+	case *model.TechStack:
+		err = s.repo.UpdateTechStack(ctx, v)
+	case string:
+		err = s.repo.UpdateConfig(ctx, rev.EntityKey, v)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("gagal memulihkan revisi ID %d: %w", id, err)
+	}
+	s.contentChanged()
+
+	if value, ok := snapshot.(string); ok {
+		s.emitConfig(ctx, rev.EntityKey, value)
+	} else {
+		id, _ := strconv.Atoi(rev.EntityKey)
+		s.emitContent(ctx, rev.Entity, "updated", id)
+	}
+	return rev, nil
+}
+
+// currentVersion mengambil isi konten saat ini beserta judul ringkasnya
+func (s *Service) currentVersion(ctx context.Context, entity, key string) (any, string, error) {
+	if entity == model.RevisionConfig {
+		config, err := s.repo.GetAllConfig(ctx)
+		if err != nil {
+			return nil, "", err
+		}
+		value, ok := config[key]
+		if !ok {
+			return nil, "", fmt.Errorf("konfigurasi %q tidak ditemukan", key)
+		}
+		return value, key, nil
+	}
+
+	id, err := strconv.Atoi(key)
+	if err != nil {
+		return nil, "", fmt.Errorf("ID konten tidak valid: %q", key)
+	}
+	switch entity {
+	case model.RevisionExperience:
+		exp, err := s.repo.GetExperienceByID(ctx, id)
+		if err != nil {
+			return nil, "", err
+		}
+		return exp, exp.Role + " @ " + exp.Company, nil
+	case model.RevisionProject:
+		proj, err := s.repo.GetProjectByID(ctx, id)
+		if err != nil {
+			return nil, "", err
+		}
+		return proj, proj.Title, nil
+	case model.RevisionTechStack:
+		ts, err := s.repo.GetTechStackByID(ctx, id)
+		if err != nil {
+			return nil, "", err
+		}
+		tags, err := s.repo.GetTagsByTechStack(ctx)
+		if err != nil {
+			return nil, "", err
+		}
+		ts.Tags = tags[ts.ID]
+		return ts, ts.Name, nil
+	default:
+		return nil, "", ErrUnknownEntity
+	}
+}
+
+// ============================================
+// TRANSLATIONS — Terjemahan Konten
+// ============================================
+
+// translationLabels berisi label field terjemahan di editor dashboard
+var translationLabels = map[string]string{
+	"company":     "Perusahaan",
+	"role":        "Posisi / Peran",
+	"title":       "Judul",
+	"category":    "Kategori",
+	"name":        "Nama",
+	"description": "Deskripsi",
+	"value":       "Nilai",
+}
+
+// GetTranslationEditor menyusun data editor terjemahan dashboard untuk satu locale:
+// teks asli (bahasa Indonesia) berdampingan dengan terjemahannya.
+// Draft ikut ditampilkan agar bisa diterjemahkan sebelum terbit
+func (s *Service) GetTranslationEditor(ctx context.Context, locale string) ([]model.TranslationEntry, error) {
+	ctx, span := tracing.Start(ctx, "service.GetTranslationEditor")
+	defer span.End()
+
+	translations, err := s.repo.GetTranslations(ctx, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []model.TranslationEntry
+	add := func(entity, key, title string, sources ...string) {
+		entry := model.TranslationEntry{Entity: entity, Key: key, Title: title}
+		for i, field := range model.TranslatableFields[entity] {
+			value := translations[model.TranslationKey(entity, key, field)]
+			entry.Fields = append(entry.Fields, model.TranslationField{
+				Field: field, Label: translationLabels[field], Source: sources[i], Value: value,
+			})
+			if sources[i] != "" && value == "" {
+				entry.Missing++
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	config, err := s.repo.GetAllConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range model.TranslatableConfigKeys {
+		add(model.RevisionConfig, key, key, config[key])
+	}
+
+	experiences, err := s.repo.GetAllExperiences(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, exp := range experiences {
+		add(model.RevisionExperience, strconv.Itoa(exp.ID), exp.Role+" @ "+exp.Company, exp.Company, exp.Role, exp.Description)
+	}
+
+	projects, err := s.repo.GetAllProjects(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, proj := range projects {
+		add(model.RevisionProject, strconv.Itoa(proj.ID), proj.Title, proj.Title, proj.Role, proj.Description)
+	}
+
+	techStacks, err := s.repo.GetAllTechStacks(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, ts := range techStacks {
+		add(model.RevisionTechStack, strconv.Itoa(ts.ID), ts.Name, ts.Category, ts.Name, ts.Description)
+	}
+	return entries, nil
+}
+
+// SaveTranslations menyimpan terjemahan satu konten ke locale selain bahasa Indonesia
+// Hanya field di model.TranslatableFields yang disimpan, field lain diabaikan
+func (s *Service) SaveTranslations(ctx context.Context, entity, key, locale string, values map[string]string) error {
+	ctx, span := tracing.Start(ctx, "service.SaveTranslations")
+	defer span.End()
+
+	if !i18n.Supported(locale) || locale == i18n.Default {
+		return fmt.Errorf("locale terjemahan tidak valid: %q", locale)
+	}
+	fields, ok := model.TranslatableFields[entity]
+	if !ok {
+		return ErrUnknownEntity
+	}
+	if entity == model.RevisionConfig {
+		if !slices.Contains(model.TranslatableConfigKeys, key) {
+			return fmt.Errorf("konfigurasi %q tidak bisa diterjemahkan", key)
+		}
+	} else if _, _, err := s.currentVersion(ctx, entity, key); err != nil {
+		return err
+	}
+
+	clean := make(map[string]string, len(fields))
+	for _, field := range fields {
+		if value, ok := values[field]; ok {
+			clean[field] = sanitizeInput(value)
+		}
+	}
+	if err := s.repo.SaveTranslations(ctx, entity, key, locale, clean); err != nil {
+		return err
+	}
+	s.contentChanged()
+	return nil
+}
+
+// ============================================
+// SITE CONFIG — Konfigurasi (CRUD Admin)
+// ============================================
+
+// GetAllConfig mengambil semua konfigurasi situs
+func (s *Service) GetAllConfig(ctx context.Context) (map[string]string, error) {
+	ctx, span := tracing.Start(ctx, "service.GetAllConfig")
+	defer span.End()
+
+	return s.repo.GetAllConfig(ctx)
+}
+
+// UpdateConfig memperbarui konfigurasi situs
+func (s *Service) UpdateConfig(ctx context.Context, key, value string) error {
+	ctx, span := tracing.Start(ctx, "service.UpdateConfig")
+	defer span.End()
+
+	key, value = sanitizeInput(key), sanitizeInput(value)
+	configs, err := s.repo.GetAllConfig(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.UpdateConfig(ctx, key, value); err != nil {
+		return err
+	}
+
+	// Form konfigurasi menyimpan semua key sekaligus; event hanya untuk nilai yang berubah
+	if configs[key] != value {
+		s.contentChanged()
+		s.emitConfig(ctx, key, value)
+	}
+	return nil
+}
+
+// ============================================
+// HELPER FUNCTIONS
+// ============================================
+
+// prepareExperience melakukan sanitasi dan validasi data pengalaman kerja sebelum disimpan
+func prepareExperience(exp *model.Experience) error {
+	exp.Company = sanitizeInput(exp.Company)
+	exp.Role = sanitizeInput(exp.Role)
+	exp.Description = sanitizeInput(exp.Description)
+	exp.Published, exp.PublishAt = normalizePublication(exp.Published, exp.PublishAt, time.Now())
+
+	if exp.StartDate == nil {
+		return fmt.Errorf("tanggal mulai experience wajib diisi")
+	}
+	// Pengalaman yang masih berjalan tidak punya tanggal selesai
+	if exp.IsCurrent {
+		exp.EndDate = nil
+	} else if exp.EndDate == nil {
+		return fmt.Errorf("tanggal selesai experience wajib diisi jika tidak sedang bekerja di sana")
+	} else if exp.EndDate.Before(*exp.StartDate) {
+		return fmt.Errorf("tanggal selesai experience tidak boleh sebelum tanggal mulai")
+	}
+	return nil
+}
+
+// totalExperienceMonths menghitung total masa kerja dalam bulan
+// Periode yang tumpang tindih (misal: freelance sambil kerja penuh waktu) hanya dihitung sekali.
+// Bulan mulai dan bulan selesai sama-sama dihitung.
+func totalExperienceMonths(experiences []model.Experience, now time.Time) int {
+	type interval struct{ from, to int } // Indeks bulan: tahun*12 + bulan
+	monthIndex := func(t time.Time) int { return t.Year()*12 + int(t.Month()) - 1 }
+
+	var intervals []interval
+	for _, exp := range experiences {
+		if exp.StartDate == nil {
+			continue
+		}
+		end := now
+		if !exp.IsCurrent && exp.EndDate != nil {
+			end = *exp.EndDate
+		}
+		intervals = append(intervals, interval{monthIndex(*exp.StartDate), monthIndex(end)})
+	}
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i].from < intervals[j].from })
+
+	total := 0
+	cur := interval{from: -1, to: -2}
+	for _, iv := range intervals {
+		if iv.from > cur.to+1 {
+			// Interval baru tidak bersambung — tutup interval sebelumnya
+			if cur.to >= cur.from {
+				total += cur.to - cur.from + 1
+			}
+			cur = iv
+		} else if iv.to > cur.to {
+			cur.to = iv.to
+		}
+	}
+	if cur.to >= cur.from {
+		total += cur.to - cur.from + 1
+	}
+	return total
+}
+
+// prepareProject melakukan sanitasi dan validasi data proyek sebelum disimpan
+func prepareProject(proj *model.Project) error {
+	proj.Title = sanitizeInput(proj.Title)
+	proj.Description = sanitizeInput(proj.Description)
+	proj.Role = sanitizeInput(proj.Role)
+	proj.GithubURL = sanitizeInput(proj.GithubURL)
+	proj.Tags = normalizeTags(proj.Tags)
+	proj.Published, proj.PublishAt = normalizePublication(proj.Published, proj.PublishAt, time.Now())
+
+	// Status kosong dianggap proyek aktif
+	switch proj.Status {
+	case "":
+		proj.Status = model.ProjectStatusActive
+	case model.ProjectStatusActive, model.ProjectStatusArchived:
+	default:
+		return fmt.Errorf("status proyek tidak valid: %q", proj.Status)
+	}
+
+	if proj.StartDate != nil && proj.EndDate != nil && proj.EndDate.Before(*proj.StartDate) {
+		return fmt.Errorf("tanggal selesai proyek tidak boleh sebelum tanggal mulai")
+	}
+	return nil
+}
+
+// normalizePublication merapikan status tayang sebelum disimpan:
+// konten yang sudah terbit tidak punya jadwal, jadwal yang sudah lewat langsung diterbitkan,
+// dan jadwal disimpan dalam UTC agar bisa dibandingkan oleh publisher
+func normalizePublication(published bool, publishAt *time.Time, now time.Time) (bool, *time.Time) {
+	if published || publishAt == nil {
+		return published, nil
+	}
+	if !publishAt.After(now) {
+		return true, nil
+	}
+	utc := publishAt.UTC()
+	return false, &utc
+}
+
+// normalizeTags membersihkan nama tag, membuang yang kosong,
+// dan menghapus duplikat (tidak membedakan huruf besar/kecil) dengan tetap menjaga urutan
+func normalizeTags(tags []model.Tag) []model.Tag {
+	seen := make(map[string]bool)
+	result := make([]model.Tag, 0, len(tags))
+	for _, tag := range tags {
+		name := sanitizeInput(tag.Name)
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, model.Tag{Name: name})
+	}
+	return result
+}
+
+// revisionField adalah satu field konten beserta nilainya dalam bentuk teks
+type revisionField struct {
+	label, value string
+}
+
+// decodeSnapshot mengubah JSON snapshot revisi menjadi struct model sesuai jenis konten
+// Snapshot site_config berupa string biasa. Snapshot yang dibuat sebelum ada kolom
+// published dianggap sudah terbit
+func decodeSnapshot(entity, snapshot string) (any, error) {
+	var v any
+	switch entity {
+	case model.RevisionExperience:
+		v = &model.Experience{Published: true}
+	case model.RevisionProject:
+		v = &model.Project{Published: true}
+	case model.RevisionTechStack:
+		v = &model.TechStack{Published: true}
+	case model.RevisionConfig:
+		var value string
+		if err := json.Unmarshal([]byte(snapshot), &value); err != nil {
+			return nil, fmt.Errorf("gagal decode revisi konfigurasi: %w", err)
+		}
+		return value, nil
+	default:
+		return nil, ErrUnknownEntity
+	}
+	if err := json.Unmarshal([]byte(snapshot), v); err != nil {
+		return nil, fmt.Errorf("gagal decode revisi %s: %w", entity, err)
+	}
+	return v, nil
+}
+
+// revisionFields mengubah isi konten menjadi daftar field yang bisa dibandingkan
+// Urutan field mengikuti urutan form di dashboard
+func revisionFields(v any) []revisionField {
+	formatDate := func(t *time.Time, layout string) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format(layout)
+	}
+	yesNo := func(b bool) string {
+		if b {
+			return "Ya"
+		}
+		return "Tidak"
+	}
+	publication := func(published bool, publishAt *time.Time) string {
+		if published {
+			return "Terbit"
+		}
+		if publishAt != nil {
+			return "Terjadwal " + publishAt.Local().Format("2006-01-02 15:04")
+		}
+		return "Draft"
+	}
+
+	switch v := v.(type) {
+	case *model.Experience:
+		return []revisionField{
+			{"Perusahaan", v.Company},
+			{"Posisi", v.Role},
+			{"Mulai", formatDate(v.StartDate, "2006-01")},
+			{"Selesai", formatDate(v.EndDate, "2006-01")},
+			{"Masih bekerja", yesNo(v.IsCurrent)},
+			{"Deskripsi", v.Description},
+			{"Status tayang", publication(v.Published, v.PublishAt)},
+		}
+	case *model.Project:
+		return []revisionField{
+			{"Judul", v.Title},
+			{"Deskripsi", v.Description},
+			{"Teknologi", v.TagNames()},
+			{"Peran", v.Role},
+			{"Status", v.Status},
+			{"Mulai", formatDate(v.StartDate, "2006-01-02")},
+			{"Selesai", formatDate(v.EndDate, "2006-01-02")},
+			{"Link", v.Link},
+			{"GitHub URL", v.GithubURL},
+			{"Gambar URL", v.ImageURL},
+			{"Status tayang", publication(v.Published, v.PublishAt)},
+		}
+	case *model.TechStack:
+		return []revisionField{
+			{"Kategori", v.Category},
+			{"Nama", v.Name},
+			{"Deskripsi", v.Description},
+			{"Tag", v.TagNames()},
+			{"Status tayang", publication(v.Published, v.PublishAt)},
+		}
+	case string:
+		return []revisionField{{"Nilai", v}}
+	}
+	return nil
+}
+
+// diffFields membandingkan dua versi konten field per field
+// Hanya field yang nilainya berbeda yang dikembalikan
+func diffFields(older, newer []revisionField) []model.FieldChange {
+	var changes []model.FieldChange
+	for i, field := range older {
+		if i < len(newer) && field.value != newer[i].value {
+			changes = append(changes, model.FieldChange{Field: field.label, Old: field.value, New: newer[i].value})
+		}
+	}
+	return changes
+}
+
+// translatePortfolio mengganti teks konten dengan terjemahannya
+// Field yang belum diterjemahkan tetap memakai teks bahasa Indonesia
+func translatePortfolio(data *model.PortfolioData, t model.Translations) {
+	config := make(map[string]string, len(data.Config))
+	for key, value := range data.Config {
+		config[key] = value
+	}
+	for _, key := range model.TranslatableConfigKeys {
+		config[key] = t.Get(model.RevisionConfig, key, "value", config[key])
+	}
+	data.Config = config
+
+	for i := range data.Experiences {
+		exp := &data.Experiences[i]
+		key := strconv.Itoa(exp.ID)
+		exp.Company = t.Get(model.RevisionExperience, key, "company", exp.Company)
+		exp.Role = t.Get(model.RevisionExperience, key, "role", exp.Role)
+		exp.Description = t.Get(model.RevisionExperience, key, "description", exp.Description)
+	}
+	for i := range data.Projects {
+		translateProject(&data.Projects[i], t)
+	}
+	for i := range data.TechStacks {
+		ts := &data.TechStacks[i]
+		key := strconv.Itoa(ts.ID)
+		ts.Category = t.Get(model.RevisionTechStack, key, "category", ts.Category)
+		ts.Name = t.Get(model.RevisionTechStack, key, "name", ts.Name)
+		ts.Description = t.Get(model.RevisionTechStack, key, "description", ts.Description)
+		for j := range ts.Projects {
+			translateProject(&ts.Projects[j], t)
+		}
+	}
+}
+
+// translateProject mengganti teks satu proyek dengan terjemahannya
+func translateProject(proj *model.Project, t model.Translations) {
+	key := strconv.Itoa(proj.ID)
+	proj.Title = t.Get(model.RevisionProject, key, "title", proj.Title)
+	proj.Role = t.Get(model.RevisionProject, key, "role", proj.Role)
+	proj.Description = t.Get(model.RevisionProject, key, "description", proj.Description)
+}
+
+// linkPattern mencocokkan URL atau domain yang sering dipakai bot (http://, www., [url=...)
+var linkPattern = regexp.MustCompile(`(?i)https?://|www\.|\[url=`)
+
+// spamScore menghitung skor heuristik spam pesan kontak beserta alasannya
+// Semakin tinggi skor, semakin besar kemungkinan pesan berasal dari bot/spammer
+func spamScore(form *model.ContactForm, blockedWords []string) (int, []string) {
+	score := 0
+	var reasons []string
+
+	// Pesan manusia jarang berisi lebih dari satu link
+	if n := len(linkPattern.FindAllString(form.Message, -1)); n > 0 {
+		score += n
+		reasons = append(reasons, fmt.Sprintf("%d link", n))
+	}
+	// Nama yang berisi link hampir pasti spam
+	if linkPattern.MatchString(form.Name) {
+		score += 3
+		reasons = append(reasons, "link di nama")
+	}
+
+	text := strings.ToLower(form.Name + " " + form.Message)
+	var matched []string
+	for _, word := range blockedWords {
+		if strings.Contains(text, strings.ToLower(word)) {
+			matched = append(matched, word)
+		}
+	}
+	if len(matched) > 0 {
+		score += 2 * len(matched)
+		reasons = append(reasons, "kata terlarang: "+strings.Join(matched, ", "))
+	}
+	return score, reasons
+}
+
+// retryDelay menghitung jeda eksponensial sebelum percobaan ulang ke-(attempts+1):
+// base, 2×base, 4×base, ... dibatasi maxDelay
+func retryDelay(attempts int, base, maxDelay time.Duration) time.Duration {
+	delay := base
+	for i := 0; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
+
+// prepareWebhook memvalidasi pengaturan webhook dari dashboard sebelum disimpan
+// URL harus http/https, format harus dikenal, dan minimal satu event dipilih
+func prepareWebhook(w *model.Webhook) error {
+	w.Name = sanitizeInput(w.Name)
+	w.URL = strings.TrimSpace(w.URL)
+	w.Secret = strings.TrimSpace(w.Secret)
+	w.ChatID = strings.TrimSpace(w.ChatID)
+
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("URL webhook harus diawali http:// atau https://")
+	}
+	if w.Name == "" {
+		w.Name = u.Host
+	}
+	if !webhook.SupportedFormat(w.Format) {
+		return fmt.Errorf("format webhook tidak dikenal: %q", w.Format)
+	}
+	if w.Format == webhook.FormatTelegram && w.ChatID == "" {
+		return fmt.Errorf("chat ID wajib diisi untuk format telegram")
+	}
+
+	// Simpan event dengan urutan baku dan buang yang tidak dikenal
+	var events []string
+	for _, event := range model.WebhookEvents {
+		if slices.Contains(w.Events, event) {
+			events = append(events, event)
+		}
+	}
+	if len(events) == 0 {
+		return fmt.Errorf("pilih minimal satu event webhook")
+	}
+	w.Events = events
+	return nil
+}
+
+// randomSecret membuat secret webhook acak (32 byte, hex)
+func randomSecret() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// excerpt memotong teks menjadi maksimal n karakter (rune) dengan elipsis
+func excerpt(text string, n int) string {
+	runes := []rune(strings.TrimSpace(text))
+	if len(runes) <= n {
+		return string(runes)
+	}
+	return strings.TrimSpace(string(runes[:n])) + "…"
+}
 
 // sanitizeInput membersihkan input dari karakter HTML berbahaya
 // untuk mencegah serangan XSS (Cross-Site Scripting)
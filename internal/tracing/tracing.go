@@ -0,0 +1,90 @@
+// Package tracing menyiapkan OpenTelemetry tracing: exporter (OTLP/HTTP ke collector
+// atau stdout untuk development), TracerProvider global, dan propagasi W3C traceparent.
+// Span request dibuat middleware otelgin; service dan repository membuat span anak
+// lewat Start sehingga satu trace memperlihatkan handler → service → query database
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Exporter span yang didukung
+const (
+	ExporterNone   = "none"   // Tracing nonaktif
+	ExporterOTLP   = "otlp"   // OTLP/HTTP ke collector (Jaeger, Tempo, OpenTelemetry Collector, dst)
+	ExporterStdout = "stdout" // Span ditulis sebagai JSON ke stdout (untuk development)
+)
+
+// tracerName adalah nama instrumentation scope untuk span service dan repository
+const tracerName = "portofolio-go"
+
+// Options berisi pengaturan tracing dari konfigurasi aplikasi
+type Options struct {
+	Exporter       string    // none, otlp, atau stdout
+	Endpoint       string    // URL lengkap OTLP/HTTP traces (misal: http://localhost:4318/v1/traces)
+	ServiceName    string    // Atribut service.name di setiap span
+	ServiceVersion string    // Atribut service.version (commit build)
+	Stdout         io.Writer // Tujuan exporter stdout
+}
+
+// Setup memasang TracerProvider dan propagator W3C (traceparent + baggage) global sesuai
+// exporter yang dipilih. Fungsi shutdown yang dikembalikan mengirim span tersisa dan harus
+// dipanggil saat server berhenti. Exporter none mengembalikan shutdown kosong
+func Setup(ctx context.Context, opts Options) (shutdown func(context.Context) error, err error) {
+	var exporter sdktrace.SpanExporter
+	switch strings.ToLower(opts.Exporter) {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		exporter, err = otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(opts.Endpoint))
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(opts.Stdout))
+	default:
+		return nil, fmt.Errorf("OTEL_TRACES_EXPORTER tidak dikenal: %q (pilihan: none, otlp, stdout)", opts.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat exporter %s: %w", opts.Exporter, err)
+	}
+
+	provider := Install(exporter, opts.ServiceName, opts.ServiceVersion)
+	return provider.Shutdown, nil
+}
+
+// Install memasang TracerProvider global yang mengirim span ke exporter, beserta propagator
+// W3C. Dipakai Setup, dan bisa dipakai tes dengan tracetest.NewInMemoryExporter untuk
+// memeriksa struktur span (panggil ForceFlush pada provider sebelum membaca span)
+func Install(exporter sdktrace.SpanExporter, serviceName, serviceVersion string) *sdktrace.TracerProvider {
+	res, _ := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", serviceName),
+		attribute.String("service.version", serviceVersion),
+	))
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider
+}
+
+// Start membuat span anak bernama name (misal: "service.CreateExperience").
+// Span hanya dibuat jika ctx sudah berada di dalam trace (request HTTP); pemanggilan dari
+// job latar belakang atau CLI tidak menghasilkan trace sendiri-sendiri per query
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
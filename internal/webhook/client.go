@@ -0,0 +1,62 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxResponseBody adalah panjang maksimal body respons yang disimpan di log delivery
+const maxResponseBody = 1024
+
+// Client mengirim payload webhook lewat HTTP POST
+type Client struct {
+	http *http.Client
+}
+
+// NewClient membuat Client dengan batas waktu per request
+func NewClient(timeout time.Duration) *Client {
+	return &Client{http: &http.Client{Timeout: timeout}}
+}
+
+// Result adalah respons endpoint tujuan untuk dicatat di log delivery
+type Result struct {
+	StatusCode int    // Kode status HTTP (0 jika tidak ada respons)
+	Body       string // Potongan body respons (maks 1 KB)
+}
+
+// Deliver mengirim body ke url dengan header event, ID delivery, timestamp, dan signature
+// (jika secret diisi). Setiap percobaan ulang memakai timestamp baru. Status selain 2xx dianggap gagal agar bisa dicoba ulang;
+// Result tetap diisi supaya kode respons tercatat
+func (c *Client) Deliver(ctx context.Context, url, secret, event string, deliveryID int, body []byte) (Result, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return Result{}, fmt.Errorf("gagal membuat request webhook: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "portofolio-go-webhook/1.0")
+	req.Header.Set(EventHeader, event)
+	req.Header.Set(DeliveryHeader, strconv.Itoa(deliveryID))
+	if secret != "" {
+		now := time.Now()
+		req.Header.Set(TimestampHeader, strconv.FormatInt(now.Unix(), 10))
+		req.Header.Set(SignatureHeader, Sign(secret, now, body))
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return Result{}, fmt.Errorf("gagal menghubungi webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	result := Result{StatusCode: resp.StatusCode, Body: string(respBody)}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return result, fmt.Errorf("webhook membalas status %d", resp.StatusCode)
+	}
+	return result, nil
+}
@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestDeliverSignature(t *testing.T) {
+	body := []byte(`{"event":"message.created"}`)
+	var got http.Header
+	var gotBody []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		gotBody, _ = io.ReadAll(r.Body)
+	}))
+	defer srv.Close()
+
+	before := time.Now()
+	if _, err := NewClient(5*time.Second).Deliver(context.Background(), srv.URL, "rahasia", "message.created", 42, body); err != nil {
+		t.Fatalf("Deliver: %v", err)
+	}
+
+	if got.Get(EventHeader) != "message.created" || got.Get(DeliveryHeader) != "42" {
+		t.Errorf("header event/delivery = %q/%q", got.Get(EventHeader), got.Get(DeliveryHeader))
+	}
+	ts, err := strconv.ParseInt(got.Get(TimestampHeader), 10, 64)
+	if err != nil || ts < before.Unix() || ts > time.Now().Unix() {
+		t.Fatalf("%s = %q, want waktu kirim", TimestampHeader, got.Get(TimestampHeader))
+	}
+	if err := Verify("rahasia", got.Get(SignatureHeader), got.Get(TimestampHeader), gotBody, time.Now(), SignatureTolerance); err != nil {
+		t.Fatalf("Verify request asli: %v", err)
+	}
+}
+
+func TestDeliverWithoutSecret(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+	}))
+	defer srv.Close()
+
+	if _, err := NewClient(5*time.Second).Deliver(context.Background(), srv.URL, "", "config.updated", 1, []byte(`{}`)); err != nil {
+		t.Fatal(err)
+	}
+	if got.Get(SignatureHeader) != "" || got.Get(TimestampHeader) != "" {
+		t.Errorf("header signature dikirim tanpa secret: %q %q", got.Get(SignatureHeader), got.Get(TimestampHeader))
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"event":"project.updated"}`)
+	sentAt := time.Unix(1_700_000_000, 0)
+	sig := Sign("rahasia", sentAt, body)
+	ts := strconv.FormatInt(sentAt.Unix(), 10)
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		timestamp string
+		body      string
+		now       time.Time
+		want      error
+	}{
+		{"valid", "rahasia", sig, ts, string(body), sentAt.Add(time.Minute), nil},
+		{"jam penerima sedikit tertinggal", "rahasia", sig, ts, string(body), sentAt.Add(-time.Minute), nil},
+		{"replay setelah toleransi", "rahasia", sig, ts, string(body), sentAt.Add(SignatureTolerance + time.Second), ErrSignatureExpired},
+		{"timestamp diganti", "rahasia", sig, strconv.FormatInt(sentAt.Unix()+600, 10), string(body), sentAt.Add(10 * time.Minute), ErrSignatureInvalid},
+		{"body diubah", "rahasia", sig, ts, `{"event":"project.deleted"}`, sentAt, ErrSignatureInvalid},
+		{"secret salah", "lain", sig, ts, string(body), sentAt, ErrSignatureInvalid},
+		{"timestamp bukan angka", "rahasia", sig, "kemarin", string(body), sentAt, ErrSignatureInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, tt.signature, tt.timestamp, []byte(tt.body), tt.now, SignatureTolerance)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Verify = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
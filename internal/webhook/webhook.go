@@ -0,0 +1,117 @@
+// Package webhook menyusun dan mengirim payload webhook keluar
+// Payload bisa berupa JSON generik (ditandatangani HMAC-SHA256) atau preset
+// yang langsung dipahami Slack, Discord, dan Telegram
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Format payload yang didukung
+const (
+	FormatJSON     = "json"     // {"event", "summary", "occurred_at", "data"}
+	FormatSlack    = "slack"    // Incoming webhook Slack: {"text"}
+	FormatDiscord  = "discord"  // Webhook Discord: {"content"}
+	FormatTelegram = "telegram" // Bot API sendMessage: {"chat_id", "text"}
+)
+
+// Formats adalah daftar format payload untuk pilihan di dashboard
+var Formats = []string{FormatJSON, FormatSlack, FormatDiscord, FormatTelegram}
+
+// Header yang dikirim bersama setiap payload
+const (
+	SignatureHeader = "X-Webhook-Signature" // "sha256=<hex HMAC "t=<timestamp>.<body>">"
+	TimestampHeader = "X-Webhook-Timestamp" // Waktu pengiriman (Unix detik), ikut ditandatangani
+	EventHeader     = "X-Webhook-Event"     // Nama event (misal: message.created)
+	DeliveryHeader  = "X-Webhook-Delivery"  // ID delivery, sama untuk setiap percobaan ulang
+)
+
+// SignatureTolerance adalah selisih waktu maksimal yang disarankan antara
+// X-Webhook-Timestamp dan jam penerima; request yang lebih tua dianggap replay
+const SignatureTolerance = 5 * time.Minute
+
+// Error verifikasi signature webhook (lihat Verify)
+var (
+	ErrSignatureInvalid = errors.New("signature webhook tidak valid")
+	ErrSignatureExpired = errors.New("timestamp webhook di luar toleransi")
+)
+
+// discordMaxContent adalah batas panjang field content pesan Discord
+const discordMaxContent = 2000
+
+// Event adalah satu kejadian di aplikasi yang dikirim ke webhook
+type Event struct {
+	Name       string    `json:"event"`       // Nama event (misal: project.updated)
+	Summary    string    `json:"summary"`     // Ringkasan satu baris untuk preset chat
+	OccurredAt time.Time `json:"occurred_at"` // Waktu kejadian
+	Data       any       `json:"data"`        // Isi konten terkait (format json saja)
+}
+
+// SupportedFormat mengecek apakah format payload dikenal
+func SupportedFormat(format string) bool {
+	return slices.Contains(Formats, format)
+}
+
+// Payload menyusun body JSON untuk format tertentu
+// chatID hanya dipakai format telegram
+func Payload(format, chatID string, ev Event) ([]byte, error) {
+	var body any
+	switch format {
+	case FormatJSON:
+		body = ev
+	case FormatSlack:
+		body = map[string]string{"text": ev.Summary}
+	case FormatDiscord:
+		content := []rune(ev.Summary)
+		if len(content) > discordMaxContent {
+			content = append(content[:discordMaxContent-1], '…')
+		}
+		body = map[string]string{"content": string(content)}
+	case FormatTelegram:
+		body = map[string]string{"chat_id": chatID, "text": ev.Summary}
+	default:
+		return nil, fmt.Errorf("format webhook tidak dikenal: %q", format)
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("gagal menyusun payload webhook %s: %w", ev.Name, err)
+	}
+	return payload, nil
+}
+
+// Sign menghitung nilai header signature untuk waktu kirim timestamp:
+// "sha256=" + hex(HMAC-SHA256(secret, "t=<unix>." + body)).
+// Timestamp ikut ditandatangani agar request lama yang disadap tidak bisa dikirim ulang
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("t=" + strconv.FormatInt(timestamp.Unix(), 10) + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify memeriksa header signature dan timestamp dari sisi penerima (contoh acuan
+// untuk penerima berbasis Go). Signature dihitung ulang dari body mentah dan dibandingkan
+// constant-time; timestamp yang berselisih lebih dari tolerance dari now ditolak
+func Verify(secret, signature, timestamp string, body []byte, now time.Time, tolerance time.Duration) error {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrSignatureInvalid
+	}
+	sentAt := time.Unix(unix, 0)
+	if !hmac.Equal([]byte(signature), []byte(Sign(secret, sentAt, body))) {
+		return ErrSignatureInvalid
+	}
+	if diff := now.Sub(sentAt).Abs(); diff > tolerance {
+		return ErrSignatureExpired
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+-- =============================================
+-- Migration: Metadata proyek terstruktur
+-- Deskripsi: Normalisasi tech_used menjadi tabel tags (many-to-many),
+--            serta menambah tanggal, status, dan peran di proyek
+-- =============================================
+
+-- Tabel tag teknologi (unik, tidak membedakan huruf besar/kecil)
+CREATE TABLE IF NOT EXISTS tags (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE COLLATE NOCASE,  -- Nama tag (misal: "Go", "React")
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Tabel relasi proyek <-> tag
+CREATE TABLE IF NOT EXISTS project_tags (
+    project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    position INTEGER DEFAULT 0,       -- Urutan tag di dalam proyek
+    PRIMARY KEY (project_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_tags_tag ON project_tags(tag_id);
+
+-- Kolom metadata proyek
+ALTER TABLE projects ADD COLUMN start_date DATE;                -- Tanggal mulai (opsional)
+ALTER TABLE projects ADD COLUMN end_date DATE;                  -- Tanggal selesai (NULL = masih berjalan)
+ALTER TABLE projects ADD COLUMN status TEXT NOT NULL DEFAULT 'active'
+    CHECK (status IN ('active', 'archived'));                   -- Status proyek
+ALTER TABLE projects ADD COLUMN role TEXT NOT NULL DEFAULT '';  -- Peran pemilik portofolio di proyek
+
+-- Isi tags dari tech_used dilakukan oleh backfill Go (internal/database/backfill.go)
@@ -0,0 +1,7 @@
+-- =============================================
+-- Migration: Hapus kolom tech_used
+-- Deskripsi: Data teknologi proyek sekarang disimpan di tabel tags
+--            dan project_tags (lihat 002_project_metadata.sql)
+-- =============================================
+
+ALTER TABLE projects DROP COLUMN tech_used;
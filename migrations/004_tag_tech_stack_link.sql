@@ -0,0 +1,20 @@
+-- =============================================
+-- Migration: Relasi tag proyek <-> tech stack
+-- Deskripsi: Setiap tag bisa menunjuk ke satu entri tech_stacks,
+--            sehingga tech stack bisa menampilkan proyek yang memakainya
+-- =============================================
+
+ALTER TABLE tags ADD COLUMN tech_stack_id INTEGER
+    REFERENCES tech_stacks(id) ON DELETE SET NULL;    -- Entri tech stack terkait (opsional)
+
+CREATE INDEX IF NOT EXISTS idx_tags_tech_stack ON tags(tech_stack_id);
+
+-- Hubungkan otomatis tag yang namanya sama dengan tech stack,
+-- termasuk nama dengan keterangan dalam kurung (misal: tag "Go" -> "Go (Golang)")
+UPDATE tags SET tech_stack_id = (
+    SELECT ts.id FROM tech_stacks ts
+    WHERE ts.name = tags.name COLLATE NOCASE
+       OR ts.name LIKE tags.name || ' (%'
+    ORDER BY ts.sort_order ASC
+    LIMIT 1
+);
@@ -0,0 +1,11 @@
+-- =============================================
+-- Migration: Tanggal pengalaman kerja terstruktur
+-- Deskripsi: Menggantikan teks bebas period dengan tanggal mulai/selesai
+--            agar bisa diurutkan, dihitung masa kerjanya, dan dilokalisasi
+-- =============================================
+
+ALTER TABLE experiences ADD COLUMN start_date DATE;                   -- Bulan mulai (tanggal 1)
+ALTER TABLE experiences ADD COLUMN end_date DATE;                     -- Bulan selesai (NULL jika masih bekerja)
+ALTER TABLE experiences ADD COLUMN is_current INTEGER NOT NULL DEFAULT 0; -- Masih bekerja di sini (0/1)
+
+-- Parsing period lama dilakukan oleh backfill Go (internal/database/backfill.go)
@@ -0,0 +1,11 @@
+-- =============================================
+-- Migration: Hapus kolom period
+-- Deskripsi: Periode sekarang dirender dari start_date, end_date, dan is_current
+--            (lihat 005_experience_dates.sql). Teks lama yang gagal di-parse
+--            disimpan di legacy_period dan ditampilkan di dashboard sampai
+--            tanggalnya diisi admin
+-- =============================================
+
+ALTER TABLE experiences ADD COLUMN legacy_period TEXT;              -- Teks periode lama yang gagal di-parse
+UPDATE experiences SET legacy_period = period WHERE start_date IS NULL;
+ALTER TABLE experiences DROP COLUMN period;
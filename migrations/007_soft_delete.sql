@@ -0,0 +1,15 @@
+-- =============================================
+-- Migration: Soft delete
+-- Deskripsi: Konten yang dihapus dari dashboard dipindahkan ke sampah
+--            (deleted_at terisi) dan bisa dipulihkan sebelum dihapus permanen
+-- =============================================
+
+ALTER TABLE experiences ADD COLUMN deleted_at DATETIME;       -- Waktu dipindahkan ke sampah (NULL = aktif)
+ALTER TABLE projects ADD COLUMN deleted_at DATETIME;
+ALTER TABLE tech_stacks ADD COLUMN deleted_at DATETIME;
+ALTER TABLE contact_messages ADD COLUMN deleted_at DATETIME;
+
+CREATE INDEX IF NOT EXISTS idx_experiences_deleted_at ON experiences(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_projects_deleted_at ON projects(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_tech_stacks_deleted_at ON tech_stacks(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_contact_messages_deleted_at ON contact_messages(deleted_at);
@@ -0,0 +1,15 @@
+-- =============================================
+-- Migration: Riwayat revisi konten
+-- Deskripsi: Snapshot setiap konten sebelum diupdate agar perubahan
+--            bisa dibandingkan dan dikembalikan dari dashboard
+-- =============================================
+
+CREATE TABLE IF NOT EXISTS revisions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    entity TEXT NOT NULL,                   -- Jenis konten (experience/project/techstack/config)
+    entity_key TEXT NOT NULL,               -- ID konten, atau key untuk site_config
+    snapshot TEXT NOT NULL,                 -- Isi konten sebelum diupdate (JSON)
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_revisions_entity ON revisions(entity, entity_key, id);
@@ -0,0 +1,16 @@
+-- =============================================
+-- Migration: Draft & jadwal terbit
+-- Deskripsi: Konten bisa disimpan sebagai draft atau dijadwalkan terbit;
+--            hanya baris dengan published = 1 yang tampil di halaman publik
+-- =============================================
+
+ALTER TABLE experiences ADD COLUMN published INTEGER NOT NULL DEFAULT 1;   -- 1 = tampil di publik, 0 = draft/terjadwal
+ALTER TABLE experiences ADD COLUMN publish_at DATETIME;                    -- Jadwal terbit otomatis (UTC, NULL = tidak dijadwalkan)
+ALTER TABLE projects ADD COLUMN published INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE projects ADD COLUMN publish_at DATETIME;
+ALTER TABLE tech_stacks ADD COLUMN published INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE tech_stacks ADD COLUMN publish_at DATETIME;
+
+CREATE INDEX IF NOT EXISTS idx_experiences_publish_at ON experiences(published, publish_at);
+CREATE INDEX IF NOT EXISTS idx_projects_publish_at ON projects(published, publish_at);
+CREATE INDEX IF NOT EXISTS idx_tech_stacks_publish_at ON tech_stacks(published, publish_at);
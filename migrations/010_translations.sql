@@ -0,0 +1,18 @@
+-- =============================================
+-- Migration: Terjemahan konten
+-- Deskripsi: Isi konten dalam bahasa selain Indonesia (locale default)
+--            disimpan per field; field yang belum diterjemahkan
+--            jatuh kembali ke teks Indonesia di tabel asal
+-- =============================================
+
+CREATE TABLE IF NOT EXISTS translations (
+    entity TEXT NOT NULL,                   -- Jenis konten (experience/project/techstack/config)
+    entity_key TEXT NOT NULL,               -- ID konten, atau key untuk site_config
+    field TEXT NOT NULL,                    -- Nama field (misal: title, description)
+    locale TEXT NOT NULL,                   -- Kode bahasa (misal: en)
+    value TEXT NOT NULL,                    -- Teks terjemahan
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (entity, entity_key, field, locale)
+);
+
+CREATE INDEX IF NOT EXISTS idx_translations_locale ON translations(locale);
@@ -0,0 +1,11 @@
+-- =============================================
+-- Migration: Folder spam pesan kontak
+-- Deskripsi: Pesan yang skor heuristiknya tinggi (banyak link, kata terlarang)
+--            masuk folder spam, bukan kotak masuk
+-- =============================================
+
+ALTER TABLE contact_messages ADD COLUMN is_spam INTEGER NOT NULL DEFAULT 0;   -- 1 = masuk folder spam
+ALTER TABLE contact_messages ADD COLUMN spam_score INTEGER NOT NULL DEFAULT 0; -- Skor heuristik saat pesan diterima
+ALTER TABLE contact_messages ADD COLUMN spam_reasons TEXT NOT NULL DEFAULT ''; -- Alasan skor (misal: "3 link, kata terlarang: casino")
+
+CREATE INDEX IF NOT EXISTS idx_contact_messages_spam ON contact_messages(is_spam);
@@ -0,0 +1,23 @@
+-- =============================================
+-- Migration: Antrean email
+-- Deskripsi: Email notifikasi disimpan dulu di antrean lalu dikirim job latar
+--            belakang, sehingga gangguan server mail tidak menggagalkan request
+--            dan email yang gagal dicoba ulang dengan jeda yang makin panjang
+-- =============================================
+
+CREATE TABLE IF NOT EXISTS email_queue (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    to_addr TEXT NOT NULL,                  -- Alamat penerima
+    reply_to TEXT NOT NULL DEFAULT '',      -- Alamat Reply-To (opsional)
+    subject TEXT NOT NULL,                  -- Subjek email
+    text_body TEXT NOT NULL,                -- Isi versi teks biasa
+    html_body TEXT NOT NULL,                -- Isi versi HTML
+    attempts INTEGER NOT NULL DEFAULT 0,    -- Jumlah percobaan kirim
+    last_error TEXT NOT NULL DEFAULT '',    -- Error percobaan terakhir
+    next_attempt_at DATETIME NOT NULL,      -- Jadwal percobaan berikutnya (UTC)
+    sent_at DATETIME,                       -- Waktu terkirim (NULL = belum)
+    failed_at DATETIME,                     -- Waktu menyerah setelah batas percobaan (NULL = belum)
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_queue_pending ON email_queue(next_attempt_at) WHERE sent_at IS NULL AND failed_at IS NULL;
@@ -0,0 +1,37 @@
+-- =============================================
+-- Migration: Webhook keluar
+-- Deskripsi: Webhook diatur dari dashboard (URL, secret, daftar event, format preset).
+--            Setiap kejadian dicatat sebagai delivery yang dikirim job latar belakang
+--            dan dicoba ulang dengan jeda eksponensial jika gagal
+-- =============================================
+
+CREATE TABLE IF NOT EXISTS webhooks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,                     -- Nama untuk ditampilkan di dashboard
+    url TEXT NOT NULL,                      -- Endpoint tujuan (http/https)
+    secret TEXT NOT NULL DEFAULT '',        -- Kunci HMAC-SHA256 untuk header signature
+    events TEXT NOT NULL DEFAULT '',        -- Daftar event comma-separated (misal: message.created,project.updated)
+    format TEXT NOT NULL DEFAULT 'json',    -- Format payload: json, slack, discord, telegram
+    chat_id TEXT NOT NULL DEFAULT '',       -- Chat ID tujuan (khusus format telegram)
+    active INTEGER NOT NULL DEFAULT 1,      -- 0 = dijeda, tidak menerima event baru
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    webhook_id INTEGER NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event TEXT NOT NULL,                    -- Nama event (misal: message.created)
+    payload TEXT NOT NULL,                  -- Body JSON yang dikirim
+    attempts INTEGER NOT NULL DEFAULT 0,    -- Jumlah percobaan kirim
+    status_code INTEGER NOT NULL DEFAULT 0, -- Kode respons HTTP terakhir (0 = tidak ada respons)
+    response TEXT NOT NULL DEFAULT '',      -- Potongan body respons terakhir
+    last_error TEXT NOT NULL DEFAULT '',    -- Error percobaan terakhir
+    next_attempt_at DATETIME NOT NULL,      -- Jadwal percobaan berikutnya (UTC)
+    delivered_at DATETIME,                  -- Waktu berhasil terkirim (NULL = belum)
+    failed_at DATETIME,                     -- Waktu menyerah setelah batas percobaan (NULL = belum)
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_pending ON webhook_deliveries(next_attempt_at) WHERE delivered_at IS NULL AND failed_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, created_at);
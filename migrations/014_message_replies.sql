@@ -0,0 +1,24 @@
+-- =============================================
+-- Migration: Balasan pesan kontak
+-- Deskripsi: Pesan kontak punya status (new/read/replied/archived) menggantikan
+--            kolom is_read, dan balasan admin disimpan sebagai thread percakapan.
+--            Email balasan dikirim lewat antrean email (email_queue)
+-- =============================================
+
+ALTER TABLE contact_messages ADD COLUMN status TEXT NOT NULL DEFAULT 'new'; -- new, read, replied, archived
+ALTER TABLE contact_messages ADD COLUMN locale TEXT NOT NULL DEFAULT 'id';  -- Bahasa halaman saat pesan dikirim (untuk email balasan)
+
+-- Status awal dari kolom lama; is_read tidak dipakai lagi sejak migration ini
+UPDATE contact_messages SET status = 'read' WHERE is_read = 1;
+
+CREATE INDEX IF NOT EXISTS idx_contact_messages_status ON contact_messages(status);
+
+CREATE TABLE IF NOT EXISTS message_replies (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    message_id INTEGER NOT NULL REFERENCES contact_messages(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,                     -- Isi balasan admin
+    email_id INTEGER REFERENCES email_queue(id) ON DELETE SET NULL, -- Email di antrean kirim
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_message_replies_message ON message_replies(message_id, created_at);
@@ -0,0 +1,33 @@
+-- =============================================
+-- Migration: Privasi data pengunjung
+-- Deskripsi: Pesan kontak mencatat versi persetujuan privasi yang dicentang pengunjung.
+--            Email di antrean dan log delivery webhook ditautkan ke pesan asalnya agar
+--            ikut terhapus saat pesan dihapus (retensi atau permintaan penghapusan).
+--            Setiap permintaan privasi dicatat di privacy_requests
+-- =============================================
+
+ALTER TABLE contact_messages ADD COLUMN consent_version TEXT NOT NULL DEFAULT ''; -- Versi kebijakan privasi yang disetujui ('' = pesan lama sebelum ada persetujuan)
+
+ALTER TABLE email_queue ADD COLUMN message_id INTEGER REFERENCES contact_messages(id) ON DELETE CASCADE;
+ALTER TABLE webhook_deliveries ADD COLUMN message_id INTEGER REFERENCES contact_messages(id) ON DELETE CASCADE;
+
+-- Email balasan yang sudah ada bisa ditautkan lewat thread balasan
+UPDATE email_queue SET message_id = (SELECT message_id FROM message_replies WHERE email_id = email_queue.id)
+WHERE id IN (SELECT email_id FROM message_replies WHERE email_id IS NOT NULL);
+
+CREATE INDEX IF NOT EXISTS idx_email_queue_message ON email_queue(message_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_message ON webhook_deliveries(message_id);
+CREATE INDEX IF NOT EXISTS idx_contact_messages_email ON contact_messages(email COLLATE NOCASE);
+
+CREATE TABLE IF NOT EXISTS privacy_requests (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL,                     -- erase, anonymize, atau retention (purge otomatis)
+    subject_hash TEXT NOT NULL DEFAULT '',  -- HMAC-SHA256 email pemohon (email aslinya tidak disimpan)
+    subject_hint TEXT NOT NULL DEFAULT '',  -- Email tersamar untuk dashboard (misal: b***@gmail.com)
+    note TEXT NOT NULL DEFAULT '',          -- Catatan admin (misal: nomor tiket/asal permintaan)
+    messages INTEGER NOT NULL DEFAULT 0,    -- Jumlah pesan yang dihapus/dianonimkan
+    replies INTEGER NOT NULL DEFAULT 0,     -- Jumlah balasan yang dihapus/dianonimkan
+    emails INTEGER NOT NULL DEFAULT 0,      -- Jumlah email di antrean yang dihapus
+    deliveries INTEGER NOT NULL DEFAULT 0,  -- Jumlah log delivery webhook yang dihapus
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
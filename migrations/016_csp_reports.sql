@@ -0,0 +1,23 @@
+-- =============================================
+-- Migration: Laporan pelanggaran Content Security Policy
+-- Deskripsi: Browser mengirim laporan ke /csp-report setiap kali CSP memblokir
+--            (atau dalam mode report-only, akan memblokir) sebuah resource.
+--            Laporan yang sama digabung menjadi satu baris dengan penghitung
+-- =============================================
+
+CREATE TABLE IF NOT EXISTS csp_reports (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    document_uri TEXT NOT NULL DEFAULT '',  -- Halaman tempat pelanggaran terjadi (tanpa query string)
+    directive TEXT NOT NULL DEFAULT '',     -- Directive yang dilanggar (misal: script-src-elem)
+    blocked_uri TEXT NOT NULL DEFAULT '',   -- Resource yang diblokir (atau inline/eval)
+    source_file TEXT NOT NULL DEFAULT '',   -- File sumber pemicu pelanggaran
+    line_number INTEGER NOT NULL DEFAULT 0, -- Baris di source_file
+    sample TEXT NOT NULL DEFAULT '',        -- Potongan kode yang diblokir (jika dikirim browser)
+    disposition TEXT NOT NULL DEFAULT '',   -- enforce (diblokir) atau report (report-only)
+    count INTEGER NOT NULL DEFAULT 1,       -- Jumlah laporan yang sama
+    first_seen DATETIME DEFAULT CURRENT_TIMESTAMP,
+    last_seen DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (document_uri, directive, blocked_uri, source_file, line_number, disposition)
+);
+
+CREATE INDEX IF NOT EXISTS idx_csp_reports_last_seen ON csp_reports(last_seen);
@@ -0,0 +1,28 @@
+/**
+ * ADMIN.JS — Perilaku form bersama halaman admin
+ * Menggantikan atribut onsubmit/onclick/onchange inline yang diblokir Content Security Policy:
+ * - data-confirm pada <form> atau tombol submit: minta konfirmasi sebelum form dikirim
+ * - data-autosubmit pada <select>: kirim form begitu pilihan berubah
+ */
+
+(function () {
+    'use strict';
+
+    // Konfirmasi dicek saat submit agar tombol lewat Enter maupun klik sama-sama tertangkap;
+    // e.submitter memberi tombol yang menekan submit (konfirmasi per tombol, misal Hapus massal)
+    document.addEventListener('submit', function (e) {
+        var form = e.target;
+        var button = e.submitter;
+        var message = (button && button.getAttribute('data-confirm')) || form.getAttribute('data-confirm');
+        if (message && !window.confirm(message)) {
+            e.preventDefault();
+        }
+    });
+
+    document.addEventListener('change', function (e) {
+        var field = e.target;
+        if (field.hasAttribute && field.hasAttribute('data-autosubmit') && field.form) {
+            field.form.submit();
+        }
+    });
+})();
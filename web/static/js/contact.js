@@ -7,6 +7,15 @@
 (function () {
     'use strict';
 
+    /**
+     * t mengambil teks UI sesuai bahasa halaman (window.I18N diisi i18n.js)
+     * @param {string} key - Key katalog pesan (misal: js.page.cover)
+     * @param {string} fallback - Teks bawaan jika key tidak ada
+     */
+    function t(key, fallback) {
+        return (window.I18N || {})[key] || fallback;
+    }
+
     // Ambil elemen form dan feedback
     var form = document.getElementById('contact-form');
     var feedback = document.getElementById('form-feedback');
@@ -42,30 +51,148 @@
 
         // Validasi nama — minimal 2 karakter
         if (name.length < 2) {
-            showFeedback('Nama minimal 2 karakter ya!', 'error');
+            showFeedback(t('js.contact.name_short', 'Nama minimal 2 karakter ya!'), 'error');
             return null;
         }
 
         // Validasi email — format dasar
         var emailRegex = /^[^\s@]+@[^\s@]+\.[^\s@]+$/;
         if (!emailRegex.test(email)) {
-            showFeedback('Format email tidak valid.', 'error');
+            showFeedback(t('js.contact.email_invalid', 'Format email tidak valid.'), 'error');
             return null;
         }
 
         // Validasi pesan — minimal 10 karakter
         if (message.length < 10) {
-            showFeedback('Pesan minimal 10 karakter. Cerita lebih banyak dong!', 'error');
+            showFeedback(t('js.contact.message_short', 'Pesan minimal 10 karakter. Cerita lebih banyak dong!'), 'error');
             return null;
         }
 
         // Validasi pesan — maksimal 2000 karakter
         if (message.length > 2000) {
-            showFeedback('Pesan terlalu panjang (maks 2000 karakter).', 'error');
+            showFeedback(t('js.contact.message_long', 'Pesan terlalu panjang (maks 2000 karakter).'), 'error');
+            return null;
+        }
+
+        // Persetujuan penyimpanan data wajib dicentang
+        var consent = form.querySelector('[name="consent_version"]');
+        if (!consent.checked) {
+            showFeedback(t('js.contact.consent_needed', 'Centang persetujuan penyimpanan data dulu ya.'), 'error');
             return null;
         }
 
-        return { name: name, email: email, message: message };
+        return {
+            name: name,
+            email: email,
+            message: message,
+            consent_version: consent.value,
+            // Field anti-spam dikirim apa adanya (lihat form-honeypot di index.html)
+            website: form.querySelector('[name="website"]').value,
+            form_token: form.querySelector('[name="form_token"]').value
+        };
+    }
+
+    // ============================================
+    // FORM TOKEN — waktu form dimuat, bertanda tangan server
+    // ============================================
+
+    /**
+     * loadFormToken mengambil token form kontak baru (halaman di-cache, jadi token
+     * tidak ikut dirender). Server menolak form yang dikirim terlalu cepat setelah token dibuat,
+     * token yang sudah dipakai, dan token yang lebih tua dari 30 menit
+     */
+    function loadFormToken() {
+        var input = form.querySelector('[name="form_token"]');
+        return fetch(input.dataset.tokenUrl, { cache: 'no-store' })
+            .then(function (response) { return response.json(); })
+            .then(function (result) { input.value = result.token || ''; })
+            .catch(function (err) { console.error('Form token error:', err); });
+    }
+
+    loadFormToken();
+    // Perbarui token berkala agar form yang lama terbuka tidak kedaluwarsa
+    setInterval(loadFormToken, 10 * 60 * 1000);
+
+    // ============================================
+    // CAPTCHA — proof-of-work atau widget pihak ketiga
+    // ============================================
+
+    // Elemen konfigurasi CAPTCHA (tidak ada jika CAPTCHA_PROVIDER kosong)
+    var captchaBox = document.getElementById('contact-captcha');
+
+    /**
+     * leadingZeroBits menghitung jumlah bit nol di awal hash
+     * @param {Uint8Array} hash - Hasil SHA-256
+     */
+    function leadingZeroBits(hash) {
+        var n = 0;
+        for (var i = 0; i < hash.length; i++) {
+            if (hash[i] === 0) { n += 8; continue; }
+            return n + Math.clz32(hash[i]) - 24;
+        }
+        return n;
+    }
+
+    /**
+     * solveChallenge mencari nonce sehingga SHA-256("<challenge>:<nonce>")
+     * diawali minimal `difficulty` bit nol (lihat internal/captcha/pow.go)
+     * @returns {Promise<string>} - Jawaban "<challenge>:<nonce>"
+     */
+    function solveChallenge(challenge, difficulty) {
+        var encoder = new TextEncoder();
+        var nonce = 0;
+
+        function attempt() {
+            var answer = challenge + ':' + nonce;
+            return crypto.subtle.digest('SHA-256', encoder.encode(answer)).then(function (buf) {
+                if (leadingZeroBits(new Uint8Array(buf)) >= difficulty) return answer;
+                nonce++;
+                return attempt();
+            });
+        }
+        return attempt();
+    }
+
+    /**
+     * captchaError membuat error "CAPTCHA belum diselesaikan" (dibedakan dari error jaringan)
+     */
+    function captchaError() {
+        var err = new Error(t('js.contact.captcha_needed', 'Selesaikan verifikasi anti-robot dulu ya.'));
+        err.captcha = true;
+        return err;
+    }
+
+    /**
+     * getCaptcha menyiapkan jawaban CAPTCHA sebelum form dikirim
+     * @returns {Promise<string>} - Jawaban CAPTCHA (string kosong jika nonaktif)
+     */
+    function getCaptcha() {
+        if (!captchaBox) return Promise.resolve('');
+
+        if (captchaBox.dataset.provider === 'pow') {
+            if (!window.crypto || !crypto.subtle) {
+                // SubtleCrypto hanya tersedia di HTTPS/localhost
+                return Promise.reject(captchaError());
+            }
+            return fetch(captchaBox.dataset.challengeUrl, { cache: 'no-store' })
+                .then(function (response) { return response.json(); })
+                .then(function (ch) { return solveChallenge(ch.challenge, ch.difficulty); });
+        }
+
+        // Widget pihak ketiga menaruh token di input tersembunyi
+        var input = form.querySelector('[name="' + captchaBox.dataset.responseField + '"]');
+        if (!input || !input.value) {
+            return Promise.reject(captchaError());
+        }
+        return Promise.resolve(input.value);
+    }
+
+    /**
+     * resetCaptcha meminta token baru dari widget pihak ketiga (token hanya berlaku sekali)
+     */
+    function resetCaptcha() {
+        if (window.hcaptcha) window.hcaptcha.reset();
+        if (window.turnstile) window.turnstile.reset();
     }
 
     // Handle submit form
@@ -80,26 +207,41 @@
         // Tampilkan loading state
         var submitBtn = form.querySelector('.submit-btn');
         var originalText = submitBtn.textContent;
-        submitBtn.textContent = 'Mengirim...';
+        submitBtn.textContent = t('js.contact.verifying', 'Memverifikasi...');
         submitBtn.disabled = true;
 
-        // Kirim data ke backend via fetch API
-        fetch('/api/contact', {
-            method: 'POST',
-            headers: { 'Content-Type': 'application/json' },
-            body: JSON.stringify(data)
-        })
-            .then(function (response) { return response.json(); })
+        getCaptcha()
+            .then(function (answer) {
+                data.captcha = answer;
+                submitBtn.textContent = t('js.contact.sending', 'Mengirim...');
+
+                // Kirim data ke backend via fetch API
+                return fetch('/api/contact', {
+                    method: 'POST',
+                    headers: {
+                        'Content-Type': 'application/json',
+                        // Pesan balasan server mengikuti bahasa halaman
+                        'Accept-Language': document.documentElement.lang
+                    },
+                    body: JSON.stringify(data)
+                }).then(function (response) { return response.json(); });
+            })
             .then(function (result) {
                 if (result.success) {
-                    showFeedback(result.message || 'Pesan berhasil dikirim!', 'success');
+                    showFeedback(result.message || t('js.contact.sent', 'Pesan berhasil dikirim!'), 'success');
                     form.reset(); // Kosongkan form
+                    loadFormToken(); // Token hanya berlaku untuk satu pesan
                 } else {
-                    showFeedback(result.message || 'Gagal mengirim pesan.', 'error');
+                    showFeedback(result.message || t('js.contact.failed', 'Gagal mengirim pesan.'), 'error');
                 }
+                resetCaptcha();
             })
             .catch(function (err) {
-                showFeedback('Terjadi kesalahan jaringan. Coba lagi nanti.', 'error');
+                if (err && err.captcha) {
+                    showFeedback(err.message, 'error');
+                } else {
+                    showFeedback(t('js.contact.network_error', 'Terjadi kesalahan jaringan. Coba lagi nanti.'), 'error');
+                }
                 console.error('Contact form error:', err);
             })
             .finally(function () {
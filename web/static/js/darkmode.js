@@ -7,6 +7,15 @@
 (function () {
     'use strict';
 
+    /**
+     * t mengambil teks UI sesuai bahasa halaman (window.I18N diisi i18n.js)
+     * @param {string} key - Key katalog pesan (misal: js.page.cover)
+     * @param {string} fallback - Teks bawaan jika key tidak ada
+     */
+    function t(key, fallback) {
+        return (window.I18N || {})[key] || fallback;
+    }
+
     // Ambil elemen toggle
     var toggle = document.getElementById('darkmode-toggle');
     if (!toggle) return;
@@ -22,11 +31,11 @@
         if (isDark) {
             document.documentElement.setAttribute('data-theme', 'dark');
             toggle.textContent = '☀️';
-            toggle.title = 'Mode terang';
+            toggle.title = t('js.theme.light', 'Mode terang');
         } else {
             document.documentElement.removeAttribute('data-theme');
             toggle.textContent = '🌙';
-            toggle.title = 'Mode gelap';
+            toggle.title = t('js.theme.dark', 'Mode gelap');
         }
 
         // Simpan preferensi ke localStorage
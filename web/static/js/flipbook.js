@@ -7,6 +7,15 @@
 (function () {
     'use strict';
 
+    /**
+     * t mengambil teks UI sesuai bahasa halaman (window.I18N diisi i18n.js)
+     * @param {string} key - Key katalog pesan (misal: js.page.cover)
+     * @param {string} fallback - Teks bawaan jika key tidak ada
+     */
+    function t(key, fallback) {
+        return (window.I18N || {})[key] || fallback;
+    }
+
     // ============================================
     // STATE — Variabel status flip-book
     // ============================================
@@ -25,12 +34,12 @@
 
     // Label untuk setiap halaman
     const pageLabels = [
-        'Cover',
-        'About Me',
-        'Experience',
-        'Projects',
-        'Tech Stack',
-        'Contact'
+        t('js.page.cover', 'Cover'),
+        t('js.page.about', 'About Me'),
+        t('js.page.experience', 'Experience'),
+        t('js.page.projects', 'Projects'),
+        t('js.page.techstack', 'Tech Stack'),
+        t('js.page.contact', 'Contact')
     ];
 
     // ============================================
@@ -95,9 +104,9 @@
             if (currentPage === 0) {
                 indicator.textContent = pageLabels[0];
             } else if (currentPage >= totalPages) {
-                indicator.textContent = 'Back Cover';
+                indicator.textContent = t('js.page.back_cover', 'Back Cover');
             } else {
-                indicator.textContent = pageLabels[currentPage] || ('Halaman ' + currentPage);
+                indicator.textContent = pageLabels[currentPage] || (t('js.page.n', 'Halaman ') + currentPage);
             }
         }
     }
@@ -114,7 +123,7 @@
     pages.forEach(function (page, index) {
         page.addEventListener('click', function (e) {
             // Jangan balik jika klik pada link, form, input, atau button
-            if (e.target.closest('a, form, input, textarea, button, .project-card')) return;
+            if (e.target.closest('a, form, input, textarea, button, .project-card, .tech-item')) return;
 
             // Cek apakah di mode mobile (lebar < 768px) — jangan flip di mobile
             if (window.innerWidth < 768) return;
@@ -195,7 +204,7 @@
         isScrollingView = true;
         if (viewToggleBtn) {
             viewToggleBtn.textContent = '📖';
-            viewToggleBtn.title = 'Kembali ke Buku';
+            viewToggleBtn.title = t('js.view.book', 'Kembali ke Buku');
         }
     }
 
@@ -209,11 +218,11 @@
         if (isScrollingView) {
             body.classList.add('view-scrolling');
             viewToggleBtn.textContent = '📖'; // Icon buku untuk kembali
-            viewToggleBtn.title = 'Kembali ke Buku';
+            viewToggleBtn.title = t('js.view.book', 'Kembali ke Buku');
         } else {
             body.classList.remove('view-scrolling');
             viewToggleBtn.textContent = '📱'; // Icon HP untuk scroll
-            viewToggleBtn.title = 'Mode HP (Scroll)';
+            viewToggleBtn.title = t('js.view.scroll', 'Mode HP (Scroll)');
             // Restore flip state
             flipToPage(currentPage);
         }
@@ -286,4 +295,9 @@
 
     // Set state awal — mulai dari cover
     updateNavigation();
+
+    // Ekspos navigasi untuk script lain (misal: techstack.js)
+    window.Flipbook = {
+        flipToPage: flipToPage
+    };
 })();
@@ -0,0 +1,17 @@
+/**
+ * I18N.JS — Katalog teks UI untuk script halaman publik
+ * Template menaruh katalog sebagai blok JSON (bukan script inline, yang diblokir
+ * Content Security Policy); file ini membacanya ke window.I18N sebelum script lain jalan
+ */
+
+(function () {
+    'use strict';
+
+    var data = document.getElementById('i18n-messages');
+    try {
+        window.I18N = data ? JSON.parse(data.textContent) : {};
+    } catch (e) {
+        // Katalog rusak: script lain memakai teks bawaan
+        window.I18N = {};
+    }
+})();
@@ -0,0 +1,124 @@
+/**
+ * REORDER.JS — Drag-and-drop urutan tampil di dashboard admin
+ * Setiap .data-list[data-reorder] bisa diurutkan ulang dengan menyeret
+ * pegangan ⠿ di kartu; urutan baru dikirim sekaligus ke endpoint reorder
+ */
+
+(function () {
+    'use strict';
+
+    /**
+     * setupReorderList memasang drag-and-drop pada satu daftar kartu
+     * @param {HTMLElement} list - Elemen .data-list dengan atribut data-reorder (URL endpoint)
+     */
+    function setupReorderList(list) {
+        var endpoint = list.getAttribute('data-reorder');
+        var status = list.previousElementSibling && list.previousElementSibling.querySelector('.reorder-status');
+        var dragged = null;
+        var originalOrder = null;
+
+        /**
+         * currentOrder mengambil daftar ID kartu sesuai urutan di DOM
+         * @returns {number[]}
+         */
+        function currentOrder() {
+            return Array.prototype.map.call(list.querySelectorAll(':scope > .data-card'), function (card) {
+                return parseInt(card.getAttribute('data-id'), 10);
+            });
+        }
+
+        /**
+         * showStatus menampilkan hasil penyimpanan urutan di samping petunjuk
+         * @param {string} message - Pesan yang ditampilkan
+         * @param {string} type - 'success' atau 'error'
+         */
+        function showStatus(message, type) {
+            if (!status) return;
+            status.textContent = message;
+            status.className = 'reorder-status ' + type;
+        }
+
+        /**
+         * restoreOrder mengembalikan urutan kartu ke kondisi sebelum diseret
+         * Dipakai jika server menolak urutan baru
+         * @param {number[]} ids - Urutan ID awal
+         */
+        function restoreOrder(ids) {
+            ids.forEach(function (id) {
+                var card = list.querySelector(':scope > .data-card[data-id="' + id + '"]');
+                if (card) list.appendChild(card);
+            });
+        }
+
+        /**
+         * saveOrder mengirim urutan terbaru ke server
+         * @param {number[]} previous - Urutan sebelum diseret (untuk rollback tampilan)
+         */
+        function saveOrder(previous) {
+            var ids = currentOrder();
+            if (ids.join(',') === previous.join(',')) return;
+
+            showStatus('Menyimpan...', '');
+            fetch(endpoint, {
+                method: 'POST',
+                headers: { 'Content-Type': 'application/json' },
+                body: JSON.stringify({ ids: ids })
+            })
+                .then(function (response) { return response.json(); })
+                .then(function (result) {
+                    if (result.success) {
+                        showStatus(result.message || 'Urutan disimpan.', 'success');
+                    } else {
+                        restoreOrder(previous);
+                        showStatus(result.message || 'Gagal menyimpan urutan.', 'error');
+                    }
+                })
+                .catch(function () {
+                    restoreOrder(previous);
+                    showStatus('Terjadi kesalahan jaringan. Urutan dikembalikan.', 'error');
+                });
+        }
+
+        list.querySelectorAll(':scope > .data-card .drag-handle').forEach(function (handle) {
+            var card = handle.closest('.data-card');
+
+            handle.addEventListener('dragstart', function (e) {
+                dragged = card;
+                originalOrder = currentOrder();
+                card.classList.add('dragging');
+                e.dataTransfer.effectAllowed = 'move';
+                e.dataTransfer.setData('text/plain', card.getAttribute('data-id'));
+                if (e.dataTransfer.setDragImage) e.dataTransfer.setDragImage(card, 10, 10);
+            });
+
+            handle.addEventListener('dragend', function () {
+                card.classList.remove('dragging');
+                list.querySelectorAll('.drop-target').forEach(function (c) { c.classList.remove('drop-target'); });
+                if (dragged) saveOrder(originalOrder);
+                dragged = null;
+            });
+        });
+
+        // Pindahkan kartu yang diseret ke posisi kursor secara langsung
+        list.addEventListener('dragover', function (e) {
+            if (!dragged) return;
+            e.preventDefault();
+
+            var target = e.target.closest('.data-card');
+            if (!target || target === dragged || target.parentNode !== list) return;
+
+            var rect = target.getBoundingClientRect();
+            var after = e.clientY > rect.top + rect.height / 2;
+            list.insertBefore(dragged, after ? target.nextSibling : target);
+
+            list.querySelectorAll('.drop-target').forEach(function (c) { c.classList.remove('drop-target'); });
+            target.classList.add('drop-target');
+        });
+
+        list.addEventListener('drop', function (e) {
+            if (dragged) e.preventDefault();
+        });
+    }
+
+    document.querySelectorAll('.data-list[data-reorder]').forEach(setupReorderList);
+})();
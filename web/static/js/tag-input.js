@@ -0,0 +1,97 @@
+/**
+ * TAG-INPUT.JS — Input tag teknologi di dashboard admin
+ * Mengubah <input data-tag-input> (comma-separated) menjadi daftar chip
+ * dengan autocomplete dari <datalist> nama tech stack
+ */
+
+(function () {
+    'use strict';
+
+    /**
+     * setupTagInput memasang UI chip pada satu input tag
+     * Input asli disembunyikan dan tetap menjadi sumber nilai yang dikirim ke server
+     * @param {HTMLInputElement} source - Input comma-separated asli
+     */
+    function setupTagInput(source) {
+        var tags = source.value.split(',')
+            .map(function (t) { return t.trim(); })
+            .filter(function (t) { return t !== ''; });
+
+        // Bangun wrapper: [chip] [chip] [input ketik]
+        var wrapper = document.createElement('div');
+        wrapper.className = 'tag-input';
+
+        var entry = document.createElement('input');
+        entry.type = 'text';
+        entry.className = 'tag-entry';
+        entry.placeholder = source.placeholder;
+        entry.setAttribute('list', source.getAttribute('list'));
+
+        source.type = 'hidden';
+        source.removeAttribute('list');
+        source.parentNode.insertBefore(wrapper, source);
+
+        /**
+         * sync menulis ulang chip dan nilai input asli sesuai array tags
+         */
+        function sync() {
+            wrapper.querySelectorAll('.tag-chip').forEach(function (chip) { chip.remove(); });
+            tags.forEach(function (tag, index) {
+                var chip = document.createElement('span');
+                chip.className = 'tag-chip';
+                chip.textContent = tag;
+
+                var remove = document.createElement('button');
+                remove.type = 'button';
+                remove.textContent = '×';
+                remove.title = 'Hapus tag';
+                remove.addEventListener('click', function () {
+                    tags.splice(index, 1);
+                    sync();
+                });
+
+                chip.appendChild(remove);
+                wrapper.insertBefore(chip, entry);
+            });
+            source.value = tags.join(', ');
+            // Input ketik hanya wajib diisi jika belum ada tag sama sekali
+            entry.required = source.required && tags.length === 0;
+        }
+
+        /**
+         * commit menambahkan teks yang sedang diketik sebagai tag baru
+         * Tag yang sama (tanpa membedakan huruf besar/kecil) diabaikan
+         */
+        function commit() {
+            var value = entry.value.replace(/,/g, '').trim();
+            entry.value = '';
+            if (value === '') return;
+
+            var exists = tags.some(function (t) { return t.toLowerCase() === value.toLowerCase(); });
+            if (!exists) {
+                tags.push(value);
+                sync();
+            }
+        }
+
+        entry.addEventListener('keydown', function (e) {
+            if (e.key === 'Enter' || e.key === ',') {
+                e.preventDefault();
+                commit();
+            } else if (e.key === 'Backspace' && entry.value === '' && tags.length > 0) {
+                tags.pop();
+                sync();
+            }
+        });
+
+        // Pilihan dari datalist memicu event input tanpa keydown
+        entry.addEventListener('change', commit);
+        entry.addEventListener('blur', commit);
+
+        wrapper.appendChild(entry);
+        wrapper.appendChild(source);
+        sync();
+    }
+
+    document.querySelectorAll('input[data-tag-input]').forEach(setupTagInput);
+})();
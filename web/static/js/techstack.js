@@ -0,0 +1,52 @@
+/**
+ * TECHSTACK.JS — Interaksi halaman Tech Stack
+ * Klik item tech stack untuk membuka daftar proyek yang memakainya,
+ * dan klik tag di kartu proyek untuk melompat ke entri tech stack terkait
+ */
+
+(function () {
+    'use strict';
+
+    var items = document.querySelectorAll('.tech-item[tabindex]');
+
+    // Klik / Enter pada item membuka-tutup daftar proyek
+    items.forEach(function (item) {
+        item.addEventListener('click', function () {
+            item.classList.toggle('open');
+        });
+        item.addEventListener('keydown', function (e) {
+            if (e.key === 'Enter' || e.key === ' ') {
+                e.preventDefault();
+                item.classList.toggle('open');
+            }
+        });
+    });
+
+    /**
+     * showTechStack membalik buku ke halaman tech stack
+     * lalu membuka dan menyorot entri yang dituju
+     * @param {HTMLElement} item - Elemen .tech-item tujuan
+     */
+    function showTechStack(item) {
+        var page = item.closest('.page');
+        var inScrollMode = document.body.classList.contains('view-scrolling') || window.innerWidth < 768;
+
+        if (page && window.Flipbook && !inScrollMode) {
+            window.Flipbook.flipToPage(parseInt(page.getAttribute('data-page'), 10));
+        } else {
+            item.scrollIntoView({ behavior: 'smooth', block: 'center' });
+        }
+
+        item.classList.add('open', 'highlight');
+        setTimeout(function () { item.classList.remove('highlight'); }, 2000);
+    }
+
+    document.querySelectorAll('.tech-tag-link').forEach(function (link) {
+        link.addEventListener('click', function (e) {
+            var item = document.getElementById('tech-' + link.getAttribute('data-tech-stack'));
+            if (!item) return;
+            e.preventDefault();
+            showTechStack(item);
+        });
+    });
+})();